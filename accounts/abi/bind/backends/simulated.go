@@ -0,0 +1,210 @@
+// Package backends provides the contract backends for testing the Go contract bindings
+// without running a real node.
+package backends
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/accounts/abi/bind"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/node"
+	"github.com/sesanetwork/go-sesa/p2p"
+
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/gossip/contract/driverauth100"
+	"github.com/sesanetwork/go-sesa/integration"
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/sesa"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driverauth"
+	"github.com/sesanetwork/go-sesa/sesaclient"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+const (
+	// validatorID is the ID of the single fakenet validator
+	validatorID = idx.ValidatorID(1)
+	// maxCommitEvents is a max number of the events emitted by a single Commit
+	maxCommitEvents = 1000
+	// maxEpochDuration is long enough for epochs to be sealed only by the epochs advancing or gas limit
+	maxEpochDuration = 24 * time.Hour
+)
+
+var (
+	// ErrCommitTimeout is returned if the pending transactions don't get into blocks after maxCommitEvents events.
+	ErrCommitTimeout = errors.New("pending transactions aren't committed")
+
+	genesisStake = utils.Tosesa(2 * 4e6)
+
+	// ensure the interfaces are implemented
+	_ bind.ContractBackend = (*SimulatedBackend)(nil)
+	_ bind.DeployBackend   = (*SimulatedBackend)(nil)
+)
+
+// SimulatedBackend is an in-memory single-validator fakenet, which implements bind.ContractBackend
+// and bind.DeployBackend over the in-process RPC of the gossip service.
+// Events aren't emitted in background, so the pending transactions get into blocks only on Commit.
+type SimulatedBackend struct {
+	*sesaclient.Client
+
+	stack     *node.Node
+	svc       *gossip.Service
+	gdb       *gossip.Store
+	validator *integration.FakeValidator
+
+	mu sync.Mutex
+}
+
+// NewSimulatedBackend creates an in-memory single-validator fakenet. The validator account is
+// funded with the balance, and owns the network driver, i.e. it's permitted to advance epochs.
+func NewSimulatedBackend(balance *big.Int) (*SimulatedBackend, error) {
+	rules := sesa.FakeNetRules()
+	rules.Epochs.MaxEpochDuration = native.Timestamp(maxEpochDuration)
+	// every Commit creates a block
+	rules.Blocks.MaxEmptyBlockSkipPeriod = 0
+
+	genStore := makefakegenesis.FakeGenesisStoreWithRules(1, balance, genesisStake, rules)
+	defer genStore.Close()
+
+	// networkless node
+	stack, err := node.New(&node.Config{
+		Name: "simulated",
+		P2P: p2p.Config{
+			NoDiscovery: true,
+			MaxPeers:    0,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the validator emits events only on Commit
+	validator, err := integration.NewFakeValidator(stack, genStore.Genesis(), integration.FakeValidatorConfig{
+		ID:     validatorID,
+		Logger: log.Root(),
+	})
+	if err != nil {
+		_ = stack.Close()
+		return nil, err
+	}
+	b := &SimulatedBackend{
+		stack:     stack,
+		svc:       validator.Service,
+		gdb:       validator.Store,
+		validator: validator,
+	}
+
+	b.stack.RegisterAPIs(b.svc.APIs())
+	b.stack.RegisterLifecycle(b.svc)
+	if err := b.stack.Start(); err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+	client, err := b.stack.Attach()
+	if err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+	b.Client = sesaclient.NewClient(client)
+	return b, nil
+}
+
+// Key returns the private key of the validator account.
+func (b *SimulatedBackend) Key() *ecdsa.PrivateKey {
+	return makefakegenesis.FakeKey(validatorID)
+}
+
+// Transactor returns the transaction options of the validator account.
+func (b *SimulatedBackend) Transactor() (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(b.Key(), new(big.Int).SetUint64(b.gdb.GetRules().NetworkID))
+}
+
+// Commit emits events until all the pending transactions get into the finalized blocks,
+// and at least one new block is created.
+func (b *SimulatedBackend) Commit() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.commit()
+}
+
+func (b *SimulatedBackend) commit() error {
+	pending, err := b.validator.TxPool.Pending(false)
+	if err != nil {
+		return err
+	}
+	txs := make([]common.Hash, 0, len(pending))
+	for _, accTxs := range pending {
+		for _, tx := range accTxs {
+			txs = append(txs, tx.Hash())
+		}
+	}
+
+	start := b.svc.EthAPI.CurrentBlock().NumberU64()
+	for i := 0; i < maxCommitEvents; i++ {
+		if _, err := b.validator.Emitter.EmitEvent(); err != nil {
+			return err
+		}
+		b.svc.WaitBlockEnd()
+		if b.svc.EthAPI.CurrentBlock().NumberU64() > start && b.included(txs) {
+			return nil
+		}
+	}
+	return ErrCommitTimeout
+}
+
+// included returns true if all the transactions are in the blocks.
+func (b *SimulatedBackend) included(txs []common.Hash) bool {
+	for _, txid := range txs {
+		tx, _, _, err := b.svc.EthAPI.GetTransaction(context.Background(), txid)
+		if tx == nil || err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Epoch returns the current epoch.
+func (b *SimulatedBackend) Epoch() idx.Epoch {
+	return b.svc.EthAPI.CurrentEpoch(context.Background())
+}
+
+// AdvanceEpochs seals the current epoch and num-1 more epochs, as if the time passed.
+// The epochs are advanced by the network driver call from the validator account.
+func (b *SimulatedBackend) AdvanceEpochs(num idx.Epoch) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	driverAuth, err := driverauth100.NewContract(driverauth.ContractAddress, b)
+	if err != nil {
+		return err
+	}
+	opts, err := b.Transactor()
+	if err != nil {
+		return err
+	}
+	target := b.Epoch() + num
+	if _, err := driverAuth.AdvanceEpochs(opts, new(big.Int).SetUint64(uint64(num))); err != nil {
+		return err
+	}
+	// an epoch is sealed at the end of every block, until all the epochs are advanced
+	for b.Epoch() < target {
+		if err := b.commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close stops the fakenet and releases the in-memory databases.
+func (b *SimulatedBackend) Close() error {
+	err := b.stack.Close()
+	b.validator.Close()
+	return err
+}
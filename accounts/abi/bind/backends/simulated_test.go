@@ -0,0 +1,103 @@
+package backends
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/accounts/abi/bind"
+	"github.com/sesanetwork/go-sesa/core/types"
+
+	"github.com/sesanetwork/go-sesa/gossip/contract/ballot"
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestSimulatedBackend_Contract(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	backend, err := NewSimulatedBackend(utils.Tosesa(1e6))
+	require.NoError(err)
+	defer backend.Close()
+	ctx := context.Background()
+
+	opts, err := backend.Transactor()
+	require.NoError(err)
+	proposals := [][32]byte{{1}, {2}, {3}}
+	addr, tx, cBallot, err := ballot.DeployBallot(opts, backend, proposals)
+	require.NoError(err)
+
+	// the transaction is pending until the commit
+	_, err = backend.TransactionReceipt(ctx, tx.Hash())
+	require.Error(err)
+	require.NoError(backend.Commit())
+	deployed, err := bind.WaitDeployed(ctx, backend, tx)
+	require.NoError(err)
+	require.Equal(addr, deployed)
+
+	chairperson, err := cBallot.Chairperson(&bind.CallOpts{})
+	require.NoError(err)
+	require.Equal(opts.From, chairperson)
+
+	tx, err = cBallot.Vote(opts, big.NewInt(1))
+	require.NoError(err)
+	require.NoError(backend.Commit())
+	receipt, err := bind.WaitMined(ctx, backend, tx)
+	require.NoError(err)
+	require.Equal(types.ReceiptStatusSuccessful, receipt.Status)
+
+	proposal, err := cBallot.Proposals(&bind.CallOpts{}, big.NewInt(1))
+	require.NoError(err)
+	require.Equal(proposals[1], proposal.Name)
+	require.Equal(uint64(1), proposal.VoteCount.Uint64())
+}
+
+func TestSimulatedBackend_Commit(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	backend, err := NewSimulatedBackend(utils.Tosesa(1e6))
+	require.NoError(err)
+	defer backend.Close()
+	ctx := context.Background()
+
+	// every commit creates a block, even if there are no transactions
+	for i := 0; i < 3; i++ {
+		before, err := backend.BlockNumber(ctx)
+		require.NoError(err)
+		require.NoError(backend.Commit())
+		after, err := backend.BlockNumber(ctx)
+		require.NoError(err)
+		require.Greater(after, before)
+	}
+}
+
+func TestSimulatedBackend_AdvanceEpochs(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	backend, err := NewSimulatedBackend(utils.Tosesa(1e6))
+	require.NoError(err)
+	defer backend.Close()
+	ctx := context.Background()
+
+	epoch := backend.Epoch()
+	require.NoError(backend.AdvanceEpochs(3))
+	require.Equal(epoch+3, backend.Epoch())
+	current, err := backend.CurrentEpoch(ctx)
+	require.NoError(err)
+	require.Equal(epoch+3, current)
+
+	// the events emission continues in the new epoch
+	opts, err := backend.Transactor()
+	require.NoError(err)
+	_, tx, _, err := ballot.DeployBallot(opts, backend, [][32]byte{{1}})
+	require.NoError(err)
+	require.NoError(backend.Commit())
+	_, err = bind.WaitDeployed(ctx, backend, tx)
+	require.NoError(err)
+	require.Equal(epoch+3, backend.Epoch())
+}
@@ -21,6 +21,22 @@ var (
 		Usage: `Genesis sections to export separated by comma (e.g. "brs-1" or "ers" or "evm-2")`,
 		Value: "brs,ers,evm",
 	}
+	GenesisJSONFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the output in JSON format",
+	}
+	GenesisSignKeyFlag = cli.StringFlag{
+		Name:  "genesis.signkey",
+		Usage: "Private key file to sign the genesis file with",
+	}
+	GenesisSignerFlag = cli.StringFlag{
+		Name:  "genesis.signer",
+		Usage: "Address the genesis file is expected to be signed by",
+	}
+	ExportResumeFlag = cli.BoolFlag{
+		Name:  "export.resume",
+		Usage: "Continue the export after the last block of the existing file",
+	}
 	importCommand = cli.Command{
 		Name:      "import",
 		Usage:     "Import a blockchain file",
@@ -118,6 +134,28 @@ Optional second and third arguments control the first and
 last epoch to write.
 Pass dry-run instead of filename for calculation of hashes without exporting data.
 EVM export mode is configured with --export.evm.mode.
+`,
+			},
+			{
+				Name:      "blocks",
+				Usage:     "Export blocks, transactions, receipts and logs as newline-delimited JSON",
+				ArgsUsage: "<filename> [<blockFrom> <blockTo>]",
+				Action:    utils.MigrateFlags(exportBlocks),
+				Flags: []cli.Flag{
+					DataDirFlag,
+					ExportResumeFlag,
+				},
+				Description: `
+    sesa export blocks
+
+Requires a first argument of the file to write to.
+Optional second and third arguments control the first and
+last block to write. Every line of the file is a block in the format
+of eth_getBlockByNumber with full transactions, with an additional
+"receipts" field in the format of eth_getBlockReceipts.
+If the file ends with .gz, the output will be gzipped.
+Pass --export.resume to append the blocks after the last exported one.
+The node must be stopped during the export.
 `,
 			},
 			{
@@ -149,6 +187,71 @@ Requires a first argument of the file to write to.
 Optional second and third arguments control the first and
 last block to write transaction traces. If the file ends with .gz, the output will
 be gzipped
+`,
+			},
+		},
+	}
+	genesisCommand = cli.Command{
+		Name:     "genesis",
+		Usage:    "Build, inspect and verify genesis files",
+		Category: "MISCELLANEOUS COMMANDS",
+
+		Subcommands: []cli.Command{
+			{
+				Name:      "build",
+				Usage:     "Build a genesis file of a custom network from a JSON spec",
+				ArgsUsage: "<spec.json> <filename> --genesis.signkey <keyfile>",
+				Action:    utils.MigrateFlags(buildGenesis),
+				Flags: []cli.Flag{
+					GenesisSignKeyFlag,
+				},
+				Description: `
+    sesa genesis build
+
+Builds a genesis file of a custom network from a JSON spec.
+The spec contains the network ID and name, the genesis time, the network rules,
+the initial validators with their stakes, the delegations, the pre-funded accounts
+and the predeployed contracts. Rules which aren't specified in the spec are taken
+from the mainnet rules.
+The genesis header and the section hashes are signed with the --genesis.signkey
+key, the signature is written as the last section of the file.
+The written file is re-read to verify the section hashes and the signature, the
+hashes are printed along with the genesis ID and the signer address.
+The node accepts the file with --genesis.allowExperimental.
+`,
+			},
+			{
+				Name:      "inspect",
+				Usage:     "Print the content summary of a genesis file",
+				ArgsUsage: "<filename> [--json]",
+				Action:    utils.MigrateFlags(inspectGenesis),
+				Flags: []cli.Flag{
+					GenesisJSONFlag,
+				},
+				Description: `
+    sesa genesis inspect
+
+Prints the genesis header, the signer of a signed file, the sections with
+their sizes and hashes, the first and the last block and epoch records, and
+the validators set of the last epoch. The EVM section isn't read.
+`,
+			},
+			{
+				Name:      "verify",
+				Usage:     "Verify a genesis file",
+				ArgsUsage: "<filename> [--json] [--genesis.signer <address>]",
+				Action:    utils.MigrateFlags(verifyGenesis),
+				Flags: []cli.Flag{
+					GenesisJSONFlag,
+					GenesisSignerFlag,
+				},
+				Description: `
+    sesa genesis verify
+
+Fully re-hashes all the sections of a genesis file, and checks the consistency
+of the LLR block and epoch records. Exits with a non-zero code if the file
+is corrupted or inconsistent. If --genesis.signer is set, the file must be
+signed by this address.
 `,
 			},
 		},
@@ -14,6 +14,7 @@ import (
 	"github.com/naoina/toml"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/sesanetwork/go-vassalo/consensus"
+	"github.com/sesanetwork/go-vassalo/native/idx"
 	"github.com/sesanetwork/go-vassalo/utils/cachescale"
 	"github.com/sesanetwork/go-sesa/cmd/utils"
 	"github.com/sesanetwork/go-sesa/common"
@@ -30,6 +31,7 @@ import (
 	"github.com/sesanetwork/go-sesa/integration"
 	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
 	"github.com/sesanetwork/go-sesa/monitoring"
+	"github.com/sesanetwork/go-sesa/sesa"
 	"github.com/sesanetwork/go-sesa/sesa/genesis"
 	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
 	futils "github.com/sesanetwork/go-sesa/utils"
@@ -127,6 +129,20 @@ var (
 		Usage: "DO NOT RUN THIS OPTION AS VALIDATOR. Enable node records inner transaction traces for debugging purpose",
 	}
 
+	HistoryRetentionBlocksFlag = cli.Uint64Flag{
+		Name:  "history.retention.blocks",
+		Usage: "Number of the latest blocks to keep EVM logs and transaction traces for (0 = keep all)",
+	}
+	HistoryRetentionEpochsFlag = cli.Uint64Flag{
+		Name:  "history.retention.epochs",
+		Usage: "Number of the latest epochs to keep EVM logs and transaction traces for (0 = keep all)",
+	}
+
+	FreezerEpochsFlag = cli.Uint64Flag{
+		Name:  "freezer.epochs",
+		Usage: "Number of the latest epochs to keep events, blocks, txs and receipts of in the key-value DB, the older ones are moved into the freezer (0 = keep all)",
+	}
+
 	DBMigrationModeFlag = cli.StringFlag{
 		Name:  "db.migration.mode",
 		Usage: "MultiDB migration mode ('reformat' or 'rebuild')",
@@ -225,7 +241,11 @@ func mayGetGenesisStore(ctx *cli.Context) *genesisstore.Store {
 		if err != nil {
 			log.Crit("Invalid flag", "flag", FakeNetFlag.Name, "err", err)
 		}
-		return makefakegenesis.FakeGenesisStore(num, futils.Tosesa(1000000000), futils.Tosesa(5000000))
+		rules := sesa.FakeNetRules()
+		if ctx.GlobalBool(FakeNetCancunFlag.Name) {
+			rules.Upgrades.Cancun = true
+		}
+		return makefakegenesis.FakeGenesisStoreWithRules(num, futils.Tosesa(1000000000), futils.Tosesa(5000000), rules)
 	case ctx.GlobalIsSet(GenesisFlag.Name):
 		genesisPath := ctx.GlobalString(GenesisFlag.Name)
 
@@ -313,6 +333,9 @@ func setTxPool(ctx *cli.Context, cfg *evmcore.TxPoolConfig) {
 	if ctx.GlobalIsSet(utils.TxPoolJournalFlag.Name) {
 		cfg.Journal = ctx.GlobalString(utils.TxPoolJournalFlag.Name)
 	}
+	if ctx.GlobalIsSet(utils.TxPoolFullJournalFlag.Name) {
+		cfg.FullJournal = ctx.GlobalString(utils.TxPoolFullJournalFlag.Name)
+	}
 	if ctx.GlobalIsSet(utils.TxPoolRejournalFlag.Name) {
 		cfg.Rejournal = ctx.GlobalDuration(utils.TxPoolRejournalFlag.Name)
 	}
@@ -337,6 +360,9 @@ func setTxPool(ctx *cli.Context, cfg *evmcore.TxPoolConfig) {
 	if ctx.GlobalIsSet(utils.TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(utils.TxPoolLifetimeFlag.Name)
 	}
+	if ctx.GlobalIsSet(utils.TxPoolPrivateLifetimeFlag.Name) {
+		cfg.PrivateLifetime = ctx.GlobalUint64(utils.TxPoolPrivateLifetimeFlag.Name)
+	}
 }
 
 func gossipConfigWithFlags(ctx *cli.Context, src gossip.Config) (gossip.Config, error) {
@@ -562,6 +588,17 @@ func mayMakeAllConfigs(ctx *cli.Context) (*config, error) {
 		cfg.sesaStore.TraceTransactions = true
 	}
 
+	if ctx.GlobalIsSet(HistoryRetentionBlocksFlag.Name) {
+		cfg.sesaStore.HistoryRetention.Blocks = idx.Block(ctx.GlobalUint64(HistoryRetentionBlocksFlag.Name))
+	}
+	if ctx.GlobalIsSet(HistoryRetentionEpochsFlag.Name) {
+		cfg.sesaStore.HistoryRetention.Epochs = idx.Epoch(ctx.GlobalUint64(HistoryRetentionEpochsFlag.Name))
+	}
+
+	if ctx.GlobalIsSet(FreezerEpochsFlag.Name) {
+		cfg.sesaStore.Freezer.Epochs = idx.Epoch(ctx.GlobalUint64(FreezerEpochsFlag.Name))
+	}
+
 	if ctx.GlobalIsSet(EnableMonitorFlag.Name) {
 		cfg.Monitoring = setMonitoringConfig(ctx, cfg.Monitoring)
 	}
@@ -17,6 +17,7 @@
 package launcher
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -48,13 +49,14 @@ See https://github.com/sesanetwork/go-sesa/libs/wiki/JavaScript-Console.`,
 		Name:      "attach",
 		Usage:     "Start an interactive JavaScript environment (connect to node)",
 		ArgsUsage: "[endpoint]",
-		Flags:     append(consoleFlags, DataDirFlag),
+		Flags:     append(consoleFlags, DataDirFlag, utils.JWTSecretFlag),
 		Category:  "CONSOLE COMMANDS",
 		Description: `
 The sesa console is an interactive shell for the JavaScript runtime environment
 which exposes a node admin interface as well as the Ðapp JavaScript API.
 See https://github.com/sesanetwork/go-sesa/libs/wiki/JavaScript-Console.
-This command allows to open a console on a running sesa node.`,
+This command allows to open a console on a running sesa node.
+Use --authrpc.jwtsecret to attach to the authenticated HTTP/WS endpoint.`,
 	}
 
 	javascriptCommand = cli.Command{
@@ -122,7 +124,21 @@ func remoteConsole(ctx *cli.Context) error {
 		}
 		endpoint = fmt.Sprintf("%s/sesa.ipc", path)
 	}
-	client, err := dialRPC(endpoint)
+	var opts []rpc.ClientOption
+	var jwtSecret string
+	if ctx.IsSet(utils.JWTSecretFlag.Name) {
+		jwtSecret = ctx.String(utils.JWTSecretFlag.Name)
+	} else if ctx.GlobalIsSet(utils.JWTSecretFlag.Name) {
+		jwtSecret = ctx.GlobalString(utils.JWTSecretFlag.Name)
+	}
+	if jwtSecret != "" {
+		secret, err := node.LoadJWTSecret(jwtSecret, false)
+		if err != nil {
+			utils.Fatalf("Failed to load JWT secret: %v", err)
+		}
+		opts = append(opts, rpc.WithHTTPAuth(node.NewJWTAuth(secret)))
+	}
+	client, err := dialRPC(endpoint, opts...)
 	if err != nil {
 		utils.Fatalf("Unable to attach to remote sesa: %v", err)
 	}
@@ -154,14 +170,14 @@ func remoteConsole(ctx *cli.Context) error {
 // dialRPC returns a RPC client which connects to the given endpoint.
 // The check for empty endpoint implements the defaulting logic
 // for "sesa attach" and "sesa monitor" with no argument.
-func dialRPC(endpoint string) (*rpc.Client, error) {
+func dialRPC(endpoint string, opts ...rpc.ClientOption) (*rpc.Client, error) {
 	if endpoint == "" {
 		endpoint = node.DefaultIPCEndpoint(clientIdentifier)
 	} else if strings.HasPrefix(endpoint, "rpc:") || strings.HasPrefix(endpoint, "ipc:") {
 		// Backwards compatibility with rpc which required these prefixes.
 		endpoint = endpoint[4:]
 	}
-	return rpc.Dial(endpoint)
+	return rpc.DialOptions(context.Background(), endpoint, opts...)
 }
 
 // ephemeralConsole starts a new sesa node, attaches an ephemeral JavaScript
@@ -55,6 +55,38 @@ will compact all databases under datadir's chaindata.
 				Description: `
 sesa db transform
 will migrate tables layout according to the configuration.
+`,
+			},
+			{
+				Name:      "backup",
+				Usage:     "Backup all databases",
+				ArgsUsage: "<dir>",
+				Action:    utils.MigrateFlags(backupDBs),
+				Category:  "DB COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+sesa db backup <dir>
+will write a consistent backup of all databases and the freezer under datadir's
+chaindata into an empty directory. The node must be stopped. To backup a running node, call
+admin_backup("<dir>") over the IPC or a private RPC endpoint instead.
+`,
+			},
+			{
+				Name:      "restore",
+				Usage:     "Restore all databases from a backup",
+				ArgsUsage: "<dir>",
+				Action:    utils.MigrateFlags(restoreDBs),
+				Category:  "DB COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+				},
+				Description: `
+sesa db restore <dir>
+will verify the backup and restore it into datadir's chaindata, which must be empty.
+The backup is verified to contain all the databases of the manifest with all
+their records, flushed at the same block, and the frozen history of the manifest.
 `,
 			},
 			{
@@ -137,6 +169,51 @@ func compact(ctx *cli.Context) error {
 	return nil
 }
 
+func backupDBs(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	dir := ctx.Args().First()
+
+	cfg := makeAllConfigs(ctx)
+	producers := makeCheckedDBsProducers(cfg)
+	dbs := makeDirectDBsProducerFrom(producers, cfg)
+	defer dbs.Close()
+	gdb := makeGossipStore(dbs, cfg)
+	defer gdb.Close()
+
+	snapshots, err := integration.SnapshotDBs(producers, dir)
+	if err != nil {
+		return err
+	}
+	defer snapshots.Release()
+	manifest := gdb.BackupManifest()
+	log.Info("Writing DBs backup", "dir", dir, "epoch", manifest.Epoch, "block", manifest.Block)
+	err = integration.WriteBackup(dir, path.Join(cfg.Node.DataDir, "chaindata"), snapshots, manifest)
+	if err != nil {
+		return err
+	}
+	log.Info("DBs backup is written", "dir", dir, "dbs", len(manifest.DBs))
+	return nil
+}
+
+func restoreDBs(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	dir := ctx.Args().First()
+
+	cfg := makeAllConfigs(ctx)
+	chaindataDir := path.Join(cfg.Node.DataDir, "chaindata")
+	log.Info("Restoring DBs backup", "dir", dir, "chaindata", chaindataDir)
+	manifest, err := integration.RestoreBackup(dir, chaindataDir, cfg.DBs)
+	if err != nil {
+		return err
+	}
+	log.Info("DBs backup is restored", "epoch", manifest.Epoch, "block", manifest.Block, "genesis", manifest.GenesisID.Hex())
+	return nil
+}
+
 func compactDB(typ multidb.TypeName, name string, producer sesadb.DBProducer) error {
 	humanName := path.Join(string(typ), name)
 	db, err := producer.OpenDB(name)
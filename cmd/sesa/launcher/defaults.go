@@ -19,6 +19,7 @@ const (
 	DefaultP2PPort  = 5050  // Default p2p port for listening
 	DefaultHTTPPort = 18545 // Default TCP port for the HTTP RPC server
 	DefaultWSPort   = 18546 // Default TCP port for the websocket RPC server
+	DefaultAuthPort = 18551 // Default TCP port for the authenticated RPC server
 )
 
 func overrideFlags() {
@@ -26,6 +27,7 @@ func overrideFlags() {
 	utils.HTTPPortFlag.Value = DefaultHTTPPort
 	utils.LegacyRPCPortFlag.Value = DefaultHTTPPort
 	utils.WSPortFlag.Value = DefaultWSPort
+	utils.AuthPortFlag.Value = DefaultAuthPort
 }
 
 // NodeDefaultConfig contains reasonable default settings.
@@ -39,9 +41,14 @@ var NodeDefaultConfig = node.Config{
 		IdleTimeout:  120 * time.Second,
 		WriteTimeout: 120 * time.Second,
 	},
-	WSPort:              DefaultWSPort,
-	WSModules:           []string{},
-	GraphQLVirtualHosts: []string{"localhost"},
+	WSPort:               DefaultWSPort,
+	WSModules:            []string{},
+	AuthPort:             DefaultAuthPort,
+	AuthVirtualHosts:     []string{"localhost"},
+	AuthModules:          node.DefaultAuthModules,
+	BatchRequestLimit:    node.DefaultConfig.BatchRequestLimit,
+	BatchResponseMaxSize: node.DefaultConfig.BatchResponseMaxSize,
+	GraphQLVirtualHosts:  []string{"localhost"},
 	P2P: p2p.Config{
 		NoDiscovery: false, // enable discovery v4 by default
 		DiscoveryV5: true,  // enable discovery v5 by default
@@ -1,7 +1,11 @@
 package launcher
 
 import (
+	"bufio"
 	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"strconv"
@@ -15,6 +19,8 @@ import (
 
 	"github.com/sesanetwork/go-sesa/cmd/utils"
 	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/types"
 	"github.com/sesanetwork/go-sesa/log"
 	"github.com/sesanetwork/go-sesa/rlp"
 
@@ -23,8 +29,10 @@ import (
 	"github.com/sesanetwork/go-vassalo/sesadb/batched"
 	"github.com/sesanetwork/go-vassalo/sesadb/pebble"
 
+	"github.com/sesanetwork/go-sesa/ethapi"
 	"github.com/sesanetwork/go-sesa/gossip"
 	"github.com/sesanetwork/go-sesa/utils/dbutil/autocompact"
+	"github.com/sesanetwork/go-sesa/utils/signers/gsignercache"
 )
 
 var (
@@ -156,3 +164,171 @@ func exportEvmKeys(ctx *cli.Context) error {
 	log.Info("Exported EVM keys", "dir", fn)
 	return nil
 }
+
+func exportBlocks(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+
+	cfg := makeAllConfigs(ctx)
+
+	rawDbs := makeDirectDBsProducer(cfg)
+	gdb := makeGossipStore(rawDbs, cfg)
+	defer gdb.Close()
+
+	fn := ctx.Args().First()
+
+	from := idx.Block(1)
+	if len(ctx.Args()) > 1 {
+		n, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			return err
+		}
+		from = idx.Block(n)
+	}
+	to := gdb.GetLatestBlockIndex()
+	if len(ctx.Args()) > 2 {
+		n, err := strconv.ParseUint(ctx.Args().Get(2), 10, 64)
+		if err != nil {
+			return err
+		}
+		to = idx.Block(n)
+	}
+
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if ctx.Bool(ExportResumeFlag.Name) {
+		last, err := lastExportedBlock(fn)
+		if err != nil {
+			return err
+		}
+		if last >= from {
+			from = last + 1
+		}
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	// Open the file handle and potentially wrap with a gzip stream
+	fh, err := os.OpenFile(fn, mode, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	if strings.HasSuffix(fn, ".gz") {
+		writer = gzip.NewWriter(writer)
+		defer writer.(*gzip.Writer).Close()
+	}
+
+	log.Info("Exporting blocks to file", "file", fn, "from", from, "to", to)
+	err = exportBlocksTo(writer, gdb, from, to)
+	if err != nil {
+		utils.Fatalf("Export error: %v\n", err)
+	}
+
+	return nil
+}
+
+// exportBlocksTo writes the blocks with transactions, receipts and logs as newline-delimited JSON.
+func exportBlocksTo(w io.Writer, gdb *gossip.Store, from, to idx.Block) error {
+	start, reported := time.Now(), time.Time{}
+
+	var (
+		reader   = gossip.NewEvmStateReader(gdb)
+		chainCfg = gdb.GetEvmChainConfig()
+		enc      = json.NewEncoder(w)
+		counter  int
+	)
+	for n := from; n <= to; n++ {
+		block := reader.GetBlock(common.Hash{}, uint64(n))
+		if block == nil {
+			return fmt.Errorf("block %d isn't found", n)
+		}
+		signer := gsignercache.Wrap(types.MakeSigner(chainCfg, block.Number))
+		receipts := gdb.EvmStore().GetReceipts(n, signer, block.Hash, block.Transactions)
+		fields, err := ethapi.RPCMarshalBlockWithReceipts(block, receipts, signer)
+		if err != nil {
+			return fmt.Errorf("block %d: %v", n, err)
+		}
+		// the encoder terminates every value with a newline
+		err = enc.Encode(fields)
+		if err != nil {
+			return err
+		}
+		counter++
+		if time.Since(reported) >= statsReportLimit {
+			log.Info("Exporting blocks", "last", n, "exported", counter, "elapsed", common.PrettyDuration(time.Since(start)))
+			reported = time.Now()
+		}
+	}
+	log.Info("Exported blocks", "from", from, "to", to, "exported", counter, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return nil
+}
+
+// lastExportedBlock returns the number of the last block in the exported file, or 0 if the file doesn't exist.
+// An incomplete last line of a plain file is cut off, so the export may continue after it.
+func lastExportedBlock(fn string) (idx.Block, error) {
+	fh, err := os.Open(fn)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer fh.Close()
+
+	var reader io.Reader = fh
+	gzipped := strings.HasSuffix(fn, ".gz")
+	if gzipped {
+		zr, err := gzip.NewReader(fh)
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		defer zr.Close()
+		reader = zr
+	}
+
+	var (
+		last     idx.Block
+		complete int64
+		buf      = bufio.NewReader(reader)
+	)
+	for {
+		line, err := buf.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF && gzipped {
+			return 0, errors.New("gzip stream is truncated, the export can't be resumed")
+		}
+		if err != nil {
+			return 0, err
+		}
+		var fields struct {
+			Number *hexutil.Big `json:"number"`
+		}
+		if err := json.Unmarshal(line, &fields); err != nil || fields.Number == nil {
+			return 0, fmt.Errorf("malformed line after block %d", last)
+		}
+		last = idx.Block(fields.Number.ToInt().Uint64())
+		complete += int64(len(line))
+	}
+
+	if !gzipped {
+		info, err := fh.Stat()
+		if err != nil {
+			return 0, err
+		}
+		if info.Size() > complete {
+			log.Warn("Cutting off incomplete exported block", "after", last)
+			if err := os.Truncate(fn, complete); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return last, nil
+}
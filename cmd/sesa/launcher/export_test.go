@@ -0,0 +1,44 @@
+package launcher
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+)
+
+func TestLastExportedBlock(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	last, err := lastExportedBlock(filepath.Join(dir, "missing.jsonl"))
+	require.NoError(err)
+	require.Equal(idx.Block(0), last)
+
+	lines := `{"number":"0x1"}` + "\n" + `{"number":"0x2"}` + "\n"
+	plain := filepath.Join(dir, "blocks.jsonl")
+	require.NoError(os.WriteFile(plain, []byte(lines+`{"numb`), 0600))
+	last, err = lastExportedBlock(plain)
+	require.NoError(err)
+	require.Equal(idx.Block(2), last)
+	// the incomplete line is cut off
+	data, err := os.ReadFile(plain)
+	require.NoError(err)
+	require.Equal(lines, string(data))
+
+	gzipped := filepath.Join(dir, "blocks.jsonl.gz")
+	fh, err := os.Create(gzipped)
+	require.NoError(err)
+	zw := gzip.NewWriter(fh)
+	_, err = zw.Write([]byte(lines))
+	require.NoError(err)
+	require.NoError(zw.Close())
+	require.NoError(fh.Close())
+	last, err = lastExportedBlock(gzipped)
+	require.NoError(err)
+	require.Equal(idx.Block(2), last)
+}
@@ -18,6 +18,12 @@ var FakeNetFlag = cli.StringFlag{
 	Usage: "'n/N' - sets coinbase as fake n-th key from genesis of N validators.",
 }
 
+// FakeNetCancunFlag activates the Cancun upgrade in the fakenet genesis rules
+var FakeNetCancunFlag = cli.BoolFlag{
+	Name:  "fakenet.cancun",
+	Usage: "Activates the Cancun EVM upgrade (PUSH0, transient storage, MCOPY, etc) from the fakenet genesis",
+}
+
 func getFakeValidatorKey(ctx *cli.Context) *ecdsa.PrivateKey {
 	id, _, err := parseFakeGen(ctx.GlobalString(FakeNetFlag.Name))
 	if err != nil || id == 0 {
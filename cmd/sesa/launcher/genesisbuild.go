@@ -0,0 +1,170 @@
+package launcher
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-sesa/cmd/utils"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/log"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/sesanetwork/go-sesa/integration/makegenesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
+)
+
+// genesisSections are the sections of a genesis file, in the order they're written
+var genesisSections = []string{
+	genesisstore.EpochsSection(0),
+	genesisstore.BlocksSection(0),
+	genesisstore.EvmSection(0),
+}
+
+func buildGenesis(ctx *cli.Context) error {
+	if len(ctx.Args()) < 2 {
+		utils.Fatalf("This command requires two arguments.")
+	}
+	specPath, fn := ctx.Args().Get(0), ctx.Args().Get(1)
+	if !ctx.IsSet(GenesisSignKeyFlag.Name) {
+		utils.Fatalf("The genesis signing key is required, set it with --%s.", GenesisSignKeyFlag.Name)
+	}
+	key, err := crypto.LoadECDSA(ctx.String(GenesisSignKeyFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load the genesis signing key: %v", err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	spec, err := makegenesis.LoadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	log.Info("Building genesis", "network", spec.NetworkName, "id", spec.NetworkID, "validators", len(spec.Validators))
+	gStore, err := makegenesis.BuildSpecGenesisStore(spec)
+	if err != nil {
+		return err
+	}
+	defer gStore.Close()
+
+	tmpPath, err := os.MkdirTemp(filepath.Dir(fn), "genesis-tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	hashes, err := writeGenesisStore(fn, gStore, key, tmpPath)
+	if err != nil {
+		return err
+	}
+	log.Info("Written genesis file", "path", fn, "signer", signer)
+
+	// ensure the written file is readable and matches the built genesis
+	if err := verifyGenesisFile(fn, gStore.Header(), hashes, signer); err != nil {
+		return fmt.Errorf("written genesis file is corrupted: %v", err)
+	}
+	log.Info("Verified genesis file", "path", fn)
+
+	header := gStore.Header()
+	fmt.Printf("- Genesis ID: %v \n", header.GenesisID.String())
+	fmt.Printf("- Network: %s (%d) \n", header.NetworkName, header.NetworkID)
+	fmt.Printf("- Signer: %v \n", signer.String())
+	for _, name := range genesisSections {
+		if h, ok := hashes[name]; ok {
+			fmt.Printf("- Section %s hash: %v \n", name, h.String())
+		}
+	}
+	return nil
+}
+
+// writeGenesisStore writes all the sections of the genesis store into a genesis file,
+// followed by the signature of the genesis header and the section hashes.
+func writeGenesisStore(fn string, gStore *genesisstore.Store, key *ecdsa.PrivateKey, tmpPath string) (genesis.Hashes, error) {
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+
+	hashes := genesis.Hashes{}
+	for _, name := range genesisSections {
+		section, err := gStore.Section(name)
+		if err != nil {
+			// the section is empty
+			continue
+		}
+		hashes[name], err = writeGenesisUnit(fh, gStore.Header(), name, section, tmpPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sig, err := genesisstore.SignGenesis(gStore.Header(), hashes, key)
+	if err != nil {
+		return nil, err
+	}
+	hashes[genesisstore.SignatureSection], err = writeGenesisUnit(fh, gStore.Header(), genesisstore.SignatureSection, bytes.NewReader(sig), tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	return hashes, fh.Close()
+}
+
+func writeGenesisUnit(fh *os.File, header genesis.Header, name string, data io.Reader, tmpPath string) (hash.Hash, error) {
+	writer := newUnitWriter(fh)
+	err := writer.Start(header, name, tmpPath)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	_, err = io.Copy(writer, data)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	return writer.Flush()
+}
+
+// verifyGenesisFile re-reads the whole genesis file, checking the section hashes and the signer.
+func verifyGenesisFile(fn string, header genesis.Header, hashes genesis.Hashes, signer common.Address) error {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	gStore, fileHashes, err := genesisstore.OpenGenesisStore(fh)
+	if err != nil {
+		_ = fh.Close()
+		return err
+	}
+	defer gStore.Close()
+
+	if !gStore.Header().Equal(header) {
+		return errors.New("genesis header mismatch")
+	}
+	if !fileHashes.Equal(hashes) {
+		return errors.New("genesis hashes mismatch")
+	}
+	fileSigner, err := gStore.Signer(fileHashes)
+	if err != nil {
+		return fmt.Errorf("section %s: %v", genesisstore.SignatureSection, err)
+	}
+	if fileSigner != signer {
+		return fmt.Errorf("genesis is signed by %s, expected %s", fileSigner.String(), signer.String())
+	}
+	for name := range fileHashes {
+		if name == genesisstore.SignatureSection {
+			continue
+		}
+		section, err := gStore.Section(name)
+		if err != nil {
+			return fmt.Errorf("section %s: %v", name, err)
+		}
+		if _, err := io.Copy(io.Discard, section); err != nil {
+			return fmt.Errorf("section %s: %v", name, err)
+		}
+	}
+	return nil
+}
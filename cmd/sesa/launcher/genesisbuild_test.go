@@ -0,0 +1,130 @@
+package launcher
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/math"
+	"github.com/sesanetwork/go-sesa/crypto"
+
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/integration/makegenesis"
+	"github.com/sesanetwork/go-sesa/native/ier"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/sesa"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driver"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestBuildGenesisFromSpec(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	key := makefakegenesis.FakeKey(1)
+	funded := common.HexToAddress("0x1000")
+	spec := makegenesis.Spec{
+		NetworkID:   4004,
+		NetworkName: "custom",
+		Time:        1700000000,
+		Rules:       sesa.FakeNetRules(),
+		Validators: []makegenesis.SpecValidator{{
+			ID: 1,
+			PubKey: validatorpk.PubKey{
+				Raw:  crypto.FromECDSAPub(&key.PublicKey),
+				Type: validatorpk.Types.Secp256k1,
+			},
+			Stake: (*math.HexOrDecimal256)(utils.Tosesa(5000000)),
+		}},
+		Delegations: []makegenesis.SpecDelegation{{
+			Address:     funded,
+			ValidatorID: 1,
+			Stake:       (*math.HexOrDecimal256)(utils.Tosesa(1000)),
+		}},
+		Accounts: map[common.Address]makegenesis.SpecAccount{
+			funded: {
+				Balance: (*math.HexOrDecimal256)(utils.Tosesa(1000000)),
+			},
+			common.HexToAddress("0x2000"): {
+				Code:    common.FromHex("0x6001600055"),
+				Storage: map[common.Hash]common.Hash{{0x1}: {0x2}},
+			},
+		},
+	}
+	specJson, err := json.Marshal(spec)
+	require.NoError(err)
+	specPath := filepath.Join(dir, "spec.json")
+	require.NoError(os.WriteFile(specPath, specJson, 0600))
+
+	loaded, err := makegenesis.LoadSpec(specPath)
+	require.NoError(err)
+	gStore, err := makegenesis.BuildSpecGenesisStore(loaded)
+	require.NoError(err)
+	defer gStore.Close()
+
+	signKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	signer := crypto.PubkeyToAddress(signKey.PublicKey)
+
+	fn := filepath.Join(dir, "custom.g")
+	hashes, err := writeGenesisStore(fn, gStore, signKey, filepath.Join(dir, "tmp"))
+	require.NoError(err)
+	require.Len(hashes, 4)
+	require.Contains(hashes, genesisstore.SignatureSection)
+	require.NoError(verifyGenesisFile(fn, gStore.Header(), hashes, signer))
+	require.Error(verifyGenesisFile(fn, gStore.Header(), hashes, common.HexToAddress("0x1")))
+	info, err := os.Stat(fn)
+	require.NoError(err)
+	require.Zero(info.Mode().Perm()&0133, "the genesis file is executable or writable by others")
+
+	openGenesis := func() (*genesisstore.Store, genesis.Hashes) {
+		fh, err := os.Open(fn)
+		require.NoError(err)
+		fStore, fileHashes, err := genesisstore.OpenGenesisStore(fh)
+		require.NoError(err)
+		return fStore, fileHashes
+	}
+	fStore, fileHashes := openGenesis()
+	defer fStore.Close()
+	require.Equal(hashes, fileHashes)
+	fileSigner, err := fStore.Signer(fileHashes)
+	require.NoError(err)
+	require.Equal(signer, fileSigner)
+
+	// the signature doesn't match a substituted section
+	forgedStore, forged := openGenesis()
+	defer forgedStore.Close()
+	forged[genesisstore.EvmSection(0)] = hash.Hash{1}
+	forgedSigner, err := forgedStore.Signer(forged)
+	require.NoError(err)
+	require.NotEqual(signer, forgedSigner)
+	require.Equal(uint64(4004), fStore.Header().NetworkID)
+	require.Equal("custom", fStore.Header().NetworkName)
+
+	var last ier.LlrIdxFullEpochRecord
+	fStore.Epochs().ForEach(func(er ier.LlrIdxFullEpochRecord) bool {
+		last = er
+		return false
+	})
+	require.Equal(uint64(4004), last.EpochState.Rules.NetworkID)
+	require.Len(last.EpochState.Validators.IDs(), 1)
+
+	// a spec with a corrupted validator is rejected
+	spec.Delegations[0].ValidatorID = 2
+	_, err = makegenesis.BuildSpecGenesisStore(&spec)
+	require.Error(err)
+	spec.Delegations[0].ValidatorID = 1
+	spec.Accounts[driver.ContractAddress] = makegenesis.SpecAccount{
+		Balance: (*math.HexOrDecimal256)(big.NewInt(1)),
+	}
+	_, err = makegenesis.BuildSpecGenesisStore(&spec)
+	require.Error(err)
+}
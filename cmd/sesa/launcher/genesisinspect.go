@@ -0,0 +1,436 @@
+package launcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/cmd/utils"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/rlp"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/sesanetwork/go-sesa/native/ibr"
+	"github.com/sesanetwork/go-sesa/native/ier"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
+	"github.com/sesanetwork/go-sesa/utils/iodb"
+)
+
+// maxGenesisVerifyErrors limits the number of reported inconsistencies
+const maxGenesisVerifyErrors = 100
+
+type (
+	genesisHeaderInfo struct {
+		GenesisID   common.Hash `json:"genesisId"`
+		NetworkID   uint64      `json:"networkId"`
+		NetworkName string      `json:"networkName"`
+	}
+
+	genesisSectionInfo struct {
+		Name             string      `json:"name"`
+		Hash             common.Hash `json:"hash"`
+		CompressedSize   uint64      `json:"compressedSize"`
+		UncompressedSize uint64      `json:"uncompressedSize"`
+		Error            string      `json:"error,omitempty"`
+	}
+
+	genesisBlockInfo struct {
+		Number  idx.Block   `json:"number"`
+		Time    time.Time   `json:"time"`
+		Atropos common.Hash `json:"atropos"`
+		Root    common.Hash `json:"root"`
+		Txs     int         `json:"txs"`
+		GasUsed uint64      `json:"gasUsed"`
+	}
+
+	genesisEpochInfo struct {
+		Epoch      idx.Epoch   `json:"epoch"`
+		Start      time.Time   `json:"start"`
+		LastBlock  idx.Block   `json:"lastBlock"`
+		StateRoot  common.Hash `json:"stateRoot"`
+		Validators int         `json:"validators"`
+		Hash       common.Hash `json:"hash"`
+	}
+
+	genesisValidatorInfo struct {
+		ID     idx.ValidatorID `json:"id"`
+		Weight uint64          `json:"weight"`
+		PubKey string          `json:"pubkey,omitempty"`
+	}
+
+	// genesisInspection is the output of genesis inspect command
+	genesisInspection struct {
+		Header      genesisHeaderInfo      `json:"header"`
+		Signer      *common.Address        `json:"signer,omitempty"`
+		Sections    []genesisSectionInfo   `json:"sections"`
+		Blocks      uint64                 `json:"blocks"`
+		FirstBlock  *genesisBlockInfo      `json:"firstBlock,omitempty"`
+		LastBlock   *genesisBlockInfo      `json:"lastBlock,omitempty"`
+		Epochs      uint64                 `json:"epochs"`
+		FirstEpoch  *genesisEpochInfo      `json:"firstEpoch,omitempty"`
+		LastEpoch   *genesisEpochInfo      `json:"lastEpoch,omitempty"`
+		Validators  []genesisValidatorInfo `json:"validators"`
+		CheckedEvm  bool                   `json:"-"`
+		EvmItems    uint64                 `json:"evmItems,omitempty"`
+		Errors      []string               `json:"errors,omitempty"`
+		ErrorsTotal int                    `json:"errorsTotal,omitempty"`
+		Ok          bool                   `json:"ok"`
+	}
+)
+
+func newGenesisBlockInfo(br ibr.LlrIdxFullBlockRecord) *genesisBlockInfo {
+	return &genesisBlockInfo{
+		Number:  br.Idx,
+		Time:    br.Time.Time().UTC(),
+		Atropos: common.Hash(br.Atropos),
+		Root:    common.Hash(br.Root),
+		Txs:     len(br.Txs),
+		GasUsed: br.GasUsed,
+	}
+}
+
+func newGenesisEpochInfo(er ier.LlrIdxFullEpochRecord) *genesisEpochInfo {
+	info := &genesisEpochInfo{
+		Epoch:     er.Idx,
+		Start:     er.EpochState.EpochStart.Time().UTC(),
+		LastBlock: er.BlockState.LastBlock.Idx,
+		StateRoot: common.Hash(er.BlockState.FinalizedStateRoot),
+		Hash:      common.Hash(er.Hash()),
+	}
+	if er.EpochState.Validators != nil {
+		info.Validators = len(er.EpochState.Validators.IDs())
+	}
+	return info
+}
+
+// genesisSectionError is an error of reading a genesis section
+type genesisSectionError struct {
+	name string
+	err  error
+}
+
+func (e *genesisSectionError) Error() string {
+	return fmt.Sprintf("section %s: %v", e.name, e.err)
+}
+
+func (g *genesisInspection) fail(format string, args ...interface{}) {
+	g.ErrorsTotal++
+	if len(g.Errors) < maxGenesisVerifyErrors {
+		g.Errors = append(g.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+// forEachGenesisEpoch decodes the epoch records of all the epochs sections, starting from the latest record.
+func forEachGenesisEpoch(gStore *genesisstore.Store, hashes genesis.Hashes, fn func(ier.LlrIdxFullEpochRecord)) error {
+	for i := 1000; i >= 0; i-- {
+		name := genesisstore.EpochsSection(i)
+		if _, ok := hashes[name]; !ok {
+			continue
+		}
+		f, err := gStore.Section(name)
+		if err != nil {
+			return &genesisSectionError{name, err}
+		}
+		stream := rlp.NewStream(f, 0)
+		for {
+			er := ier.LlrIdxFullEpochRecord{}
+			err = stream.Decode(&er)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return &genesisSectionError{name, err}
+			}
+			fn(er)
+		}
+	}
+	return nil
+}
+
+// forEachGenesisBlock decodes the block records of all the blocks sections, starting from the latest record.
+func forEachGenesisBlock(gStore *genesisstore.Store, hashes genesis.Hashes, fn func(ibr.LlrIdxFullBlockRecord)) error {
+	for i := 1000; i >= 0; i-- {
+		name := genesisstore.BlocksSection(i)
+		if _, ok := hashes[name]; !ok {
+			continue
+		}
+		f, err := gStore.Section(name)
+		if err != nil {
+			return &genesisSectionError{name, err}
+		}
+		stream := rlp.NewStream(f, 0)
+		for {
+			br := ibr.LlrIdxFullBlockRecord{}
+			err = stream.Decode(&br)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return &genesisSectionError{name, err}
+			}
+			fn(br)
+		}
+	}
+	return nil
+}
+
+// inspectGenesisFile reads the genesis file records. If verify is true, all the sections
+// are fully re-hashed and the block and epoch records are checked for consistency.
+// Every genesis section is read only once, as sections readers cannot be rewound.
+func inspectGenesisFile(fn string, verify bool) (*genesisInspection, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	units, err := genesisstore.ReadUnits(fh)
+	if err != nil {
+		_ = fh.Close()
+		return nil, err
+	}
+	gStore, hashes, err := genesisstore.OpenGenesisStore(fh)
+	if err != nil {
+		_ = fh.Close()
+		return nil, err
+	}
+	defer gStore.Close()
+
+	header := gStore.Header()
+	g := &genesisInspection{
+		Header: genesisHeaderInfo{
+			GenesisID:   common.Hash(header.GenesisID),
+			NetworkID:   header.NetworkID,
+			NetworkName: header.NetworkName,
+		},
+		Sections:   make([]genesisSectionInfo, 0, len(units)),
+		Validators: []genesisValidatorInfo{},
+	}
+	sectionIdx := make(map[string]int, len(units))
+	for _, u := range units {
+		sectionIdx[u.UnitName] = len(g.Sections)
+		g.Sections = append(g.Sections, genesisSectionInfo{
+			Name:             u.UnitName,
+			Hash:             common.Hash(u.Hash),
+			CompressedSize:   u.CompressedSize,
+			UncompressedSize: u.UncompressedSize,
+		})
+	}
+	sectionFailed := func(err error) {
+		g.fail("%v", err)
+		if se, ok := err.(*genesisSectionError); ok {
+			g.Sections[sectionIdx[se.name]].Error = se.err.Error()
+		}
+	}
+
+	signer, err := gStore.Signer(hashes)
+	if err == nil {
+		g.Signer = &signer
+	} else if err != genesisstore.ErrUnsigned {
+		sectionFailed(&genesisSectionError{genesisstore.SignatureSection, err})
+	}
+
+	// epochs, the latest one goes first
+	epochLastBlocks := make(map[idx.Block]ier.LlrIdxFullEpochRecord)
+	var prevEpoch *ier.LlrIdxFullEpochRecord
+	err = forEachGenesisEpoch(gStore, hashes, func(er ier.LlrIdxFullEpochRecord) {
+		g.Epochs++
+		if g.LastEpoch == nil {
+			g.LastEpoch = newGenesisEpochInfo(er)
+			if vv := er.EpochState.Validators; vv != nil {
+				for _, id := range vv.SortedIDs() {
+					v := genesisValidatorInfo{
+						ID:     id,
+						Weight: uint64(vv.Get(id)),
+					}
+					if profile, ok := er.EpochState.ValidatorProfiles[id]; ok {
+						v.PubKey = profile.PubKey.String()
+					}
+					g.Validators = append(g.Validators, v)
+				}
+			}
+		}
+		g.FirstEpoch = newGenesisEpochInfo(er)
+		if !verify {
+			return
+		}
+		if er.EpochState.Epoch != er.Idx {
+			g.fail("epoch %d: epoch state refers to epoch %d", er.Idx, er.EpochState.Epoch)
+		}
+		if er.EpochState.EpochStart != er.BlockState.LastBlock.Time {
+			g.fail("epoch %d: epoch start %d doesn't match the sealing block time %d", er.Idx, er.EpochState.EpochStart, er.BlockState.LastBlock.Time)
+		}
+		if er.EpochState.Validators == nil || len(er.EpochState.Validators.IDs()) == 0 {
+			g.fail("epoch %d: empty validators set", er.Idx)
+		}
+		if prevEpoch != nil {
+			if er.Idx+1 != prevEpoch.Idx {
+				g.fail("epoch %d: followed by epoch %d", er.Idx, prevEpoch.Idx)
+			} else {
+				if prevEpoch.EpochState.PrevEpochStart != er.EpochState.EpochStart {
+					g.fail("epoch %d: previous epoch start %d doesn't match the epoch %d start %d", prevEpoch.Idx, prevEpoch.EpochState.PrevEpochStart, er.Idx, er.EpochState.EpochStart)
+				}
+				if prevEpoch.BlockState.LastBlock.Idx < er.BlockState.LastBlock.Idx {
+					g.fail("epoch %d: last block %d is lower than the epoch %d last block %d", prevEpoch.Idx, prevEpoch.BlockState.LastBlock.Idx, er.Idx, er.BlockState.LastBlock.Idx)
+				}
+			}
+		}
+		epochLastBlocks[er.BlockState.LastBlock.Idx] = er
+		prevEpoch = &er
+	})
+	if err != nil {
+		sectionFailed(err)
+	}
+
+	// blocks, the latest one goes first
+	var prevBlock *ibr.LlrIdxFullBlockRecord
+	err = forEachGenesisBlock(gStore, hashes, func(br ibr.LlrIdxFullBlockRecord) {
+		g.Blocks++
+		if g.LastBlock == nil {
+			g.LastBlock = newGenesisBlockInfo(br)
+		}
+		g.FirstBlock = newGenesisBlockInfo(br)
+		if !verify {
+			return
+		}
+		if len(br.Receipts) != len(br.Txs) {
+			g.fail("block %d: %d receipts for %d transactions", br.Idx, len(br.Receipts), len(br.Txs))
+		}
+		if prevBlock != nil {
+			if br.Idx+1 != prevBlock.Idx {
+				g.fail("block %d: followed by block %d", br.Idx, prevBlock.Idx)
+			} else if br.Time > prevBlock.Time {
+				g.fail("block %d: time %d is after the block %d time %d", br.Idx, br.Time, prevBlock.Idx, prevBlock.Time)
+			}
+		}
+		if er, ok := epochLastBlocks[br.Idx]; ok {
+			if br.Time != er.BlockState.LastBlock.Time {
+				g.fail("block %d: time %d doesn't match the epoch %d sealing block time %d", br.Idx, br.Time, er.Idx, er.BlockState.LastBlock.Time)
+			}
+			if br.Root != er.BlockState.FinalizedStateRoot {
+				g.fail("block %d: state root %s doesn't match the epoch %d state root %s", br.Idx, br.Root.String(), er.Idx, er.BlockState.FinalizedStateRoot.String())
+			}
+		}
+		prevBlock = &br
+	})
+	if err != nil {
+		sectionFailed(err)
+	}
+
+	if verify {
+		g.CheckedEvm = true
+		for i := 1000; i >= 0; i-- {
+			name := genesisstore.EvmSection(i)
+			if _, ok := sectionIdx[name]; !ok {
+				continue
+			}
+			f, err := gStore.Section(name)
+			if err != nil {
+				sectionFailed(&genesisSectionError{name, err})
+				continue
+			}
+			it := iodb.NewIterator(f)
+			for it.Next() {
+				g.EvmItems++
+			}
+			if it.Error() != nil {
+				sectionFailed(&genesisSectionError{name, it.Error()})
+			}
+			it.Release()
+		}
+	}
+	g.Ok = g.ErrorsTotal == 0
+	return g, nil
+}
+
+func printGenesisInspection(g *genesisInspection, asJSON bool) error {
+	if asJSON {
+		b, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	fmt.Printf("Genesis ID: %s\n", g.Header.GenesisID.Hex())
+	fmt.Printf("Network:    %s (%d)\n", g.Header.NetworkName, g.Header.NetworkID)
+	if g.Signer != nil {
+		fmt.Printf("Signer:     %s\n", g.Signer.Hex())
+	} else {
+		fmt.Println("Signer:     unsigned")
+	}
+	fmt.Println("Sections:")
+	for _, s := range g.Sections {
+		fmt.Printf("  %-8s hash=%s size=%d uncompressed=%d\n", s.Name, s.Hash.Hex(), s.CompressedSize, s.UncompressedSize)
+		if s.Error != "" {
+			fmt.Printf("           error: %s\n", s.Error)
+		}
+	}
+	fmt.Printf("Blocks:     %d\n", g.Blocks)
+	if g.FirstBlock != nil {
+		fmt.Printf("  first    #%d time=%s atropos=%s root=%s txs=%d\n", g.FirstBlock.Number, g.FirstBlock.Time.Format(time.RFC3339), g.FirstBlock.Atropos.Hex(), g.FirstBlock.Root.Hex(), g.FirstBlock.Txs)
+		fmt.Printf("  last     #%d time=%s atropos=%s root=%s txs=%d\n", g.LastBlock.Number, g.LastBlock.Time.Format(time.RFC3339), g.LastBlock.Atropos.Hex(), g.LastBlock.Root.Hex(), g.LastBlock.Txs)
+	}
+	fmt.Printf("Epochs:     %d\n", g.Epochs)
+	if g.FirstEpoch != nil {
+		fmt.Printf("  first    %d start=%s lastBlock=%d validators=%d hash=%s\n", g.FirstEpoch.Epoch, g.FirstEpoch.Start.Format(time.RFC3339), g.FirstEpoch.LastBlock, g.FirstEpoch.Validators, g.FirstEpoch.Hash.Hex())
+		fmt.Printf("  last     %d start=%s lastBlock=%d validators=%d hash=%s\n", g.LastEpoch.Epoch, g.LastEpoch.Start.Format(time.RFC3339), g.LastEpoch.LastBlock, g.LastEpoch.Validators, g.LastEpoch.Hash.Hex())
+		fmt.Printf("Validators of epoch %d:\n", g.LastEpoch.Epoch)
+		for _, v := range g.Validators {
+			fmt.Printf("  %-8d weight=%d pubkey=%s\n", v.ID, v.Weight, v.PubKey)
+		}
+	}
+	if g.CheckedEvm {
+		fmt.Printf("EVM items:  %d\n", g.EvmItems)
+	}
+	if len(g.Errors) != 0 {
+		fmt.Printf("Errors:     %d\n", g.ErrorsTotal)
+		for _, e := range g.Errors {
+			fmt.Printf("  %s\n", e)
+		}
+	}
+	return nil
+}
+
+func inspectGenesis(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	g, err := inspectGenesisFile(ctx.Args().First(), false)
+	if err != nil {
+		return err
+	}
+	return printGenesisInspection(g, ctx.Bool(GenesisJSONFlag.Name))
+}
+
+func verifyGenesis(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	var signer *common.Address
+	if ctx.IsSet(GenesisSignerFlag.Name) {
+		if !common.IsHexAddress(ctx.String(GenesisSignerFlag.Name)) {
+			utils.Fatalf("Invalid --%s address", GenesisSignerFlag.Name)
+		}
+		addr := common.HexToAddress(ctx.String(GenesisSignerFlag.Name))
+		signer = &addr
+	}
+	g, err := inspectGenesisFile(ctx.Args().First(), true)
+	if err != nil {
+		return err
+	}
+	err = printGenesisInspection(g, ctx.Bool(GenesisJSONFlag.Name))
+	if err != nil {
+		return err
+	}
+	if !g.Ok {
+		return errors.New("genesis file verification failed")
+	}
+	if signer != nil && (g.Signer == nil || *g.Signer != *signer) {
+		return fmt.Errorf("genesis file isn't signed by %s", signer.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package launcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestInspectGenesisFile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	gStore := makefakegenesis.FakeGenesisStore(3, utils.Tosesa(1000000), utils.Tosesa(5000000))
+	defer gStore.Close()
+	fn := filepath.Join(dir, "fake.g")
+	signKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	hashes, err := writeGenesisStore(fn, gStore, signKey, filepath.Join(dir, "tmp"))
+	require.NoError(err)
+
+	g, err := inspectGenesisFile(fn, false)
+	require.NoError(err)
+	require.True(g.Ok, g.Errors)
+	require.NotNil(g.Signer)
+	require.Equal(crypto.PubkeyToAddress(signKey.PublicKey), *g.Signer)
+	require.Equal(common.Hash(gStore.Header().GenesisID), g.Header.GenesisID)
+	require.Len(g.Sections, len(hashes))
+	for _, s := range g.Sections {
+		require.Equal(common.Hash(hashes[s.Name]), s.Hash)
+		require.NotZero(s.CompressedSize)
+		require.NotZero(s.UncompressedSize)
+	}
+	require.NotNil(g.LastBlock)
+	require.NotNil(g.LastEpoch)
+	require.Len(g.Validators, 3)
+	require.Zero(g.EvmItems)
+
+	g, err = inspectGenesisFile(fn, true)
+	require.NoError(err)
+	require.True(g.Ok, g.Errors)
+	require.NotZero(g.EvmItems)
+
+	// corrupt the data of the first section
+	fh, err := os.OpenFile(fn, os.O_RDWR, 0)
+	require.NoError(err)
+	units, err := genesisstore.ReadUnits(fh)
+	require.NoError(err)
+	pos := units[0].DataOffset + int64(units[0].CompressedSize)/2
+	b := make([]byte, 1)
+	_, err = fh.ReadAt(b, pos)
+	require.NoError(err)
+	b[0] ^= 0xff
+	_, err = fh.WriteAt(b, pos)
+	require.NoError(err)
+	require.NoError(fh.Close())
+
+	g, err = inspectGenesisFile(fn, true)
+	require.NoError(err)
+	require.False(g.Ok)
+	require.NotEmpty(g.Sections[0].Error)
+}
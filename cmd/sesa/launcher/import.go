@@ -83,6 +83,7 @@ func importEvents(ctx *cli.Context) error {
 	cfg.sesa.Protocol.EventsSemaphoreLimit.Num = math.MaxUint32
 	cfg.Emitter.Validator = emitter.ValidatorConfig{}
 	cfg.TxPool.Journal = ""
+	cfg.TxPool.FullJournal = ""
 	cfg.Node.IPCPath = ""
 	cfg.Node.HTTPHost = ""
 	cfg.Node.WSHost = ""
@@ -22,6 +22,7 @@ import (
 	"github.com/sesanetwork/go-sesa/flags"
 	"github.com/sesanetwork/go-sesa/gossip"
 	"github.com/sesanetwork/go-sesa/gossip/emitter"
+	"github.com/sesanetwork/go-sesa/graphql"
 	"github.com/sesanetwork/go-sesa/integration"
 	"github.com/sesanetwork/go-sesa/log"
 	evmetrics "github.com/sesanetwork/go-sesa/metrics"
@@ -63,6 +64,7 @@ func initFlags() {
 	// Flags for testing purpose.
 	testFlags = []cli.Flag{
 		FakeNetFlag,
+		FakeNetCancunFlag,
 	}
 
 	// Flags that configure the node.
@@ -94,6 +96,7 @@ func initFlags() {
 		utils.TxPoolLocalsFlag,
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
+		utils.TxPoolFullJournalFlag,
 		utils.TxPoolRejournalFlag,
 		utils.TxPoolPriceLimitFlag,
 		utils.TxPoolPriceBumpFlag,
@@ -102,6 +105,7 @@ func initFlags() {
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
+		utils.TxPoolPrivateLifetimeFlag,
 	}
 	sesaFlags = []cli.Flag{
 		GenesisFlag,
@@ -119,11 +123,16 @@ func initFlags() {
 		validatorIDFlag,
 		validatorPubkeyFlag,
 		validatorPasswordFlag,
+		validatorSignerFlag,
+		validatorSignerTokenFlag,
 		SyncModeFlag,
 		GCModeFlag,
 		DBPresetFlag,
 		DBMigrationModeFlag,
 		EnableTxTracerFlag,
+		HistoryRetentionBlocksFlag,
+		HistoryRetentionEpochsFlag,
+		FreezerEpochsFlag,
 		EnableMonitorFlag,
 		PrometheusMonitoringPortFlag,
 	}
@@ -136,6 +145,9 @@ func initFlags() {
 		utils.HTTPVirtualHostsFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
+		utils.GraphQLEnabledFlag,
+		utils.GraphQLCORSDomainFlag,
+		utils.GraphQLVirtualHostsFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.WSPortFlag,
@@ -145,6 +157,17 @@ func initFlags() {
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 		utils.AllowUnprotectedTxs,
+		utils.AuthEnabledFlag,
+		utils.AuthListenAddrFlag,
+		utils.AuthPortFlag,
+		utils.AuthVirtualHostsFlag,
+		utils.AuthApiFlag,
+		utils.JWTSecretFlag,
+		utils.BatchRequestLimitFlag,
+		utils.BatchResponseMaxSizeFlag,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateLimitBurstFlag,
+		utils.RPCRateLimitNamespacesFlag,
 		RPCGlobalGasCapFlag,
 		RPCGlobalTxFeeCapFlag,
 		RPCGlobalTimeoutFlag,
@@ -166,6 +189,9 @@ func initFlags() {
 		utils.MetricsInfluxDBBucketFlag,
 		utils.MetricsInfluxDBOrganizationFlag,
 		tracing.EnableFlag,
+		tracing.OtlpEndpointFlag,
+		tracing.OtlpInsecureFlag,
+		tracing.FileFlag,
 	}
 
 	nodeFlags = []cli.Flag{}
@@ -210,11 +236,14 @@ func init() {
 		importCommand,
 		exportCommand,
 		checkCommand,
+		genesisCommand,
 		// See snapshot.go
 		snapshotCommand,
 		// See dbcmd.go
 		dbCommand,
 		deleteCommand,
+		// See p2pcmd.go
+		p2pCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -320,20 +349,33 @@ func makeNode(ctx *cli.Context, cfg *config, genesisStore *genesisstore.Store) (
 		log.Info("Unlocked fake validator account", "address", coinbase.Address.Hex())
 	}
 
-	// unlock validator key
-	if !valPubkey.Empty() {
-		err := unlockValidatorKey(ctx, valPubkey, valKeystore)
+	var signer valkeystore.SignerI
+	if endpoint := ctx.GlobalString(validatorSignerFlag.Name); endpoint != "" {
+		// the validator key is held by the remote signer
+		remote, err := valkeystore.NewRemoteSigner(endpoint, readSignerToken(ctx.GlobalString(validatorSignerTokenFlag.Name)))
 		if err != nil {
-			utils.Fatalf("Failed to unlock validator key: %v", err)
+			utils.Fatalf("Failed to set up remote signer: %v", err)
 		}
+		signer = remote
+	} else {
+		// unlock validator key
+		if !valPubkey.Empty() {
+			err := unlockValidatorKey(ctx, valPubkey, valKeystore)
+			if err != nil {
+				utils.Fatalf("Failed to unlock validator key: %v", err)
+			}
+		}
+		signer = valkeystore.NewSigner(valKeystore)
 	}
-	signer := valkeystore.NewSigner(valKeystore)
 
 	// Create and register a gossip network service.
 	newTxPool := func(reader evmcore.StateReader) gossip.TxPool {
 		if cfg.TxPool.Journal != "" {
 			cfg.TxPool.Journal = stack.ResolvePath(cfg.TxPool.Journal)
 		}
+		if cfg.TxPool.FullJournal != "" {
+			cfg.TxPool.FullJournal = stack.ResolvePath(cfg.TxPool.FullJournal)
+		}
 		return evmcore.NewTxPool(cfg.TxPool, reader.Config(), reader)
 	}
 	haltCheck := func(oldEpoch, newEpoch idx.Epoch, age time.Time) bool {
@@ -361,7 +403,15 @@ func makeNode(ctx *cli.Context, cfg *config, genesisStore *genesisstore.Store) (
 		svc.RegisterEmitter(emitter.NewEmitter(cfg.Emitter, svc.EmitterWorld(signer)))
 	}
 
+	svc.RegisterBackupWriter(integration.NewBackupWriter(path.Join(cfg.Node.DataDir, "chaindata")))
+
 	stack.RegisterAPIs(svc.APIs())
+	if ctx.GlobalBool(utils.GraphQLEnabledFlag.Name) {
+		err = graphql.New(stack, svc.EthAPI, svc.EthAPI, cfg.sesa.FilterAPI, cfg.Node.GraphQLCors, cfg.Node.GraphQLVirtualHosts)
+		if err != nil {
+			utils.Fatalf("Failed to register the GraphQL service: %v", err)
+		}
+	}
 	stack.RegisterProtocols(svc.Protocols())
 	stack.RegisterLifecycle(svc)
 
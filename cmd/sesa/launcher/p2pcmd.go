@@ -0,0 +1,349 @@
+package launcher
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/cmd/utils"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/forkid"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/p2p/discover"
+	"github.com/sesanetwork/go-sesa/p2p/enode"
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+const (
+	// crawlRequestThreads is a max number of the parallel ENR requests of the crawler
+	crawlRequestThreads = 16
+	// maxNodeAge is a time after which the unresponsive nodes are removed from the nodes set
+	maxNodeAge = 24 * time.Hour
+)
+
+var (
+	CrawlTimeoutFlag = cli.DurationFlag{
+		Name:  "crawl.timeout",
+		Usage: "Time limit of the crawl",
+		Value: 5 * time.Minute,
+	}
+	CrawlV5Flag = cli.BoolFlag{
+		Name:  "crawl.v5",
+		Usage: "Crawl the discv5 network instead of discv4",
+	}
+	CrawlAddrFlag = cli.StringFlag{
+		Name:  "crawl.addr",
+		Usage: "UDP listening address of the crawler",
+		Value: "0.0.0.0:0",
+	}
+	CrawlNetworkIDFlag = cli.Uint64Flag{
+		Name:  "crawl.networkid",
+		Usage: "Network ID of the nodes to keep (0 to keep the nodes of any network)",
+		Value: sesa.MainNetworkID,
+	}
+	DNSDomainFlag = cli.StringFlag{
+		Name:  "domain",
+		Usage: "Domain name of the tree",
+	}
+	DNSSeqFlag = cli.UintFlag{
+		Name:  "seq",
+		Usage: "Sequence number of the tree (the previous one plus one by default)",
+	}
+
+	p2pCommand = cli.Command{
+		Name:     "p2p",
+		Usage:    "P2P network tools",
+		Category: "MISCELLANEOUS COMMANDS",
+
+		Subcommands: []cli.Command{
+			{
+				Name:      "crawl",
+				Usage:     "Crawl the discovery network for the sesa nodes",
+				ArgsUsage: "<nodes.json>",
+				Action:    utils.MigrateFlags(crawlNodes),
+				Flags: []cli.Flag{
+					utils.BootnodesFlag,
+					CrawlTimeoutFlag,
+					CrawlV5Flag,
+					CrawlAddrFlag,
+					CrawlNetworkIDFlag,
+				},
+				Description: `
+    sesa p2p crawl
+
+Crawls the discv4 (or discv5) network starting from the bootnodes and the nodes
+of the existing nodes file, and writes the nodes which advertise the sesa ENR entry
+of the network back into the file. The older nodes, which don't advertise the network ID,
+are kept if their fork ID matches the genesis of a known network. The nodes of the file are revalidated, and the ones
+which aren't responding for 24 hours are removed.
+The mainnet bootnodes are used if neither --bootnodes nor the nodes file is provided.
+`,
+			},
+			{
+				Name:  "dns",
+				Usage: "Build the DNS discovery trees",
+
+				Subcommands: []cli.Command{
+					{
+						Name:      "sign",
+						Usage:     "Sign the DNS discovery tree of the crawled nodes",
+						ArgsUsage: "<tree-directory> <key-file>",
+						Action:    utils.MigrateFlags(signDNSTree),
+						Flags: []cli.Flag{
+							DNSDomainFlag,
+							DNSSeqFlag,
+						},
+						Description: `
+    sesa p2p dns sign
+
+Builds the tree of the nodes from the nodes.json file of the tree directory, and the links
+from the enrtree-info.json file, and signs it with the hex private key of the key file.
+The signature, the sequence number and the tree URL are written into enrtree-info.json.
+The domain is taken from the previous tree URL, unless --domain is provided.
+`,
+					},
+					{
+						Name:      "to-zonefile",
+						Usage:     "Write the signed DNS discovery tree as a BIND zone file",
+						ArgsUsage: "<tree-directory> [<zone-file>]",
+						Action:    utils.MigrateFlags(dnsTreeToZoneFile),
+						Description: `
+    sesa p2p dns to-zonefile
+
+Writes the TXT records of the signed tree into the zone file (or to stdout), so the tree
+can be served by any DNS server which reads the BIND zone files.
+`,
+					},
+				},
+			},
+		},
+	}
+)
+
+// discoverer is the discv4 or discv5 network listener used by the crawler.
+type discoverer interface {
+	RandomNodes() enode.Iterator
+	RequestENR(*enode.Node) (*enode.Node, error)
+	Close()
+}
+
+func crawlNodes(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return errors.New("nodes file is required")
+	}
+	fn := ctx.Args().First()
+	input := make(nodeSet)
+	if common.FileExist(fn) {
+		var err error
+		input, err = loadNodeSet(fn)
+		if err != nil {
+			return err
+		}
+	}
+	bootnodes, err := parseBootnodes(ctx.String(utils.BootnodesFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(bootnodes) == 0 && len(input) == 0 {
+		bootnodes, err = parseBootnodes(strings.Join(Bootnodes["main"], ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	disc, err := startDiscovery(ctx.String(CrawlAddrFlag.Name), ctx.Bool(CrawlV5Flag.Name), bootnodes)
+	if err != nil {
+		return err
+	}
+	defer disc.Close()
+
+	c := newCrawler(input, disc)
+	output := c.run(ctx.Duration(CrawlTimeoutFlag.Name))
+	output = output.filter(sesaNodeFilter(ctx.Uint64(CrawlNetworkIDFlag.Name)), time.Now().Add(-maxNodeAge))
+	log.Info("Crawling is finished", "found", c.found, "responded", c.responded, "kept", len(output))
+	return writeNodeSet(fn, output)
+}
+
+func parseBootnodes(s string) ([]*enode.Node, error) {
+	var nodes []*enode.Node
+	for _, url := range strings.Split(s, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		n, err := enode.Parse(enode.ValidSchemes, url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bootnode %s: %v", url, err)
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// startDiscovery starts the discovery listener with a random node key.
+func startDiscovery(addr string, v5 bool, bootnodes []*enode.Node) (discoverer, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, err
+	}
+	db, err := enode.OpenDB("")
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	ln := enode.NewLocalNode(db, key)
+	cfg := discover.Config{
+		PrivateKey: key,
+		Bootnodes:  bootnodes,
+	}
+	var disc discoverer
+	if v5 {
+		disc, err = discover.ListenV5(conn, ln, cfg)
+	} else {
+		disc, err = discover.ListenV4(conn, ln, cfg)
+	}
+	if err != nil {
+		conn.Close()
+		db.Close()
+		return nil, err
+	}
+	return &closingDiscoverer{disc, db}, nil
+}
+
+// closingDiscoverer closes the nodes DB along with the listener.
+type closingDiscoverer struct {
+	discoverer
+	db *enode.DB
+}
+
+func (d *closingDiscoverer) Close() {
+	d.discoverer.Close()
+	d.db.Close()
+}
+
+// sesaNodeFilter accepts the nodes which advertise the sesa ENR entry of the network.
+// Any network is accepted if networkID is zero. The older nodes don't advertise
+// the network ID, so they are accepted if their fork ID matches the genesis of the network.
+func sesaNodeFilter(networkID uint64) func(*enode.Node) bool {
+	var forkFilter forkid.Filter
+	if genesisID, ok := networkGenesisID(networkID); ok {
+		chainConfig := sesa.Rules{NetworkID: networkID}.EvmChainConfig(nil)
+		forkFilter = forkid.NewStaticFilter(chainConfig, genesisID)
+	}
+	return func(n *enode.Node) bool {
+		var entry gossip.Enr
+		if err := n.Load(&entry); err != nil {
+			return false
+		}
+		if networkID == 0 {
+			return true
+		}
+		if entry.NetworkID != 0 {
+			return entry.NetworkID == networkID
+		}
+		return forkFilter != nil && forkFilter(entry.ForkID) == nil
+	}
+}
+
+// networkGenesisID returns the genesis ID of a known network.
+func networkGenesisID(networkID uint64) (common.Hash, bool) {
+	for _, g := range AllowedsesaGenesis {
+		if g.Header.NetworkID == networkID {
+			return common.Hash(g.Header.GenesisID), true
+		}
+	}
+	return common.Hash{}, false
+}
+
+// crawler requests the records of the nodes found by the discovery, and of the known nodes.
+type crawler struct {
+	input  nodeSet
+	output nodeSet
+	disc   discoverer
+
+	found     int
+	responded int
+	mu        sync.Mutex
+}
+
+func newCrawler(input nodeSet, disc discoverer) *crawler {
+	output := make(nodeSet, len(input))
+	for id, n := range input {
+		output[id] = n
+	}
+	return &crawler{
+		input:  input,
+		output: output,
+		disc:   disc,
+	}
+}
+
+// run crawls the network until the timeout, and returns the known and the found nodes.
+func (c *crawler) run(timeout time.Duration) nodeSet {
+	it := c.disc.RandomNodes()
+	timer := time.AfterFunc(timeout, it.Close)
+	defer timer.Stop()
+
+	var (
+		wg      sync.WaitGroup
+		threads = make(chan struct{}, crawlRequestThreads)
+		seen    = make(map[enode.ID]bool)
+	)
+	request := func(n *enode.Node) {
+		threads <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-threads }()
+			c.update(n)
+		}()
+	}
+	// revalidate the known nodes
+	for id, n := range c.input {
+		seen[id] = true
+		request(n.N)
+	}
+	for it.Next() {
+		n := it.Node()
+		if seen[n.ID()] {
+			continue
+		}
+		seen[n.ID()] = true
+		c.found++
+		request(n)
+	}
+	wg.Wait()
+	return c.output
+}
+
+// update requests the latest record of the node, as the records of the discovery packets may lack the ENR entries.
+func (c *crawler) update(n *enode.Node) {
+	rec, err := c.disc.RequestENR(n)
+	if err != nil {
+		log.Debug("Node isn't responding", "id", n.ID(), "err", err)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responded++
+	c.output[rec.ID()] = nodeJSON{
+		Seq:          rec.Seq(),
+		N:            rec,
+		LastResponse: time.Now().UTC().Truncate(time.Second),
+	}
+}
@@ -0,0 +1,253 @@
+package launcher
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/p2p/dnsdisc"
+	"github.com/sesanetwork/go-sesa/p2p/enode"
+	"gopkg.in/urfave/cli.v1"
+)
+
+const (
+	nodesFileName   = "nodes.json"
+	treeInfoName    = "enrtree-info.json"
+	zoneTTL         = 3600
+	maxTXTStringLen = 255 // max length of a single string of a TXT record
+)
+
+// nodeSet is the set of the crawled nodes, which is stored in nodes.json.
+type nodeSet map[enode.ID]nodeJSON
+
+type nodeJSON struct {
+	Seq uint64      `json:"seq"`
+	N   *enode.Node `json:"record"`
+	// LastResponse is the time of the last successful contact with the node
+	LastResponse time.Time `json:"lastResponse,omitempty"`
+}
+
+// dnsMetaJSON is the content of enrtree-info.json, which holds the tree links and its signature.
+type dnsMetaJSON struct {
+	URL          string    `json:"url,omitempty"`
+	Seq          uint      `json:"seq"`
+	Sig          string    `json:"signature,omitempty"`
+	Links        []string  `json:"links"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+func loadNodeSet(fn string) (nodeSet, error) {
+	var ns nodeSet
+	if err := loadJSON(fn, &ns); err != nil {
+		return nil, err
+	}
+	return ns, nil
+}
+
+func writeNodeSet(fn string, ns nodeSet) error {
+	return writeJSON(fn, ns)
+}
+
+// filter returns the nodes accepted by the filter, which responded after the minimum time.
+// The nodes which never responded are kept, e.g. the manually added ones.
+func (ns nodeSet) filter(accept func(*enode.Node) bool, minTime time.Time) nodeSet {
+	out := make(nodeSet, len(ns))
+	for id, n := range ns {
+		if !n.LastResponse.IsZero() && n.LastResponse.Before(minTime) {
+			continue
+		}
+		if accept(n.N) {
+			out[id] = n
+		}
+	}
+	return out
+}
+
+// nodes returns the node records, the tree sorts them by ID.
+func (ns nodeSet) nodes() []*enode.Node {
+	result := make([]*enode.Node, 0, len(ns))
+	for _, n := range ns {
+		result = append(result, n.N)
+	}
+	return result
+}
+
+func loadJSON(fn string, v interface{}) error {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %v", fn, err)
+	}
+	return nil
+}
+
+func writeJSON(fn string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(fn, data, 0644)
+}
+
+// loadTreeDef reads the nodes and the links of the tree directory, enrtree-info.json is optional.
+func loadTreeDef(dir string) (*dnsMetaJSON, nodeSet, error) {
+	nodes, err := loadNodeSet(filepath.Join(dir, nodesFileName))
+	if err != nil {
+		return nil, nil, err
+	}
+	meta := &dnsMetaJSON{}
+	if fn := filepath.Join(dir, treeInfoName); common.FileExist(fn) {
+		if err := loadJSON(fn, meta); err != nil {
+			return nil, nil, err
+		}
+	}
+	return meta, nodes, nil
+}
+
+func signDNSTree(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		return errors.New("tree directory and key file are required")
+	}
+	key, err := crypto.LoadECDSA(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	url, err := signTree(ctx.Args().First(), key, ctx.String(DNSDomainFlag.Name), ctx.Uint(DNSSeqFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Println(url)
+	return nil
+}
+
+// signTree signs the tree of the directory, and writes the signature into enrtree-info.json.
+// The previous domain is used if domain is empty, and the previous seq plus one is used if seq is zero.
+func signTree(dir string, key *ecdsa.PrivateKey, domain string, seq uint) (string, error) {
+	meta, nodes, err := loadTreeDef(dir)
+	if err != nil {
+		return "", err
+	}
+	if domain == "" {
+		if meta.URL == "" {
+			return "", errors.New("domain of the tree is required")
+		}
+		domain, _, err = dnsdisc.ParseURL(meta.URL)
+		if err != nil {
+			return "", err
+		}
+	}
+	if seq == 0 {
+		seq = meta.Seq + 1
+	}
+	t, err := dnsdisc.MakeTree(seq, nodes.nodes(), meta.Links)
+	if err != nil {
+		return "", err
+	}
+	url, err := t.Sign(key, domain)
+	if err != nil {
+		return "", err
+	}
+	meta.URL = url
+	meta.Seq = t.Seq()
+	meta.Sig = t.Signature()
+	if meta.Links == nil {
+		meta.Links = []string{}
+	}
+	meta.LastModified = time.Now().UTC().Truncate(time.Second)
+	return url, writeJSON(filepath.Join(dir, treeInfoName), meta)
+}
+
+// loadSignedTree reads the tree of the directory, and checks its signature.
+func loadSignedTree(dir string) (string, *dnsdisc.Tree, error) {
+	meta, nodes, err := loadTreeDef(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	if meta.URL == "" || meta.Sig == "" {
+		return "", nil, errors.New("tree isn't signed")
+	}
+	domain, pubkey, err := dnsdisc.ParseURL(meta.URL)
+	if err != nil {
+		return "", nil, err
+	}
+	t, err := dnsdisc.MakeTree(meta.Seq, nodes.nodes(), meta.Links)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := t.SetSignature(pubkey, meta.Sig); err != nil {
+		return "", nil, err
+	}
+	return domain, t, nil
+}
+
+func dnsTreeToZoneFile(ctx *cli.Context) error {
+	if len(ctx.Args()) < 1 || len(ctx.Args()) > 2 {
+		return errors.New("tree directory is required")
+	}
+	domain, t, err := loadSignedTree(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	if len(ctx.Args()) == 1 {
+		return writeZoneFile(os.Stdout, domain, t)
+	}
+	f, err := os.Create(ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	if err := writeZoneFile(f, domain, t); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeZoneFile writes the TXT records of the tree in the BIND zone file format.
+// The root record goes first, and the rest records are sorted by the subdomain.
+func writeZoneFile(w io.Writer, domain string, t *dnsdisc.Tree) error {
+	records := t.ToTXT(domain)
+	names := make([]string, 0, len(records))
+	for name := range records {
+		if name != domain {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if _, err := fmt.Fprintf(w, "$ORIGIN %s.\n$TTL %d\n", domain, zoneTTL); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "@\tIN\tTXT\t%s\n", zoneTXT(records[domain])); err != nil {
+		return err
+	}
+	for _, name := range names {
+		sub := strings.TrimSuffix(name, "."+domain)
+		if _, err := fmt.Fprintf(w, "%s\tIN\tTXT\t%s\n", sub, zoneTXT(records[name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// zoneTXT splits the TXT record content into the quoted strings of the max allowed length.
+func zoneTXT(content string) string {
+	var parts []string
+	for len(content) > maxTXTStringLen {
+		parts = append(parts, `"`+content[:maxTXTStringLen]+`"`)
+		content = content[maxTXTStringLen:]
+	}
+	parts = append(parts, `"`+content+`"`)
+	return strings.Join(parts, " ")
+}
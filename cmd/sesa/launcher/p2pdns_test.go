@@ -0,0 +1,180 @@
+package launcher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/forkid"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/p2p/dnsdisc"
+	"github.com/sesanetwork/go-sesa/p2p/enode"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+// zoneResolver serves the TXT records of a BIND zone file written by writeZoneFile.
+type zoneResolver map[string]string
+
+func parseZoneFile(t *testing.T, zone []byte) zoneResolver {
+	r := make(zoneResolver)
+	var origin string
+	s := bufio.NewScanner(bytes.NewReader(zone))
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(line, "$ORIGIN ") {
+			origin = strings.TrimSuffix(strings.TrimPrefix(line, "$ORIGIN "), ".")
+			continue
+		}
+		if strings.HasPrefix(line, "$") {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 || fields[1] != "IN" || fields[2] != "TXT" {
+			t.Fatalf("invalid zone line %q", line)
+		}
+		name := origin
+		if fields[0] != "@" {
+			name = fields[0] + "." + origin
+		}
+		var content string
+		for _, part := range strings.Split(fields[3], `" "`) {
+			part = strings.Trim(part, `"`)
+			if len(part) > maxTXTStringLen {
+				t.Fatalf("too long TXT string of %s", name)
+			}
+			content += part
+		}
+		r[name] = content
+	}
+	return r
+}
+
+func (r zoneResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if content, ok := r[name]; ok {
+		return []string{content}, nil
+	}
+	return nil, fmt.Errorf("%s isn't found", name)
+}
+
+func testSesaNode(t *testing.T, networkID uint64) *enode.Node {
+	if networkID == 0 {
+		return testEnrNode(t, nil)
+	}
+	return testEnrNode(t, &gossip.Enr{NetworkID: networkID})
+}
+
+func testEnrNode(t *testing.T, entry *gossip.Enr) *enode.Node {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	defer db.Close()
+	ln := enode.NewLocalNode(db, key)
+	if entry != nil {
+		ln.Set(entry)
+	}
+	return ln.Node()
+}
+
+func TestSesaNodeFilter(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UTC()
+	ns := nodeSet{}
+	add := func(n *enode.Node, lastResponse time.Time) *enode.Node {
+		ns[n.ID()] = nodeJSON{Seq: n.Seq(), N: n, LastResponse: lastResponse}
+		return n
+	}
+	fresh := add(testSesaNode(t, 1), now)
+	manual := add(testSesaNode(t, 1), time.Time{})
+	add(testSesaNode(t, 1), now.Add(-2*maxNodeAge))
+	other := add(testSesaNode(t, 2), now)
+	add(testSesaNode(t, 0), now)
+
+	out := ns.filter(sesaNodeFilter(1), now.Add(-maxNodeAge))
+	require.Len(out, 2)
+	require.Contains(out, fresh.ID())
+	require.Contains(out, manual.ID())
+
+	out = ns.filter(sesaNodeFilter(0), now.Add(-maxNodeAge))
+	require.Len(out, 3)
+	require.Contains(out, other.ID())
+}
+
+func TestSesaNodeFilter_ForkID(t *testing.T) {
+	require := require.New(t)
+
+	networkID := sesa.MainNetworkID
+	genesisID, ok := networkGenesisID(networkID)
+	require.True(ok)
+	chainConfig := sesa.Rules{NetworkID: networkID}.EvmChainConfig(nil)
+	older := testEnrNode(t, &gossip.Enr{ForkID: forkid.NewID(chainConfig, genesisID, 100)})
+	otherGenesis := testEnrNode(t, &gossip.Enr{ForkID: forkid.NewID(chainConfig, common.Hash{1}, 100)})
+	newer := testEnrNode(t, &gossip.Enr{ForkID: forkid.NewID(chainConfig, genesisID, 100), NetworkID: sesa.TestNetworkID})
+
+	// the nodes without the network ID are matched by the fork ID
+	require.True(sesaNodeFilter(networkID)(older))
+	require.False(sesaNodeFilter(networkID)(otherGenesis))
+	// the network ID takes precedence over the fork ID
+	require.False(sesaNodeFilter(networkID)(newer))
+	require.True(sesaNodeFilter(sesa.TestNetworkID)(newer))
+	// the fork ID isn't matched if the network genesis is unknown
+	require.False(sesaNodeFilter(1)(older))
+	require.True(sesaNodeFilter(0)(older))
+}
+
+func TestDNSTreeZoneFile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	ns := nodeSet{}
+	for i := 0; i < 20; i++ {
+		n := testSesaNode(t, 1)
+		ns[n.ID()] = nodeJSON{Seq: n.Seq(), N: n}
+	}
+	require.NoError(writeNodeSet(filepath.Join(dir, nodesFileName), ns))
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	_, err = signTree(dir, key, "", 0)
+	require.Error(err, "domain is required for the first tree")
+	url, err := signTree(dir, key, "nodes.example.org", 0)
+	require.NoError(err)
+	domain, pubkey, err := dnsdisc.ParseURL(url)
+	require.NoError(err)
+	require.Equal("nodes.example.org", domain)
+	require.Equal(key.PublicKey, *pubkey)
+
+	// the domain is kept, and the seq is increased
+	url, err = signTree(dir, key, "", 0)
+	require.NoError(err)
+	domain, t2, err := loadSignedTree(dir)
+	require.NoError(err)
+	require.Equal("nodes.example.org", domain)
+	require.Equal(uint(2), t2.Seq())
+
+	var zone bytes.Buffer
+	require.NoError(writeZoneFile(&zone, domain, t2))
+	resolver := parseZoneFile(t, zone.Bytes())
+	require.Equal(t2.ToTXT(domain), map[string]string(resolver))
+
+	client := dnsdisc.NewClient(dnsdisc.Config{Resolver: resolver, RateLimit: 1000})
+	synced, err := client.SyncTree(url)
+	require.NoError(err)
+	require.Equal(uint(2), synced.Seq())
+	nodes := synced.Nodes()
+	require.Len(nodes, len(ns))
+	for _, n := range nodes {
+		require.Contains(ns, n.ID())
+		require.True(sesaNodeFilter(1)(n))
+	}
+}
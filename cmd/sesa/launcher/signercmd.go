@@ -0,0 +1,101 @@
+package launcher
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/sesanetwork/go-sesa/cmd/utils"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/valkeystore"
+)
+
+var signerListenFlag = cli.StringFlag{
+	Name:  "signer.listen",
+	Usage: "Address of the remote signer to listen on (host:port or unix:///path/to/socket), <DATADIR>/signer.ipc by default",
+	Value: "",
+}
+
+// readSignerToken reads the remote signer token from the file.
+func readSignerToken(path string) string {
+	if path == "" {
+		utils.Fatalf("Remote signer token file isn't specified, use --%s", validatorSignerTokenFlag.Name)
+	}
+	token, err := ioutil.ReadFile(path)
+	if err != nil {
+		utils.Fatalf("Failed to read remote signer token: %v", err)
+	}
+	trimmed := strings.TrimSpace(string(token))
+	if trimmed == "" {
+		utils.Fatalf("Remote signer token file %s is empty", path)
+	}
+	return trimmed
+}
+
+// validatorSigner runs the remote signer of the validator events.
+func validatorSigner(ctx *cli.Context) error {
+	cfg := makeAllConfigs(ctx)
+	utils.SetNodeConfig(ctx, &cfg.Node)
+
+	pubkey, err := validatorpk.FromString(ctx.GlobalString(validatorPubkeyFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to decode the validator pubkey: %v", err)
+	}
+	token := readSignerToken(ctx.GlobalString(validatorSignerTokenFlag.Name))
+
+	valKeystore := valkeystore.NewDefaultFileKeystore(path.Join(getValKeystoreDir(cfg.Node), "validator"))
+	if err := unlockValidatorKey(ctx, pubkey, valKeystore); err != nil {
+		utils.Fatalf("Failed to unlock validator key: %v", err)
+	}
+
+	guardDir := cfg.Node.ResolvePath("signer")
+	if err := os.MkdirAll(guardDir, 0700); err != nil {
+		utils.Fatalf("Failed to create signer directory: %v", err)
+	}
+	guard, err := valkeystore.NewSignGuard(path.Join(guardDir, "signed.json"))
+	if err != nil {
+		utils.Fatalf("Failed to load signed events: %v", err)
+	}
+
+	listen := ctx.GlobalString(signerListenFlag.Name)
+	if listen == "" {
+		listen = "unix://" + cfg.Node.ResolvePath("signer.ipc")
+	}
+	var listener net.Listener
+	if strings.HasPrefix(listen, "unix://") {
+		socket := strings.TrimPrefix(listen, "unix://")
+		_ = os.Remove(socket)
+		listener, err = net.Listen("unix", socket)
+		if err == nil {
+			err = os.Chmod(socket, 0600)
+		}
+	} else {
+		listener, err = net.Listen("tcp", listen)
+	}
+	if err != nil {
+		utils.Fatalf("Failed to listen on %s: %v", listen, err)
+	}
+
+	srv := &http.Server{Handler: valkeystore.NewRemoteSignerServer(valkeystore.NewSigner(valKeystore), guard, token)}
+	go func() {
+		sigc := make(chan os.Signal, 1)
+		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+		<-sigc
+		log.Info("Got interrupt, shutting down...")
+		_ = srv.Close()
+	}()
+
+	log.Info("Remote signer started", "listen", listen, "pubkey", pubkey.String())
+	if err := srv.Serve(listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -1,12 +1,20 @@
 package tracing
 
 import (
-	opentracing "github.com/opentracing/opentracing-go"
-	jaegercfg "github.com/uber/jaeger-client-go/config"
-	jaegerlog "github.com/uber/jaeger-client-go/log"
-	"github.com/uber/jaeger-lib/metrics"
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gopkg.in/urfave/cli.v1"
 
+	"github.com/sesanetwork/go-sesa/log"
 	"github.com/sesanetwork/go-sesa/tracing"
 )
 
@@ -15,6 +23,25 @@ var EnableFlag = cli.BoolFlag{
 	Usage: "Enable traces collection and reporting",
 }
 
+var OtlpEndpointFlag = cli.StringFlag{
+	Name:  "tracing.otlp.endpoint",
+	Usage: "OTLP/gRPC collector endpoint to export traces to",
+	Value: "localhost:4317",
+}
+
+var OtlpInsecureFlag = cli.BoolFlag{
+	Name:  "tracing.otlp.insecure",
+	Usage: "Disable TLS of the OTLP/gRPC connection",
+}
+
+var FileFlag = cli.StringFlag{
+	Name:  "tracing.file",
+	Usage: "Export traces as JSON into the file instead of the OTLP collector",
+}
+
+// shutdownTimeout is the time limit to flush the remaining spans on stop.
+const shutdownTimeout = 5 * time.Second
+
 func Start(ctx *cli.Context) (stop func(), err error) {
 	stop = func() {}
 
@@ -22,26 +49,51 @@ func Start(ctx *cli.Context) (stop func(), err error) {
 		return
 	}
 
-	var cfg *jaegercfg.Configuration
-	cfg, err = jaegercfg.FromEnv()
-	if err != nil {
-		return
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+	)
+	if fn := ctx.String(FileFlag.Name); fn != "" {
+		var fh *os.File
+		fh, err = os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(fh))
+		if err != nil {
+			fh.Close()
+			return
+		}
+		closer = fh
+	} else {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(ctx.String(OtlpEndpointFlag.Name)),
+		}
+		if ctx.Bool(OtlpInsecureFlag.Name) {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return
+		}
 	}
 
-	cfg.ServiceName = "sesa"
-
-	tracer, closer, err := cfg.NewTracer(
-		jaegercfg.Logger(jaegerlog.StdLogger),
-		jaegercfg.Metrics(metrics.NullFactory),
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "sesa"))),
 	)
-	if err != nil {
-		return
-	}
 	stop = func() {
-		closer.Close()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := provider.Shutdown(shutdownCtx); err != nil {
+			log.Warn("Failed to flush traces", "err", err)
+		}
+		if closer != nil {
+			closer.Close()
+		}
 	}
 
-	opentracing.SetGlobalTracer(tracer)
+	otel.SetTracerProvider(provider)
 	tracing.SetEnabled(true)
 	return
 }
@@ -29,6 +29,18 @@ var validatorPasswordFlag = cli.StringFlag{
 	Value: "",
 }
 
+var validatorSignerFlag = cli.StringFlag{
+	Name:  "validator.signer",
+	Usage: "Endpoint of a remote signer holding the validator key (http://host:port or unix:///path/to/socket)",
+	Value: "",
+}
+
+var validatorSignerTokenFlag = cli.StringFlag{
+	Name:  "validator.signer.token",
+	Usage: "File containing the token to authenticate in the remote signer",
+	Value: "",
+}
+
 // setValidatorID retrieves the validator ID either from the directly specified
 // command line flags or from the keystore if CLI indexed.
 func setValidator(ctx *cli.Context, cfg *emitter.Config) error {
@@ -79,6 +79,29 @@ password to file or expose in any other way.
     sesa validator convert
 
 Converts an account private key to a validator private key and saves in the validator keystore.
+`,
+			},
+			{
+				Name:   "signer",
+				Usage:  "Run a remote signer of the validator events",
+				Action: utils.MigrateFlags(validatorSigner),
+				Flags: []cli.Flag{
+					DataDirFlag,
+					utils.KeyStoreDirFlag,
+					validatorPubkeyFlag,
+					validatorPasswordFlag,
+					validatorSignerTokenFlag,
+					signerListenFlag,
+				},
+				Description: `
+    sesa validator signer --validator.pubkey <pubkey> --validator.signer.token <file>
+
+Unlocks the validator key and signs the events of a node started with the
+--validator.signer flag, so the key is never loaded into the node process.
+
+The signer refuses to sign an event which conflicts with an already signed one
+(the same or a lower sequence number of the same epoch). The last signed events
+are recorded under <DATADIR>/signer, keep this directory when moving the signer.
 `,
 			},
 		},
@@ -279,9 +279,13 @@ var (
 	}
 	TxPoolRejournalFlag = cli.DurationFlag{
 		Name:  "txpool.rejournal",
-		Usage: "Time interval to regenerate the local transaction journal",
+		Usage: "Time interval to regenerate the transaction journals",
 		Value: core.DefaultTxPoolConfig.Rejournal,
 	}
+	TxPoolFullJournalFlag = cli.StringFlag{
+		Name:  "txpool.fulljournal",
+		Usage: "Disk journal for all the pending and queued transactions to survive node restarts (disabled if empty)",
+	}
 	TxPoolPriceLimitFlag = cli.Uint64Flag{
 		Name:  "txpool.pricelimit",
 		Usage: "Minimum gas price limit to enforce for acceptance into the pool",
@@ -317,6 +321,11 @@ var (
 		Usage: "Maximum amount of time non-executable transaction are queued",
 		Value: ethconfig.Defaults.TxPool.Lifetime,
 	}
+	TxPoolPrivateLifetimeFlag = cli.Uint64Flag{
+		Name:  "txpool.privatelifetime",
+		Usage: "Number of blocks private transactions aren't broadcast for",
+		Value: core.DefaultTxPoolConfig.PrivateLifetime,
+	}
 	// Performance tuning settings
 	CacheFlag = cli.IntFlag{
 		Name:  "cache",
@@ -447,6 +456,20 @@ var (
 		Usage: "HTTP path path prefix on which JSON-RPC is served. Use '/' to serve on all paths.",
 		Value: "",
 	}
+	GraphQLEnabledFlag = cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
+	}
+	GraphQLCORSDomainFlag = cli.StringFlag{
+		Name:  "graphql.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value: "",
+	}
+	GraphQLVirtualHostsFlag = cli.StringFlag{
+		Name:  "graphql.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
+	}
 	WSEnabledFlag = cli.BoolFlag{
 		Name:  "ws",
 		Usage: "Enable the WS-RPC server",
@@ -476,6 +499,56 @@ var (
 		Usage: "HTTP path prefix on which JSON-RPC is served. Use '/' to serve on all paths.",
 		Value: "",
 	}
+	AuthEnabledFlag = cli.BoolFlag{
+		Name:  "authrpc",
+		Usage: "Enable the JWT authenticated HTTP/WS-RPC server",
+	}
+	AuthListenAddrFlag = cli.StringFlag{
+		Name:  "authrpc.addr",
+		Usage: "Listening address for the authenticated HTTP/WS-RPC server",
+		Value: node.DefaultAuthHost,
+	}
+	AuthPortFlag = cli.IntFlag{
+		Name:  "authrpc.port",
+		Usage: "Listening port for the authenticated HTTP/WS-RPC server",
+		Value: node.DefaultAuthPort,
+	}
+	AuthVirtualHostsFlag = cli.StringFlag{
+		Name:  "authrpc.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.AuthVirtualHosts, ","),
+	}
+	AuthApiFlag = cli.StringFlag{
+		Name:  "authrpc.api",
+		Usage: "API's offered over the authenticated HTTP/WS-RPC interface",
+		Value: strings.Join(node.DefaultAuthModules, ","),
+	}
+	JWTSecretFlag = cli.StringFlag{
+		Name:  "authrpc.jwtsecret",
+		Usage: "Path to a hex encoded 32 bytes JWT secret of the authenticated RPC (generated if the file doesn't exist)",
+	}
+	BatchRequestLimitFlag = cli.IntFlag{
+		Name:  "rpc.batch-request-limit",
+		Usage: "Maximum number of requests in a batch (0 means no limit)",
+		Value: node.DefaultConfig.BatchRequestLimit,
+	}
+	BatchResponseMaxSizeFlag = cli.IntFlag{
+		Name:  "rpc.batch-response-max-size",
+		Usage: "Maximum number of bytes returned from a batched call (0 means no limit)",
+		Value: node.DefaultConfig.BatchResponseMaxSize,
+	}
+	RPCRateLimitFlag = cli.Float64Flag{
+		Name:  "rpc.ratelimit",
+		Usage: "Maximum number of HTTP/WS RPC requests per second from a single IP (0 means no limit)",
+	}
+	RPCRateLimitBurstFlag = cli.IntFlag{
+		Name:  "rpc.ratelimit.burst",
+		Usage: "Maximum number of HTTP/WS RPC requests from a single IP served at once (defaults to the rate)",
+	}
+	RPCRateLimitNamespacesFlag = cli.StringFlag{
+		Name:  "rpc.ratelimit.namespaces",
+		Usage: "Comma separated list of per-IP request rates of API namespaces (e.g. debug=1,eth=50)",
+	}
 	ExecFlag = cli.StringFlag{
 		Name:  "exec",
 		Usage: "Execute JavaScript statement",
@@ -814,6 +887,17 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setGraphQL creates the GraphQL listener interface string from the set
+// command line flags, returning empty if the GraphQL endpoint is disabled.
+func setGraphQL(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalIsSet(GraphQLCORSDomainFlag.Name) {
+		cfg.GraphQLCors = SplitAndTrim(ctx.GlobalString(GraphQLCORSDomainFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLVirtualHostsFlag.Name) {
+		cfg.GraphQLVirtualHosts = SplitAndTrim(ctx.GlobalString(GraphQLVirtualHostsFlag.Name))
+	}
+}
+
 // setWS creates the WebSocket RPC listener interface string from the set
 // command line flags, returning empty if the HTTP endpoint is disabled.
 func setWS(ctx *cli.Context, cfg *node.Config) {
@@ -840,6 +924,58 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setAuthRPC applies the authenticated RPC server configuration from the command line flags.
+func setAuthRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(AuthEnabledFlag.Name) && cfg.AuthAddr == "" {
+		cfg.AuthAddr = node.DefaultAuthHost
+	}
+	if ctx.GlobalIsSet(AuthListenAddrFlag.Name) {
+		cfg.AuthAddr = ctx.GlobalString(AuthListenAddrFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthPortFlag.Name) {
+		cfg.AuthPort = ctx.GlobalInt(AuthPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(AuthVirtualHostsFlag.Name) {
+		cfg.AuthVirtualHosts = SplitAndTrim(ctx.GlobalString(AuthVirtualHostsFlag.Name))
+	}
+	if ctx.GlobalIsSet(AuthApiFlag.Name) {
+		cfg.AuthModules = SplitAndTrim(ctx.GlobalString(AuthApiFlag.Name))
+	}
+	if ctx.GlobalIsSet(JWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.GlobalString(JWTSecretFlag.Name)
+	}
+}
+
+// setRPCLimits applies the HTTP and WebSocket RPC requests limits from the command line flags.
+func setRPCLimits(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalIsSet(BatchRequestLimitFlag.Name) {
+		cfg.BatchRequestLimit = ctx.GlobalInt(BatchRequestLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(BatchResponseMaxSizeFlag.Name) {
+		cfg.BatchResponseMaxSize = ctx.GlobalInt(BatchResponseMaxSizeFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCRateLimitFlag.Name) {
+		cfg.RPCRateLimits.PerIP = ctx.GlobalFloat64(RPCRateLimitFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCRateLimitBurstFlag.Name) {
+		cfg.RPCRateLimits.Burst = ctx.GlobalInt(RPCRateLimitBurstFlag.Name)
+	}
+	if ctx.GlobalIsSet(RPCRateLimitNamespacesFlag.Name) {
+		cfg.RPCRateLimits.Namespaces = make(map[string]float64)
+		for _, entry := range SplitAndTrim(ctx.GlobalString(RPCRateLimitNamespacesFlag.Name)) {
+			kv := strings.SplitN(entry, "=", 2)
+			if len(kv) != 2 {
+				Fatalf("Invalid namespace rate limit %q, expected namespace=rate", entry)
+			}
+			r, err := strconv.ParseFloat(kv[1], 64)
+			if err != nil {
+				Fatalf("Invalid namespace rate limit %q: %v", entry, err)
+			}
+			cfg.RPCRateLimits.Namespaces[kv[0]] = r
+		}
+	}
+}
+
 // setIPC creates an IPC path configuration from the set command line flags,
 // returning an empty string if IPC was explicitly disabled, or the set path.
 func setIPC(ctx *cli.Context, cfg *node.Config) {
@@ -998,7 +1134,10 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	SetP2PConfig(ctx, &cfg.P2P)
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
+	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
+	setAuthRPC(ctx, cfg)
+	setRPCLimits(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	setDataDir(ctx, cfg)
 	setSmartCard(ctx, cfg)
@@ -475,6 +475,12 @@ func isNumber(v goja.Value) bool {
 	return k >= reflect.Int && k <= reflect.Float64
 }
 
+// isString returns true if input value is a JS string.
+func isString(v goja.Value) bool {
+	t := v.ExportType()
+	return t != nil && t.Kind() == reflect.String
+}
+
 func getObject(vm *goja.Runtime, name string) *goja.Object {
 	v := vm.Get(name)
 	if v == nil {
@@ -0,0 +1,82 @@
+package console
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/dop251/goja"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/internal/jsre"
+)
+
+// rpcValidator is a validator profile returned by abft_getValidators.
+type rpcValidator struct {
+	Weight *hexutil.Big `json:"weight"`
+	PubKey string       `json:"pubkey"`
+}
+
+// PrintValidators prints the validators table of the epoch, the latest epoch by default.
+func (b *bridge) PrintValidators(call jsre.Call) (goja.Value, error) {
+	epoch := interface{}("latest")
+	if nArgs := len(call.Arguments); nArgs > 1 {
+		return nil, fmt.Errorf("usage: abft.printValidators([epoch])")
+	}
+	if arg := call.Argument(0); !goja.IsUndefined(arg) && !goja.IsNull(arg) {
+		switch {
+		case isNumber(arg) && arg.ToInteger() >= 0:
+			epoch = hexutil.Uint64(arg.ToInteger())
+		case isString(arg):
+			epoch = arg.String()
+		default:
+			return nil, fmt.Errorf("expected epoch number or tag as argument")
+		}
+	}
+
+	var validators map[hexutil.Uint64]rpcValidator
+	if err := b.client.Call(&validators, "abft_getValidators", epoch); err != nil {
+		return nil, err
+	}
+	if validators == nil {
+		return nil, fmt.Errorf("epoch %v is not found", epoch)
+	}
+	ids := make([]hexutil.Uint64, 0, len(validators))
+	total := new(big.Int)
+	for id, v := range validators {
+		ids = append(ids, id)
+		if v.Weight != nil {
+			total.Add(total, v.Weight.ToInt())
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	w := tabwriter.NewWriter(b.printer, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tWEIGHT\tSHARE\tPUBKEY")
+	for _, id := range ids {
+		v := validators[id]
+		weight := new(big.Int)
+		if v.Weight != nil {
+			weight = v.Weight.ToInt()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", uint64(id), weight, weightShare(weight, total), v.PubKey)
+	}
+	fmt.Fprintf(w, "total: %d\t%s\t%s\t\n", len(ids), total, weightShare(total, total))
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return goja.Undefined(), nil
+}
+
+// weightShare formats the weight as a percentage of the total weight.
+func weightShare(weight, total *big.Int) string {
+	if total.Sign() == 0 {
+		return "-"
+	}
+	bp := new(big.Int).Mul(weight, big.NewInt(10000))
+	bp.Div(bp, total)
+	return fmt.Sprintf("%d.%02d%%", bp.Uint64()/100, bp.Uint64()%100)
+}
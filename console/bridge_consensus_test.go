@@ -0,0 +1,199 @@
+package console
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/node"
+	"github.com/sesanetwork/go-sesa/rpc"
+)
+
+// testAbftAPI serves the validators of the epoch 2, which is the latest one.
+type testAbftAPI struct{}
+
+func (api *testAbftAPI) GetValidators(epoch rpc.BlockNumber) (map[hexutil.Uint64]interface{}, error) {
+	if epoch != rpc.LatestBlockNumber && epoch != 2 {
+		return nil, nil
+	}
+	return map[hexutil.Uint64]interface{}{
+		3: map[string]interface{}{
+			"weight": (*hexutil.Big)(big.NewInt(1000)),
+			"pubkey": "0xc0003",
+		},
+		1: map[string]interface{}{
+			"weight": (*hexutil.Big)(big.NewInt(3000)),
+			"pubkey": "0xc0001",
+		},
+	}, nil
+}
+
+func (api *testAbftAPI) GetDowntime(validatorID hexutil.Uint) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"offlineBlocks": hexutil.Uint64(validatorID) * 10,
+		"offlineTime":   hexutil.Uint64(validatorID) * 1000,
+	}, nil
+}
+
+// testDagAPI serves the events DAG:
+//
+//	0x0a <- 0x0b <- 0x0d
+//	0x0a <- 0x0c <- 0x0d
+//	0x0c <- 0x0e (missing)
+type testDagAPI struct{}
+
+var testDagEvents = map[string][]string{
+	"0x0a": {"0x0b", "0x0c"},
+	"0x0b": {"0x0d"},
+	"0x0c": {"0x0d", "0x0e"},
+	"0x0d": {},
+}
+
+func (api *testDagAPI) GetEvent(id string) (map[string]interface{}, error) {
+	parents, ok := testDagEvents[id]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found", id)
+	}
+	parentIDs := make([]hexutil.Bytes, 0, len(parents))
+	for _, p := range parents {
+		parentIDs = append(parentIDs, hexutil.MustDecode(p))
+	}
+	seq := hexutil.Uint64(len(parents))
+	return map[string]interface{}{
+		"id":      hexutil.Bytes(hexutil.MustDecode(id)),
+		"epoch":   hexutil.Uint64(2),
+		"seq":     seq,
+		"frame":   hexutil.Uint64(1),
+		"creator": hexutil.Uint64(1),
+		"lamport": seq + 1,
+		"parents": parentIDs,
+	}, nil
+}
+
+func (api *testDagAPI) GetHeads(epoch rpc.BlockNumber) ([]hexutil.Bytes, error) {
+	return []hexutil.Bytes{hexutil.MustDecode("0x0a")}, nil
+}
+
+// newConsensusTester creates a console around a node which serves the test abft and dag APIs.
+func newConsensusTester(t *testing.T) *tester {
+	workspace := t.TempDir()
+
+	stack, err := node.New(&node.Config{DataDir: workspace, UseLightweightKDF: true, Name: testInstance})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	stack.RegisterAPIs([]rpc.API{
+		{
+			Namespace: "abft",
+			Version:   "1.0",
+			Service:   new(testAbftAPI),
+			Public:    true,
+		}, {
+			Namespace: "dag",
+			Version:   "1.0",
+			Service:   new(testDagAPI),
+			Public:    true,
+		},
+	})
+	if err = stack.Start(); err != nil {
+		t.Fatalf("failed to start test stack: %v", err)
+	}
+	client, err := stack.Attach()
+	if err != nil {
+		t.Fatalf("failed to attach to node: %v", err)
+	}
+	printer := new(bytes.Buffer)
+	console, err := New(Config{
+		DataDir: stack.DataDir(),
+		DocRoot: "testdata",
+		Client:  client,
+		Printer: printer,
+	})
+	if err != nil {
+		t.Fatalf("failed to create JavaScript console: %v", err)
+	}
+	return &tester{
+		workspace: workspace,
+		stack:     stack,
+		console:   console,
+		output:    printer,
+	}
+}
+
+// Tests that the abft and dag web3 extensions are loaded for the served APIs.
+func TestConsensusExtensions(t *testing.T) {
+	tester := newConsensusTester(t)
+	defer tester.Close(t)
+
+	tester.console.Evaluate("abft.getDowntime(2).offlineBlocks")
+	if output := tester.output.String(); !strings.Contains(output, "20") {
+		t.Fatalf("abft.getDowntime failed: have %s, want %s", output, "20")
+	}
+	tester.output.Reset()
+
+	tester.console.Evaluate("dag.getHeads('latest')[0]")
+	if output := tester.output.String(); !strings.Contains(output, "0x0a") {
+		t.Fatalf("dag.getHeads failed: have %s, want %s", output, "0x0a")
+	}
+}
+
+// Tests that the validators table is printed sorted by validator IDs.
+func TestPrintValidators(t *testing.T) {
+	tester := newConsensusTester(t)
+	defer tester.Close(t)
+
+	want := strings.Join([]string{
+		"ID        WEIGHT  SHARE    PUBKEY",
+		"1         3000    75.00%   0xc0001",
+		"3         1000    25.00%   0xc0003",
+		"total: 2  4000    100.00%  ",
+	}, "\n")
+	for _, call := range []string{"abft.printValidators()", "abft.printValidators(2)", "abft.printValidators('latest')"} {
+		tester.output.Reset()
+		tester.console.Evaluate(call)
+		if output := tester.output.String(); !strings.Contains(output, want) {
+			t.Fatalf("%s: validators table mismatch: have\n%s\nwant\n%s", call, output, want)
+		}
+	}
+
+	tester.output.Reset()
+	tester.console.Evaluate("abft.printValidators(1)")
+	if output := tester.output.String(); !strings.Contains(output, "epoch 0x1 is not found") {
+		t.Fatalf("missing epoch error: have %s", output)
+	}
+}
+
+// Tests that the event parents are walked down to the requested depth, and
+// every event is expanded only once.
+func TestWalkParents(t *testing.T) {
+	tester := newConsensusTester(t)
+	defer tester.Close(t)
+
+	tester.console.Evaluate("dag.walkParents('0x0a')")
+	want := strings.Join([]string{
+		"0x0a creator=1 epoch=2 seq=2 frame=1 lamport=3 parents=2",
+		"  0x0b creator=1 epoch=2 seq=1 frame=1 lamport=2 parents=1",
+		"    0x0d creator=1 epoch=2 seq=0 frame=1 lamport=1 parents=0",
+		"  0x0c creator=1 epoch=2 seq=2 frame=1 lamport=3 parents=2",
+		"    0x0d (see above)",
+		"    0x0e: event 0x0e not found",
+	}, "\n")
+	if output := tester.output.String(); !strings.Contains(output, want) {
+		t.Fatalf("parents tree mismatch: have\n%s\nwant\n%s", output, want)
+	}
+
+	tester.output.Reset()
+	tester.console.Evaluate("dag.walkParents('0x0a', 1)")
+	if output := tester.output.String(); strings.Contains(output, "0x0d") {
+		t.Fatalf("parents are walked deeper than requested: have\n%s", output)
+	}
+
+	tester.output.Reset()
+	tester.console.Evaluate("dag.walkParents(1)")
+	if output := tester.output.String(); !strings.Contains(output, "usage: dag.walkParents") {
+		t.Fatalf("missing usage error: have %s", output)
+	}
+}
@@ -0,0 +1,93 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dop251/goja"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/internal/jsre"
+	"github.com/sesanetwork/go-sesa/rpc"
+)
+
+// defaultWalkDepth is a number of the parents levels printed by dag.walkParents by default.
+const defaultWalkDepth = 3
+
+// rpcEvent is an event header returned by dag_getEvent.
+type rpcEvent struct {
+	ID      hexutil.Bytes   `json:"id"`
+	Epoch   hexutil.Uint64  `json:"epoch"`
+	Seq     hexutil.Uint64  `json:"seq"`
+	Frame   hexutil.Uint64  `json:"frame"`
+	Creator hexutil.Uint64  `json:"creator"`
+	Lamport hexutil.Uint64  `json:"lamport"`
+	Parents []hexutil.Bytes `json:"parents"`
+}
+
+// WalkParents prints the tree of the event parents, down to the given depth.
+// Events reachable by several paths are expanded only once.
+func (b *bridge) WalkParents(call jsre.Call) (goja.Value, error) {
+	nArgs := len(call.Arguments)
+	if nArgs == 0 || nArgs > 2 || !isString(call.Argument(0)) {
+		return nil, fmt.Errorf("usage: dag.walkParents(<event id>[, depth])")
+	}
+	depth := int64(defaultWalkDepth)
+	if nArgs == 2 {
+		arg := call.Argument(1)
+		if goja.IsUndefined(arg) || goja.IsNull(arg) || !isNumber(arg) || arg.ToInteger() < 0 {
+			return nil, fmt.Errorf("expected non-negative number as second argument")
+		}
+		depth = arg.ToInteger()
+	}
+
+	var root rpcEvent
+	if err := b.client.Call(&root, "dag_getEvent", call.Argument(0).String()); err != nil {
+		return nil, err
+	}
+	w := &dagWalker{
+		client: b.client,
+		out:    b.printer,
+		seen:   make(map[string]bool),
+	}
+	w.print(&root, 0)
+	w.walk(&root, 1, depth)
+	return goja.Undefined(), nil
+}
+
+// dagWalker prints the event parents in the depth-first order.
+type dagWalker struct {
+	client *rpc.Client
+	out    io.Writer
+	seen   map[string]bool // events which are already printed
+}
+
+func (w *dagWalker) walk(e *rpcEvent, level, depth int64) {
+	if level > depth {
+		return
+	}
+	for _, id := range e.Parents {
+		var parent rpcEvent
+		if err := w.client.Call(&parent, "dag_getEvent", id.String()); err != nil {
+			fmt.Fprintf(w.out, "%s%s: %v\n", indent(level), id, err)
+			continue
+		}
+		if w.seen[parent.ID.String()] {
+			fmt.Fprintf(w.out, "%s%s (see above)\n", indent(level), parent.ID)
+			continue
+		}
+		w.print(&parent, level)
+		w.walk(&parent, level+1, depth)
+	}
+}
+
+func (w *dagWalker) print(e *rpcEvent, level int64) {
+	w.seen[e.ID.String()] = true
+	fmt.Fprintf(w.out, "%s%s creator=%d epoch=%d seq=%d frame=%d lamport=%d parents=%d\n",
+		indent(level), e.ID, e.Creator, e.Epoch, e.Seq, e.Frame, e.Lamport, len(e.Parents))
+}
+
+func indent(level int64) string {
+	return strings.Repeat("  ", int(level))
+}
@@ -127,6 +127,7 @@ func (c *Console) init(preload []string) error {
 	c.jsre.Do(func(vm *goja.Runtime) {
 		c.initAdmin(vm, bridge)
 		c.initPersonal(vm, bridge)
+		c.initConsensus(vm, bridge)
 	})
 
 	// Preload JavaScript files.
@@ -224,6 +225,17 @@ func (c *Console) initAdmin(vm *goja.Runtime, bridge *bridge) {
 	}
 }
 
+// initConsensus creates additional abft and dag APIs implemented by the bridge,
+// which pretty-print the epoch validators and the event parents.
+func (c *Console) initConsensus(vm *goja.Runtime, bridge *bridge) {
+	if abft := getObject(vm, "abft"); abft != nil {
+		abft.Set("printValidators", jsre.MakeCallback(vm, bridge.PrintValidators))
+	}
+	if dag := getObject(vm, "dag"); dag != nil {
+		dag.Set("walkParents", jsre.MakeCallback(vm, bridge.WalkParents))
+	}
+}
+
 // initPersonal redirects account-related API methods through the bridge.
 //
 // If the console is in interactive mode and the 'personal' API is available, override
@@ -738,6 +738,16 @@ func (t *freezerTable) retrieveItems(start, count, maxBytes uint64) ([]byte, []i
 	return output[:outputSize], sizes, nil
 }
 
+// Items returns the number of items stored in the table.
+func (t *freezerTable) Items() uint64 {
+	return atomic.LoadUint64(&t.items)
+}
+
+// Truncate discards any recent data above the provided threshold number.
+func (t *freezerTable) Truncate(items uint64) error {
+	return t.truncate(items)
+}
+
 // has returns an indicator whether the specified number data
 // exists in the freezer table.
 func (t *freezerTable) has(number uint64) bool {
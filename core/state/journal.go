@@ -112,6 +112,10 @@ type (
 		account       *common.Address
 		key, prevalue common.Hash
 	}
+	transientStorageChange struct {
+		account       *common.Address
+		key, prevalue common.Hash
+	}
 	codeChange struct {
 		account            *common.Address
 		prevcode, prevhash []byte
@@ -213,6 +217,14 @@ func (ch storageChange) dirtied() *common.Address {
 	return ch.account
 }
 
+func (ch transientStorageChange) revert(s *StateDB) {
+	s.setTransientState(*ch.account, ch.key, ch.prevalue)
+}
+
+func (ch transientStorageChange) dirtied() *common.Address {
+	return nil
+}
+
 func (ch refundChange) revert(s *StateDB) {
 	s.refund = ch.prev
 }
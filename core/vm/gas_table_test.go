@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/common/hexutil"
 	"github.com/sesanetwork/go-sesa/core/rawdb"
@@ -105,3 +106,55 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+func TestEIP3860CreateGas(t *testing.T) {
+	for i, tt := range []struct {
+		size     uint64
+		shanghai bool
+		failure  error
+	}{
+		{320, false, nil},
+		{320, true, nil},
+		{params.MaxInitCodeSize, true, nil},
+		{params.MaxInitCodeSize + 1, false, nil},
+		{params.MaxInitCodeSize + 1, true, ErrOutOfGas},
+	} {
+		address := common.BytesToAddress([]byte("contract"))
+		// PUSH3 size PUSH1 0 PUSH1 0 CREATE STOP
+		code := []byte{byte(PUSH3), byte(tt.size >> 16), byte(tt.size >> 8), byte(tt.size), byte(PUSH1), 0, byte(PUSH1), 0, byte(CREATE), byte(STOP)}
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, code)
+		statedb.Finalise(true)
+
+		vmctx := BlockContext{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int) {},
+			BlockNumber: big.NewInt(0),
+		}
+		config := *params.AllProtocolChanges
+		if tt.shanghai {
+			config.ShanghaiBlock = big.NewInt(0)
+			config.CancunBlock = big.NewInt(0)
+		}
+		vmenv := NewEVM(vmctx, TxContext{}, statedb, &config, Config{})
+
+		_, _, err := vmenv.Call(AccountRef(common.Address{}), address, nil, 10000000, new(big.Int))
+		if err != tt.failure {
+			t.Errorf("test %d: failure mismatch: have %v, want %v", i, err, tt.failure)
+		}
+	}
+	// The initcode words are charged on top of the memory expansion
+	stack := newstack()
+	stack.push(new(uint256.Int).SetUint64(320))
+	stack.push(new(uint256.Int))
+	stack.push(new(uint256.Int))
+	gas, err := gasCreateEip3860(nil, nil, stack, NewMemory(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 10 * params.InitCodeWordGas; gas != want {
+		t.Errorf("initcode gas mismatch: have %d, want %d", gas, want)
+	}
+}
@@ -21,10 +21,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/holiman/uint256"
 	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/rawdb"
+	"github.com/sesanetwork/go-sesa/core/state"
 	"github.com/sesanetwork/go-sesa/crypto"
 	"github.com/sesanetwork/go-sesa/params"
 )
@@ -537,6 +541,125 @@ func TestOpMstore(t *testing.T) {
 	}
 }
 
+func TestOpMCopy(t *testing.T) {
+	for i, tc := range []struct {
+		dst, src, len string
+		pre           string
+		want          string
+	}{
+		{ // MCOPY 0 32 32 - copy 32 bytes from offset 32 to offset 0.
+			dst: "0x0", src: "0x20", len: "0x20",
+			pre:  "0000000000000000000000000000000000000000000000000000000000000000 000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			want: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f 000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		},
+		{ // MCOPY 0 0 32 - copy 32 bytes from offset 0 to offset 0.
+			dst: "0x0", src: "0x0", len: "0x20",
+			pre:  "0101010101010101010101010101010101010101010101010101010101010101",
+			want: "0101010101010101010101010101010101010101010101010101010101010101",
+		},
+		{ // MCOPY 0 1 8 - copy 8 bytes from offset 1 to offset 0 (overlapping).
+			dst: "0x0", src: "0x1", len: "0x8",
+			pre:  "000102030405060708 000000000000000000000000000000000000000000000000",
+			want: "010203040506070808 000000000000000000000000000000000000000000000000",
+		},
+		{ // MCOPY 1 0 8 - copy 8 bytes from offset 0 to offset 1 (overlapping).
+			dst: "0x1", src: "0x0", len: "0x8",
+			pre:  "000102030405060708 000000000000000000000000000000000000000000000000",
+			want: "000001020304050607 000000000000000000000000000000000000000000000000",
+		},
+		{ // MCOPY 0xFFFFFFFFFFFF 0xFFFFFFFFFFFF 0 - copy zero bytes out of bounds.
+			dst: "0xFFFFFFFFFFFF", src: "0xFFFFFFFFFFFF", len: "0x0",
+		},
+	} {
+		var (
+			env            = NewEVM(BlockContext{}, TxContext{}, nil, params.TestChainConfig, Config{})
+			stack          = newstack()
+			pc             = uint64(0)
+			evmInterpreter = NewEVMInterpreter(env, env.Config)
+		)
+		data := common.FromHex(strings.ReplaceAll(tc.pre, " ", ""))
+		// Set pre
+		mem := NewMemory()
+		mem.Resize(uint64(len(data)))
+		mem.Set(0, uint64(len(data)), data)
+		// Push stack args
+		length, _ := uint256.FromHex(tc.len)
+		src, _ := uint256.FromHex(tc.src)
+		dst, _ := uint256.FromHex(tc.dst)
+		stack.push(length)
+		stack.push(src)
+		stack.push(dst)
+		// The memory is expanded by the interpreter before executing the opcode
+		size, overflow := memoryMcopy(stack)
+		if overflow {
+			t.Fatalf("test %d: memory size overflow", i)
+		}
+		if size > 0 && length.Sign() > 0 {
+			mem.Resize(toWordSize(size) * 32)
+		}
+		opMcopy(&pc, evmInterpreter, &ScopeContext{mem, stack, nil})
+		// Compare memory
+		want := common.FromHex(strings.ReplaceAll(tc.want, " ", ""))
+		if have := mem.Data(); !bytes.Equal(want, have) {
+			t.Errorf("test %d: want: %#x\nhave: %#x\n", i, want, have)
+		}
+	}
+}
+
+func TestOpTstore(t *testing.T) {
+	var (
+		statedb, _     = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		env            = NewEVM(BlockContext{}, TxContext{}, statedb, params.TestChainConfig, Config{})
+		stack          = newstack()
+		mem            = NewMemory()
+		evmInterpreter = NewEVMInterpreter(env, env.Config)
+		caller         = common.Address{}
+		to             = common.Address{1}
+		contract       = NewContract(AccountRef(caller), AccountRef(to), new(big.Int), 0)
+		scopeContext   = ScopeContext{mem, stack, contract}
+		value          = common.Hex2Bytes("abcdef00000000000000abba000000000deaf000000c0de00100000000133700")
+	)
+
+	env.interpreter = evmInterpreter
+	pc := uint64(0)
+	// push the value to the stack
+	stack.push(new(uint256.Int).SetBytes(value))
+	// push the location to the stack
+	stack.push(new(uint256.Int))
+	opTstore(&pc, evmInterpreter, &scopeContext)
+	// there should be no elements on the stack after TSTORE
+	if stack.len() != 0 {
+		t.Fatal("stack wrong size")
+	}
+	// push the location to the stack
+	stack.push(new(uint256.Int))
+	opTload(&pc, evmInterpreter, &scopeContext)
+	// there should be one element on the stack after TLOAD
+	if stack.len() != 1 {
+		t.Fatal("stack wrong size")
+	}
+	val := stack.peek()
+	if !bytes.Equal(val.Bytes(), value) {
+		t.Fatal("incorrect element read from transient storage")
+	}
+}
+
+func TestOpPush0(t *testing.T) {
+	var (
+		env            = NewEVM(BlockContext{}, TxContext{}, nil, params.TestChainConfig, Config{})
+		stack          = newstack()
+		evmInterpreter = NewEVMInterpreter(env, env.Config)
+	)
+	pc := uint64(0)
+	opPush0(&pc, evmInterpreter, &ScopeContext{nil, stack, nil})
+	if stack.len() != 1 || !stack.peek().IsZero() {
+		t.Fatal("PUSH0 must push a single zero")
+	}
+	if newLondonInstructionSet()[PUSH0] != nil || newShanghaiInstructionSet()[PUSH0] == nil {
+		t.Fatal("PUSH0 must be enabled only since Shanghai")
+	}
+}
+
 func BenchmarkOpMstore(bench *testing.B) {
 	var (
 		env            = NewEVM(BlockContext{}, TxContext{}, nil, params.TestChainConfig, Config{})
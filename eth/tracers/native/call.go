@@ -0,0 +1,246 @@
+// Package native is a collection of tracers written in Go. They are registered
+// in the tracers package under the names of their JavaScript counterparts and
+// are picked up by the debug_trace* APIs instead of the slower JS versions.
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/accounts/abi"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/vm"
+	"github.com/sesanetwork/go-sesa/eth/tracers"
+)
+
+func init() {
+	tracers.RegisterNative("callTracer", newCallTracer)
+}
+
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	To           *common.Address `json:"to,omitempty"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []callFrame     `json:"calls,omitempty"`
+	Logs         []callLog       `json:"logs,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+
+	typ vm.OpCode
+}
+
+func (f *callFrame) failed() bool {
+	return len(f.Error) > 0
+}
+
+func (f *callFrame) processOutput(output []byte, err error) {
+	output = common.CopyBytes(output)
+	if err == nil {
+		f.Output = output
+		return
+	}
+	f.Error = err.Error()
+	if f.typ == vm.CREATE || f.typ == vm.CREATE2 {
+		f.To = nil
+	}
+	if !errors.Is(err, vm.ErrExecutionReverted) || len(output) == 0 {
+		return
+	}
+	f.Output = output
+	if len(output) < 4 {
+		return
+	}
+	if unpacked, err := abi.UnpackRevert(output); err == nil {
+		f.RevertReason = unpacked
+	}
+}
+
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
+	WithLog     bool `json:"withLog"`     // If true, call tracer will collect event logs
+}
+
+// callTracer is a native implementation of call_tracer.js. It records the
+// tree of call frames entered during the execution of a transaction.
+type callTracer struct {
+	callstack []callFrame
+	config    callTracerConfig
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+// newCallTracer returns a native go tracer which tracks
+// call frames of a tx, and implements vm.Tracer.
+func newCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.TxTracer, error) {
+	var config callTracerConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	// First callframe contains tx context info
+	// and is populated on start and end.
+	return &callTracer{callstack: make([]callFrame, 1), config: config}, nil
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing operation.
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	toCopy := to
+	t.callstack[0] = callFrame{
+		typ:   vm.CALL,
+		From:  from,
+		To:    &toCopy,
+		Input: common.CopyBytes(input),
+		Gas:   hexutil.Uint64(gas),
+		Value: (*hexutil.Big)(new(big.Int)),
+	}
+	if value != nil {
+		t.callstack[0].Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+	if create {
+		t.callstack[0].typ = vm.CREATE
+	}
+	t.callstack[0].Type = t.callstack[0].typ.String()
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	t.callstack[0].GasUsed = hexutil.Uint64(gasUsed)
+	t.callstack[0].processOutput(output, err)
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of VM execution.
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	// Only logs need to be captured via opcode processing
+	if !t.config.WithLog {
+		return
+	}
+	// Avoid processing nested calls when only caring about top call
+	if t.config.OnlyTopCall && depth > 1 {
+		return
+	}
+	// Skip if tracing was interrupted
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	if op < vm.LOG0 || op > vm.LOG4 {
+		return
+	}
+	size := int(op - vm.LOG0)
+	stackData := scope.Stack.Data()
+	if len(stackData) < size+2 {
+		return
+	}
+
+	// Don't modify the stack
+	mStart := stackData[len(stackData)-1]
+	mSize := stackData[len(stackData)-2]
+	topics := make([]common.Hash, size)
+	for i := 0; i < size; i++ {
+		topic := stackData[len(stackData)-2-(i+1)]
+		topics[i] = common.Hash(topic.Bytes32())
+	}
+
+	data := scope.Memory.GetCopy(int64(mStart.Uint64()), int64(mSize.Uint64()))
+	log := callLog{Address: scope.Contract.Address(), Topics: topics, Data: hexutil.Bytes(data)}
+	t.callstack[len(t.callstack)-1].Logs = append(t.callstack[len(t.callstack)-1].Logs, log)
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault.
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *callTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.config.OnlyTopCall {
+		return
+	}
+	// Skip if tracing was interrupted
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+
+	toCopy := to
+	call := callFrame{
+		typ:   typ,
+		Type:  typ.String(),
+		From:  from,
+		To:    &toCopy,
+		Input: common.CopyBytes(input),
+		Gas:   hexutil.Uint64(gas),
+	}
+	if value != nil {
+		call.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+	t.callstack = append(t.callstack, call)
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.config.OnlyTopCall {
+		return
+	}
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+	// pop call
+	call := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	size -= 1
+
+	call.GasUsed = hexutil.Uint64(gasUsed)
+	call.processOutput(output, err)
+	t.callstack[size-1].Calls = append(t.callstack[size-1].Calls, call)
+}
+
+// GetResult returns the json-encoded nested list of call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	// Logs of the reverted frames never made it into the state
+	if t.config.WithLog {
+		clearFailedLogs(&t.callstack[0], false)
+	}
+	res, err := json.Marshal(t.callstack[0])
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *callTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// clearFailedLogs clears the logs of a callframe and all its children
+// in case of execution failure.
+func clearFailedLogs(cf *callFrame, parentFailed bool) {
+	failed := cf.failed() || parentFailed
+	if failed {
+		cf.Logs = nil
+	}
+	for i := range cf.Calls {
+		clearFailedLogs(&cf.Calls[i], failed)
+	}
+}
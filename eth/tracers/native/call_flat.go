@@ -0,0 +1,312 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/vm"
+	"github.com/sesanetwork/go-sesa/eth/tracers"
+)
+
+func init() {
+	tracers.RegisterNative("flatCallTracer", newFlatCallTracer)
+}
+
+var parityErrorMapping = map[string]string{
+	"contract creation code storage out of gas": "Out of gas",
+	"out of gas":                      "Out of gas",
+	"gas uint64 overflow":             "Out of gas",
+	"max code size exceeded":          "Out of gas",
+	"invalid jump destination":        "Bad jump destination",
+	"execution reverted":              "Reverted",
+	"return data out of bounds":       "Out of bounds",
+	"stack limit reached 1024 (1023)": "Out of stack",
+	"precompiled failed":              "Built-in failed",
+	"invalid input length":            "Built-in failed",
+}
+
+var parityErrorMappingStartingWith = map[string]string{
+	"invalid opcode:": "Bad instruction",
+	"stack underflow": "Stack underflow",
+}
+
+// flatCallFrame is a standalone callframe in the format of the trace_* API.
+type flatCallFrame struct {
+	Action              flatCallAction  `json:"action"`
+	BlockHash           common.Hash     `json:"blockHash"`
+	BlockNumber         uint64          `json:"blockNumber"`
+	Error               string          `json:"error,omitempty"`
+	Result              *flatCallResult `json:"result,omitempty"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     common.Hash     `json:"transactionHash"`
+	TransactionPosition uint64          `json:"transactionPosition"`
+	Type                string          `json:"type"`
+}
+
+type flatCallAction struct {
+	SelfDestructed *common.Address `json:"address,omitempty"`
+	Balance        *hexutil.Big    `json:"balance,omitempty"`
+	CallType       string          `json:"callType,omitempty"`
+	From           *common.Address `json:"from,omitempty"`
+	Gas            *hexutil.Uint64 `json:"gas,omitempty"`
+	Init           *hexutil.Bytes  `json:"init,omitempty"`
+	Input          *hexutil.Bytes  `json:"input,omitempty"`
+	RefundAddress  *common.Address `json:"refund_address,omitempty"`
+	To             *common.Address `json:"to,omitempty"`
+	Value          *hexutil.Big    `json:"value,omitempty"`
+}
+
+type flatCallResult struct {
+	Address *common.Address `json:"address,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+	GasUsed *hexutil.Uint64 `json:"gasUsed,omitempty"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+}
+
+type flatCallTracerConfig struct {
+	ConvertParityErrors bool `json:"convertParityErrors"` // If true, call tracer converts errors to parity format
+	IncludePrecompiles  bool `json:"includePrecompiles"`  // If true, call tracer includes calls to precompiled contracts
+}
+
+// flatCallTracer reports call frames in a flat format, i.e. as opposed to
+// the nested format of callTracer, in the same shape as the trace_* API.
+type flatCallTracer struct {
+	tracer            *callTracer
+	config            flatCallTracerConfig
+	ctx               *tracers.Context // Holds tracer context data
+	blockNumber       uint64
+	activePrecompiles []common.Address // Updated on CaptureStart based on given rules
+}
+
+// newFlatCallTracer returns a new flatCallTracer.
+func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.TxTracer, error) {
+	var config flatCallTracerConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	// Create inner call tracer with default configuration, don't forward
+	// the OnlyTopCall or WithLog to inner for now
+	t, err := newCallTracer(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &flatCallTracer{tracer: t.(*callTracer), ctx: ctx, config: config}, nil
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing operation.
+func (t *flatCallTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.tracer.CaptureStart(env, from, to, create, input, gas, value)
+	// Update list of precompiles based on current block
+	rules := env.ChainConfig().Rules(env.Context.BlockNumber)
+	t.activePrecompiles = vm.ActivePrecompiles(rules)
+	t.blockNumber = env.Context.BlockNumber.Uint64()
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *flatCallTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) {
+	t.tracer.CaptureEnd(output, gasUsed, d, err)
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of VM execution.
+func (t *flatCallTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault.
+func (t *flatCallTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *flatCallTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.tracer.CaptureEnter(typ, from, to, input, gas, value)
+
+	// Child calls must have a value, even if it's zero.
+	// Practically speaking, only STATICCALL and DELEGATECALL have nil value. Set it to zero.
+	if value == nil && len(t.tracer.callstack) > 1 {
+		t.tracer.callstack[len(t.tracer.callstack)-1].Value = (*hexutil.Big)(new(big.Int))
+	}
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *flatCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.tracer.CaptureExit(output, gasUsed, err)
+
+	// Parity traces don't include CALL/STATICCALLs to precompiles.
+	// By default we remove them from the callstack.
+	if t.config.IncludePrecompiles || len(t.tracer.callstack) == 0 {
+		return
+	}
+	parent := &t.tracer.callstack[len(t.tracer.callstack)-1]
+	if len(parent.Calls) == 0 {
+		return
+	}
+	call := parent.Calls[len(parent.Calls)-1]
+	if call.typ == vm.CALL || call.typ == vm.STATICCALL {
+		if call.To != nil && t.isPrecompiled(*call.To) {
+			parent.Calls = parent.Calls[:len(parent.Calls)-1]
+		}
+	}
+}
+
+// GetResult returns the json-encoded flat list of call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *flatCallTracer) GetResult() (json.RawMessage, error) {
+	if len(t.tracer.callstack) < 1 {
+		return nil, errors.New("invalid number of calls")
+	}
+
+	flat, err := t.flatFromNested(&t.tracer.callstack[0], []int{})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := json.Marshal(flat)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.tracer.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *flatCallTracer) Stop(err error) {
+	t.tracer.Stop(err)
+}
+
+// isPrecompiled returns whether the addr is a precompile.
+func (t *flatCallTracer) isPrecompiled(addr common.Address) bool {
+	for _, p := range t.activePrecompiles {
+		if p == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *flatCallTracer) flatFromNested(input *callFrame, traceAddress []int) (output []flatCallFrame, err error) {
+	var frame *flatCallFrame
+	switch input.typ {
+	case vm.CREATE, vm.CREATE2:
+		frame = newFlatCreate(input)
+	case vm.SELFDESTRUCT:
+		frame = newFlatSuicide(input)
+	case vm.CALL, vm.STATICCALL, vm.CALLCODE, vm.DELEGATECALL:
+		frame = newFlatCall(input)
+	default:
+		return nil, fmt.Errorf("unrecognized call frame type: %s", input.typ)
+	}
+
+	frame.Error = input.Error
+	if t.config.ConvertParityErrors {
+		convertErrorToParity(frame)
+	}
+
+	// Revert output contains useful information (revert reason).
+	// Otherwise discard result.
+	if input.Error != "" && input.Error != vm.ErrExecutionReverted.Error() {
+		frame.Result = nil
+	}
+
+	frame.TraceAddress = traceAddress
+	frame.Subtraces = len(input.Calls)
+	frame.BlockHash = t.ctx.BlockHash
+	frame.BlockNumber = t.blockNumber
+	frame.TransactionHash = t.ctx.TxHash
+	frame.TransactionPosition = uint64(t.ctx.TxIndex)
+	output = append(output, *frame)
+
+	for i := range input.Calls {
+		childAddr := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(childAddr, traceAddress)
+		childAddr = append(childAddr, i)
+
+		flat, err := t.flatFromNested(&input.Calls[i], childAddr)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, flat...)
+	}
+	return output, nil
+}
+
+func newFlatCreate(input *callFrame) *flatCallFrame {
+	var (
+		gas     = input.Gas
+		gasUsed = input.GasUsed
+		init    = input.Input
+		code    = input.Output
+	)
+	return &flatCallFrame{
+		Type: "create",
+		Action: flatCallAction{
+			From:  &input.From,
+			Gas:   &gas,
+			Value: input.Value,
+			Init:  &init,
+		},
+		Result: &flatCallResult{
+			GasUsed: &gasUsed,
+			Address: input.To,
+			Code:    &code,
+		},
+	}
+}
+
+func newFlatCall(input *callFrame) *flatCallFrame {
+	var (
+		gas     = input.Gas
+		gasUsed = input.GasUsed
+		data    = input.Input
+		output  = input.Output
+	)
+	return &flatCallFrame{
+		Type: "call",
+		Action: flatCallAction{
+			From:     &input.From,
+			To:       input.To,
+			Gas:      &gas,
+			Value:    input.Value,
+			CallType: strings.ToLower(input.Type),
+			Input:    &data,
+		},
+		Result: &flatCallResult{
+			GasUsed: &gasUsed,
+			Output:  &output,
+		},
+	}
+}
+
+func newFlatSuicide(input *callFrame) *flatCallFrame {
+	return &flatCallFrame{
+		Type: "suicide",
+		Action: flatCallAction{
+			SelfDestructed: &input.From,
+			Balance:        input.Value,
+			RefundAddress:  input.To,
+		},
+	}
+}
+
+func convertErrorToParity(call *flatCallFrame) {
+	if call.Error == "" {
+		return
+	}
+	if parityError, ok := parityErrorMapping[call.Error]; ok {
+		call.Error = parityError
+		return
+	}
+	for gethError, parityError := range parityErrorMappingStartingWith {
+		if strings.HasPrefix(call.Error, gethError) {
+			call.Error = parityError
+			return
+		}
+	}
+}
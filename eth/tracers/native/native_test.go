@@ -0,0 +1,137 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/rawdb"
+	"github.com/sesanetwork/go-sesa/core/state"
+	"github.com/sesanetwork/go-sesa/core/vm"
+	"github.com/sesanetwork/go-sesa/eth/tracers"
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/params"
+)
+
+var (
+	testSender = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testCaller = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testCallee = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	testGas    = uint64(100000)
+	testInput  = append([]byte{0x12, 0x34, 0x56, 0x78}, make([]byte, 32)...)
+)
+
+// runNativeTrace executes a call to a contract which emits a log and then calls
+// another contract which reverts, and returns the result of the named tracer.
+func runNativeTrace(t *testing.T, name string, cfg string) json.RawMessage {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetBalance(testSender, big.NewInt(1e18))
+	// buy the gas as the state transition does
+	intrinsicGas, err := evmcore.IntrinsicGas(testInput, nil, false, false)
+	require.NoError(t, err)
+	statedb.SubBalance(testSender, new(big.Int).SetUint64(testGas+intrinsicGas))
+	// PUSH1 0 PUSH1 0 LOG0, then CALL testCallee with no value and no data, POP STOP
+	callerCode := []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0),
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH20),
+	}
+	callerCode = append(callerCode, testCallee.Bytes()...)
+	callerCode = append(callerCode, byte(vm.GAS), byte(vm.CALL), byte(vm.POP), byte(vm.STOP))
+	statedb.SetCode(testCaller, callerCode)
+	// PUSH1 0 PUSH1 0 REVERT
+	statedb.SetCode(testCallee, []byte{byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT)})
+
+	tracer, err := tracers.NewTracer(name, &tracers.Context{TxIndex: 1}, json.RawMessage(cfg))
+	require.NoError(t, err)
+
+	blockCtx := vm.BlockContext{
+		CanTransfer: evmcore.CanTransfer,
+		Transfer:    evmcore.Transfer,
+		BlockNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		Difficulty:  big.NewInt(0),
+		GasLimit:    1000000,
+	}
+	txCtx := vm.TxContext{Origin: testSender, GasPrice: big.NewInt(1)}
+	evm := vm.NewEVM(blockCtx, txCtx, statedb, params.TestChainConfig, vm.Config{Debug: true, Tracer: tracer})
+	_, _, err = evm.Call(vm.AccountRef(testSender), testCaller, testInput, testGas, big.NewInt(0))
+	require.NoError(t, err)
+
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+	return res
+}
+
+func TestCallTracer(t *testing.T) {
+	require := require.New(t)
+
+	var res callFrame
+	require.NoError(json.Unmarshal(runNativeTrace(t, "callTracer", `{"withLog": true}`), &res))
+	require.Equal("CALL", res.Type)
+	require.Equal(testSender, res.From)
+	require.Equal(testCaller, *res.To)
+	require.Empty(res.Error)
+	require.Len(res.Logs, 1)
+	require.Equal(testCaller, res.Logs[0].Address)
+	require.Len(res.Calls, 1)
+	require.Equal("CALL", res.Calls[0].Type)
+	require.Equal(testCallee, *res.Calls[0].To)
+	require.Equal(vm.ErrExecutionReverted.Error(), res.Calls[0].Error)
+
+	res = callFrame{}
+	require.NoError(json.Unmarshal(runNativeTrace(t, "callTracer", `{"onlyTopCall": true}`), &res))
+	require.Empty(res.Calls)
+	require.Empty(res.Logs)
+}
+
+func TestFlatCallTracer(t *testing.T) {
+	require := require.New(t)
+
+	var res []flatCallFrame
+	require.NoError(json.Unmarshal(runNativeTrace(t, "flatCallTracer", `{"convertParityErrors": true}`), &res))
+	require.Len(res, 2)
+	require.Equal("call", res[0].Type)
+	require.Equal(1, res[0].Subtraces)
+	require.Equal([]int{}, res[0].TraceAddress)
+	require.Equal(uint64(1), res[0].BlockNumber)
+	require.Equal(uint64(1), res[0].TransactionPosition)
+	require.Equal("call", res[1].Action.CallType)
+	require.Equal([]int{0}, res[1].TraceAddress)
+	require.Equal("Reverted", res[1].Error)
+}
+
+func TestPrestateTracer(t *testing.T) {
+	require := require.New(t)
+
+	res := map[common.Address]accountMarshaling{}
+	require.NoError(json.Unmarshal(runNativeTrace(t, "prestateTracer", ""), &res))
+	require.Contains(res, testSender)
+	require.Equal(big.NewInt(1e18), res[testSender].Balance.ToInt())
+	require.Contains(res, testCaller)
+	require.Contains(res, testCallee)
+	require.NotEmpty(res[testCallee].Code)
+
+	var diff struct {
+		Pre  map[common.Address]accountMarshaling `json:"pre"`
+		Post map[common.Address]accountMarshaling `json:"post"`
+	}
+	require.NoError(json.Unmarshal(runNativeTrace(t, "prestateTracer", `{"diffMode": true}`), &diff))
+	// only the sender is modified, as the bought gas isn't refunded without the state transition around
+	require.Len(diff.Pre, 1)
+	require.Len(diff.Post, 1)
+	require.Equal(big.NewInt(1e18), diff.Pre[testSender].Balance.ToInt())
+	require.Equal(-1, diff.Post[testSender].Balance.ToInt().Cmp(big.NewInt(1e18)))
+}
+
+func TestFourByteTracer(t *testing.T) {
+	require := require.New(t)
+
+	res := map[string]int{}
+	require.NoError(json.Unmarshal(runNativeTrace(t, "4byteTracer", ""), &res))
+	require.Equal(map[string]int{"0x12345678-32": 1}, res)
+}
@@ -0,0 +1,297 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/vm"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/eth/tracers"
+	"github.com/sesanetwork/go-sesa/evmcore"
+)
+
+func init() {
+	tracers.RegisterNative("prestateTracer", newPrestateTracer)
+}
+
+type stateMap = map[common.Address]*account
+
+type account struct {
+	Balance *big.Int
+	Code    []byte
+	Nonce   uint64
+	Storage map[common.Hash]common.Hash
+}
+
+type accountMarshaling struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// MarshalJSON encodes the account in the format of prestate_tracer.js.
+func (a *account) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accountMarshaling{
+		Balance: (*hexutil.Big)(a.Balance),
+		Code:    a.Code,
+		Nonce:   a.Nonce,
+		Storage: a.Storage,
+	})
+}
+
+func (a *account) exists() bool {
+	return a.Nonce > 0 || len(a.Code) > 0 || len(a.Storage) > 0 || (a.Balance != nil && a.Balance.Sign() != 0)
+}
+
+type prestateTracerConfig struct {
+	DiffMode bool `json:"diffMode"` // If true, this tracer will return state modifications
+}
+
+// prestateTracer is a native implementation of prestate_tracer.js. It collects
+// the state of every account touched by a transaction before its execution,
+// and optionally the state modifications made by it.
+type prestateTracer struct {
+	env       *vm.EVM
+	pre       stateMap
+	post      stateMap
+	config    prestateTracerConfig
+	created   map[common.Address]bool
+	deleted   map[common.Address]bool
+	diffDone  bool
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newPrestateTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.TxTracer, error) {
+	var config prestateTracerConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return &prestateTracer{
+		pre:     stateMap{},
+		post:    stateMap{},
+		config:  config,
+		created: make(map[common.Address]bool),
+		deleted: make(map[common.Address]bool),
+	}, nil
+}
+
+// CaptureStart implements the vm.Tracer interface to initialize the tracing operation.
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.env = env
+
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+
+	if value == nil {
+		value = new(big.Int)
+	}
+	// The recipient balance includes the value transferred.
+	t.pre[to].Balance = new(big.Int).Sub(t.pre[to].Balance, value)
+
+	// The sender balance is after reducing the value and the bought gas,
+	// re-add them to get the pre-tx balance. The gas passed in is what
+	// remains after the intrinsic gas was deducted.
+	intrinsicGas, err := evmcore.IntrinsicGas(input, nil, create, env.ChainConfig().IsShanghai(env.Context.BlockNumber))
+	if err != nil {
+		intrinsicGas = 0
+	}
+	gasLimit := new(big.Int).SetUint64(gas + intrinsicGas)
+	fromBal := new(big.Int).Add(t.pre[from].Balance, value)
+	if gasPrice := env.TxContext.GasPrice; gasPrice != nil {
+		fromBal.Add(fromBal, gasLimit.Mul(gasLimit, gasPrice))
+	}
+	t.pre[from].Balance = fromBal
+	// The sender nonce has been already incremented for both calls and creations
+	if t.pre[from].Nonce > 0 {
+		t.pre[from].Nonce--
+	}
+
+	if create && t.config.DiffMode {
+		t.created[to] = true
+	}
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+}
+
+// CaptureState implements the vm.Tracer interface to trace a single step of VM execution.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil {
+		return
+	}
+	// Skip if tracing was interrupted
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	stackData := scope.Stack.Data()
+	stackLen := len(stackData)
+	caller := scope.Contract.Address()
+	switch {
+	case stackLen >= 1 && (op == vm.SLOAD || op == vm.SSTORE):
+		slot := common.Hash(stackData[stackLen-1].Bytes32())
+		t.lookupAccount(caller)
+		t.lookupStorage(caller, slot)
+	case stackLen >= 1 && (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE || op == vm.SELFDESTRUCT):
+		addr := common.Address(stackData[stackLen-1].Bytes20())
+		t.lookupAccount(addr)
+		if op == vm.SELFDESTRUCT {
+			t.deleted[caller] = true
+		}
+	case stackLen >= 5 && (op == vm.DELEGATECALL || op == vm.CALL || op == vm.STATICCALL || op == vm.CALLCODE):
+		addr := common.Address(stackData[stackLen-2].Bytes20())
+		t.lookupAccount(addr)
+	case op == vm.CREATE:
+		nonce := env.StateDB.GetNonce(caller)
+		addr := crypto.CreateAddress(caller, nonce)
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	case stackLen >= 4 && op == vm.CREATE2:
+		offset := stackData[stackLen-2]
+		size := stackData[stackLen-3]
+		init := scope.Memory.GetCopy(int64(offset.Uint64()), int64(size.Uint64()))
+		inithash := crypto.Keccak256(init)
+		salt := stackData[stackLen-4]
+		addr := crypto.CreateAddress2(caller, salt.Bytes32(), inithash)
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	}
+}
+
+// CaptureFault implements the vm.Tracer interface to trace an execution fault.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *prestateTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *prestateTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+}
+
+// processDiffState computes the post state of the touched accounts. It has to
+// run after the whole message is applied, so that the gas refund is included.
+func (t *prestateTracer) processDiffState() {
+	if t.diffDone || t.env == nil {
+		return
+	}
+	t.diffDone = true
+
+	for addr, state := range t.pre {
+		// The deleted account's state is pruned from `post` but kept in `pre`
+		if t.deleted[addr] {
+			continue
+		}
+		modified := false
+		postAccount := &account{Storage: make(map[common.Hash]common.Hash)}
+		newBalance := t.env.StateDB.GetBalance(addr)
+		newNonce := t.env.StateDB.GetNonce(addr)
+		newCode := t.env.StateDB.GetCode(addr)
+
+		if newBalance.Cmp(state.Balance) != 0 {
+			modified = true
+			postAccount.Balance = new(big.Int).Set(newBalance)
+		}
+		if newNonce != state.Nonce {
+			modified = true
+			postAccount.Nonce = newNonce
+		}
+		if !bytes.Equal(newCode, state.Code) {
+			modified = true
+			postAccount.Code = newCode
+		}
+
+		for key, val := range state.Storage {
+			// don't include the empty slot
+			if val == (common.Hash{}) {
+				delete(state.Storage, key)
+			}
+			newVal := t.env.StateDB.GetState(addr, key)
+			if val == newVal {
+				// Omit unchanged slots
+				delete(state.Storage, key)
+			} else {
+				modified = true
+				if newVal != (common.Hash{}) {
+					postAccount.Storage[key] = newVal
+				}
+			}
+		}
+
+		if modified {
+			t.post[addr] = postAccount
+		} else {
+			// if state is not modified, then no need to include into the pre state
+			delete(t.pre, addr)
+		}
+	}
+	// the new created contracts' prestate were empty, so delete them
+	for a := range t.created {
+		// the created contract maybe exists in statedb before the creating tx
+		if s := t.pre[a]; s != nil && !s.exists() {
+			delete(t.pre, a)
+		}
+	}
+}
+
+// GetResult returns the json-encoded pre state (and the post state in diff mode),
+// and any error arising from the encoding or forceful termination (via `Stop`).
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	var res []byte
+	var err error
+	if t.config.DiffMode {
+		t.processDiffState()
+		res, err = json.Marshal(struct {
+			Post stateMap `json:"post"`
+			Pre  stateMap `json:"pre"`
+		}{t.post, t.pre})
+	} else {
+		res, err = json.Marshal(t.pre)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *prestateTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// lookupAccount fetches details of an account and adds it to the prestate
+// if it doesn't exist there.
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+
+	t.pre[addr] = &account{
+		Balance: new(big.Int).Set(t.env.StateDB.GetBalance(addr)),
+		Nonce:   t.env.StateDB.GetNonce(addr),
+		Code:    common.CopyBytes(t.env.StateDB.GetCode(addr)),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage fetches the requested storage slot and adds
+// it to the prestate of the given contract. It assumes `lookupAccount`
+// has been performed on the contract before.
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	if _, ok := t.pre[addr].Storage[key]; ok {
+		return
+	}
+	t.pre[addr].Storage[key] = t.env.StateDB.GetState(addr, key)
+}
@@ -14,18 +14,38 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-// Package tracers is a collection of JavaScript transaction tracers.
+// Package tracers is a collection of JavaScript and native transaction tracers.
 package tracers
 
 import (
+	"encoding/json"
 	"strings"
 	"unicode"
 
+	"github.com/sesanetwork/go-sesa/core/vm"
 	"github.com/sesanetwork/go-sesa/eth/tracers/internal/tracers"
 )
 
-// all contains all the built in JavaScript tracers by name.
-var all = make(map[string]string)
+// TxTracer is implemented by every tracer able to trace a single transaction,
+// regardless of whether it is backed by JavaScript or native Go code.
+type TxTracer interface {
+	vm.Tracer
+	// GetResult returns the JSON encoded result of the trace.
+	GetResult() (json.RawMessage, error)
+	// Stop terminates execution of the tracer at the first opportune moment.
+	Stop(err error)
+}
+
+// NativeConstructor creates a new native tracer instance for a single transaction.
+// cfg is the tracer specific configuration passed as `tracerConfig`, it may be nil.
+type NativeConstructor func(ctx *Context, cfg json.RawMessage) (TxTracer, error)
+
+var (
+	// all contains all the built in JavaScript tracers by name.
+	all = make(map[string]string)
+	// native contains all the registered native tracers by name.
+	native = make(map[string]NativeConstructor)
+)
 
 // camel converts a snake cased input string into a camel cased output.
 func camel(str string) string {
@@ -37,10 +57,13 @@ func camel(str string) string {
 }
 
 // init retrieves the JavaScript transaction tracers included in go-ethereum.
+// Every JavaScript tracer is also reachable with the "Js" suffix, so that it
+// stays available when a native tracer is registered under the same name.
 func init() {
 	for _, file := range tracers.AssetNames() {
 		name := camel(strings.TrimSuffix(file, ".js"))
 		all[name] = string(tracers.MustAsset(file))
+		all[name+"Js"] = all[name]
 	}
 }
 
@@ -51,3 +74,26 @@ func tracer(name string) (string, bool) {
 	}
 	return "", false
 }
+
+// RegisterNative makes a native tracer available under the given name.
+// Native tracers take precedence over the JavaScript ones with the same name.
+// It is meant to be called from init functions and is not thread safe.
+func RegisterNative(name string, ctor NativeConstructor) {
+	native[name] = ctor
+}
+
+// NewTracer instantiates a tracer by name or by JavaScript code. A registered
+// native tracer is preferred, otherwise code is resolved as in New.
+func NewTracer(code string, ctx *Context, cfg json.RawMessage) (TxTracer, error) {
+	if ctor, ok := native[code]; ok {
+		if ctx == nil {
+			ctx = new(Context)
+		}
+		return ctor(ctx, cfg)
+	}
+	t, err := New(code, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
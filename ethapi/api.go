@@ -18,6 +18,7 @@ package ethapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -46,6 +47,7 @@ import (
 	"github.com/sesanetwork/go-sesa/core/vm"
 	"github.com/sesanetwork/go-sesa/crypto"
 	"github.com/sesanetwork/go-sesa/eth/tracers"
+	_ "github.com/sesanetwork/go-sesa/eth/tracers/native"
 	"github.com/sesanetwork/go-sesa/evmcore"
 	"github.com/sesanetwork/go-sesa/gossip/gasprice"
 	"github.com/sesanetwork/go-sesa/log"
@@ -307,6 +309,80 @@ func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	return content
 }
 
+// PrivateTxPoolAPI offers an API for the node operators to inspect and evict
+// the transaction pool content.
+type PrivateTxPoolAPI struct {
+	b Backend
+}
+
+// NewPrivateTxPoolAPI creates a new private tx pool service.
+func NewPrivateTxPoolAPI(b Backend) *PrivateTxPoolAPI {
+	return &PrivateTxPoolAPI{b}
+}
+
+// RPCPoolTransaction represents a pool transaction with the time it has arrived into the pool.
+type RPCPoolTransaction struct {
+	*RPCTransaction
+	Arrival time.Time `json:"arrival"`
+}
+
+// Dump returns the transactions contained within the transaction pool with their
+// arrival times, optionally filtered by the sender.
+func (s *PrivateTxPoolAPI) Dump(from *common.Address) map[string]map[string]map[string]*RPCPoolTransaction {
+	content := map[string]map[string]map[string]*RPCPoolTransaction{
+		"pending": make(map[string]map[string]*RPCPoolTransaction),
+		"queued":  make(map[string]map[string]*RPCPoolTransaction),
+	}
+	var pending, queue map[common.Address]types.Transactions
+	if from != nil {
+		pendingFrom, queueFrom := s.b.TxPoolContentFrom(*from)
+		pending = map[common.Address]types.Transactions{*from: pendingFrom}
+		queue = map[common.Address]types.Transactions{*from: queueFrom}
+	} else {
+		pending, queue = s.b.TxPoolContent()
+	}
+
+	curHeader := s.b.CurrentBlock().Header()
+	flatten := func(all map[common.Address]types.Transactions, dst map[string]map[string]*RPCPoolTransaction) {
+		for account, txs := range all {
+			if len(txs) == 0 {
+				continue
+			}
+			dump := make(map[string]*RPCPoolTransaction, len(txs))
+			for _, tx := range txs {
+				dump[fmt.Sprintf("%d", tx.Nonce())] = &RPCPoolTransaction{
+					RPCTransaction: newRPCPendingTransaction(tx, curHeader.BaseFee),
+					Arrival:        s.b.TxPoolTxArrival(tx.Hash()),
+				}
+			}
+			dst[account.Hex()] = dump
+		}
+	}
+	flatten(pending, content["pending"])
+	flatten(queue, content["queued"])
+	return content
+}
+
+// RemoveTransaction evicts the transaction from the pool. The subsequent transactions
+// of the sender become non-executable. It returns false if the transaction isn't in the pool.
+func (s *PrivateTxPoolAPI) RemoveTransaction(hash common.Hash) bool {
+	removed := s.b.TxPoolRemoveTx(hash)
+	if removed {
+		log.Info("Evicted transaction from the pool", "hash", hash)
+	}
+	return removed
+}
+
+// RemoveSender evicts all the transactions of the sender from the pool. It returns
+// the number of evicted transactions.
+func (s *PrivateTxPoolAPI) RemoveSender(addr common.Address) hexutil.Uint {
+	removed := s.b.TxPoolRemoveSender(addr)
+	if removed > 0 {
+		log.Info("Evicted sender transactions from the pool", "sender", addr, "count", removed)
+	}
+	return hexutil.Uint(removed)
+}
+
 // PublicAccountAPI provides an API to access accounts managed by this node.
 // It offers only methods that can retrieve accounts.
 type PublicAccountAPI struct {
@@ -715,6 +791,12 @@ func (s *PublicBlockChainAPI) BlockNumber() hexutil.Uint64 {
 	return hexutil.Uint64(header.Number.Uint64())
 }
 
+// LowestAvailableBlock returns the lowest block which logs and transaction traces are available for,
+// the history below it is pruned according to the node retention policy.
+func (s *PublicBlockChainAPI) LowestAvailableBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.b.HistoryLowestBlock())
+}
+
 // GetBalance returns the amount of wei for the given address in the state of the
 // given block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta
 // block numbers are also allowed.
@@ -828,12 +910,18 @@ func (s *PublicBlockChainAPI) calculateExtBlockApi(ctx context.Context, blkNumbe
 		if err != nil {
 			return ext
 		}
-		if receipts.Len() != 0 {
-			ext.receiptsRoot = types.DeriveSha(receipts, trie.NewStackTrie(nil))
-			ext.bloom = types.CreateBloom(receipts)
-		} else {
-			ext.receiptsRoot = types.EmptyRootHash
-		}
+		ext = newExtBlockApi(receipts)
+	}
+	return ext
+}
+
+func newExtBlockApi(receipts types.Receipts) extBlockApi {
+	var ext extBlockApi
+	if receipts.Len() != 0 {
+		ext.receiptsRoot = types.DeriveSha(receipts, trie.NewStackTrie(nil))
+		ext.bloom = types.CreateBloom(receipts)
+	} else {
+		ext.receiptsRoot = types.EmptyRootHash
 	}
 	return ext
 }
@@ -1404,6 +1492,26 @@ func RPCMarshalBlock(block *evmcore.EvmBlock, ext extBlockApi, inclTx bool, full
 	return fields, nil
 }
 
+// RPCMarshalBlockWithReceipts converts the given block to the RPC output with full transactions,
+// and adds the block receipts in the format of eth_getBlockReceipts.
+func RPCMarshalBlockWithReceipts(block *evmcore.EvmBlock, receipts types.Receipts, signer types.Signer) (map[string]interface{}, error) {
+	txs := block.Transactions
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	fields, err := RPCMarshalBlock(block, newExtBlockApi(receipts), true, true)
+	if err != nil {
+		return nil, err
+	}
+	header := block.Header()
+	marshaled := make([]map[string]interface{}, len(receipts))
+	for i, receipt := range receipts {
+		marshaled[i] = marshalReceipt(receipt, header, signer, txs[i], i)
+	}
+	fields["receipts"] = marshaled
+	return fields, nil
+}
+
 // rpcMarshalHeader uses the generalized output filler, then adds the total difficulty field, which requires
 // a `PublicBlockchainAPI`.
 func (s *PublicBlockChainAPI) rpcMarshalHeader(header *evmcore.EvmHeader, ext extBlockApi) map[string]interface{} {
@@ -1782,22 +1890,57 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	}
 	receipt := receipts[index]
 
+	// Derive the sender.
+	bigblock := new(big.Int).SetUint64(blockNumber)
+	signer := gsignercache.Wrap(types.MakeSigner(s.b.ChainConfig(), bigblock))
+	return marshalReceipt(receipt, header, signer, tx, int(index)), nil
+}
+
+// GetBlockReceipts returns the receipts of all transactions in the block identified by number or hash,
+// in the same format as GetTransactionReceipt.
+func (s *PublicTransactionPoolAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	number, err := s.b.ResolveRpcBlockNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+	if block == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := s.b.GetReceiptsByNumber(ctx, rpc.BlockNumber(number))
+	if receipts == nil || err != nil {
+		return nil, err
+	}
+	txs := block.Transactions
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+
+	header := block.Header()
+	signer := gsignercache.Wrap(types.MakeSigner(s.b.ChainConfig(), header.Number))
+	result := make([]map[string]interface{}, len(receipts))
+	for i, receipt := range receipts {
+		result[i] = marshalReceipt(receipt, header, signer, txs[i], i)
+	}
+	return result, nil
+}
+
+// marshalReceipt marshals a transaction receipt into a JSON object.
+func marshalReceipt(receipt *types.Receipt, header *evmcore.EvmHeader, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
+	blockNumber := header.Number.Uint64()
 	for _, l := range receipt.Logs {
-		l.TxHash = hash
+		l.TxHash = tx.Hash()
 		l.BlockHash = header.Hash
 		l.BlockNumber = blockNumber
 	}
 
-	// Derive the sender.
-	bigblock := new(big.Int).SetUint64(blockNumber)
-	signer := gsignercache.Wrap(types.MakeSigner(s.b.ChainConfig(), bigblock))
 	from, _ := internaltx.Sender(signer, tx)
 
 	fields := map[string]interface{}{
 		"blockHash":         header.Hash,
 		"blockNumber":       hexutil.Uint64(blockNumber),
-		"transactionHash":   hash,
-		"transactionIndex":  hexutil.Uint64(index),
+		"transactionHash":   tx.Hash(),
+		"transactionIndex":  hexutil.Uint64(txIndex),
 		"from":              from,
 		"to":                tx.To(),
 		"gasUsed":           hexutil.Uint64(receipt.GasUsed),
@@ -1827,7 +1970,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	if tx.To() == nil {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
-	return fields, nil
+	return fields
 }
 
 // sign is a helper function that signs a transaction with the private key of the given address.
@@ -1845,6 +1988,16 @@ func (s *PublicTransactionPoolAPI) sign(addr common.Address, tx *types.Transacti
 
 // SubmitTransaction is a helper function that submits tx to txPool and logs a message.
 func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, b.SendTx)
+}
+
+// SubmitPrivateTransaction is a helper function that submits tx to the private lane of txPool,
+// so it isn't broadcast until it's expired, and logs a message.
+func SubmitPrivateTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
+	return submitTransaction(ctx, b, tx, b.SendPrivateTx)
+}
+
+func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction, send func(context.Context, *types.Transaction) error) (common.Hash, error) {
 	// If the transaction fee cap is already specified, ensure the
 	// fee of the given transaction is _reasonable_.
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), b.RPCTxFeeCap()); err != nil {
@@ -1854,7 +2007,7 @@ func SubmitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (c
 		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
 		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
 	}
-	if err := b.SendTx(ctx, tx); err != nil {
+	if err := send(ctx, tx); err != nil {
 		return common.Hash{}, err
 	} // Print a log with full tx details for manual investigations and interventions
 	signer := gsignercache.Wrap(types.MakeSigner(b.ChainConfig(), b.CurrentBlock().Number))
@@ -1931,6 +2084,17 @@ func (s *PublicTransactionPoolAPI) SendRawTransaction(ctx context.Context, encod
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// SendPrivateTransaction will add the signed transaction to the private lane of the transaction
+// pool of the validator node. The transaction isn't broadcast to the peers until it's expired,
+// so it may be included only into the events of this validator in the meantime.
+func (s *PublicTransactionPoolAPI) SendPrivateTransaction(ctx context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(encodedTx); err != nil {
+		return common.Hash{}, err
+	}
+	return SubmitPrivateTransaction(ctx, s.b, tx)
+}
+
 // Sign calculates an ECDSA signature for:
 // keccack256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -2145,6 +2309,9 @@ type TraceConfig struct {
 	Tracer  *string
 	Timeout *string
 	Reexec  *uint64
+	// Config specific to given tracer. Note struct logger
+	// config are historically embedded in main object.
+	TracerConfig json.RawMessage
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
@@ -2235,10 +2402,11 @@ func (api *PublicDebugAPI) TraceCall(ctx context.Context, args TransactionArgs,
 	var traceConfig *TraceConfig
 	if config != nil {
 		traceConfig = &TraceConfig{
-			LogConfig: config.LogConfig,
-			Tracer:    config.Tracer,
-			Timeout:   config.Timeout,
-			Reexec:    config.Reexec,
+			LogConfig:    config.LogConfig,
+			Tracer:       config.Tracer,
+			Timeout:      config.Timeout,
+			Reexec:       config.Reexec,
+			TracerConfig: config.TracerConfig,
 		}
 	}
 	return api.traceTx(ctx, msg, new(tracers.Context), vmctx, statedb, traceConfig)
@@ -2248,7 +2416,7 @@ func (api *PublicDebugAPI) TraceCall(ctx context.Context, args TransactionArgs,
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
 func (api *PublicDebugAPI) traceTx(ctx context.Context, message evmcore.Message, txctx *tracers.Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig) (interface{}, error) {
-	// Assemble the structured logger or the JavaScript tracer
+	// Assemble the structured logger, the native or the JavaScript tracer
 	var (
 		tracer    vm.Tracer
 		err       error
@@ -2266,7 +2434,7 @@ func (api *PublicDebugAPI) traceTx(ctx context.Context, message evmcore.Message,
 				return nil, err
 			}
 		}
-		if t, err := tracers.New(*config.Tracer, txctx); err != nil {
+		if t, err := tracers.NewTracer(*config.Tracer, txctx, config.TracerConfig); err != nil {
 			return nil, err
 		} else {
 			deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
@@ -2313,11 +2481,11 @@ func (api *PublicDebugAPI) traceTx(ctx context.Context, message evmcore.Message,
 			StructLogs:  FormatLogs(tracer.StructLogs()),
 		}, nil
 
-	case *tracers.Tracer:
+	case tracers.TxTracer:
 		result, err := tracer.GetResult()
-		if err != nil && result == nil {
-			// Only for tracer called callTracer
-			if config.Tracer != nil && strings.Compare(*config.Tracer, "callTracer") == 0 {
+		if _, js := tracer.(*tracers.Tracer); js && err != nil && result == nil {
+			// Only for JavaScript tracer called callTracer
+			if config.Tracer != nil && strings.Compare(*config.Tracer, "callTracerJs") == 0 {
 				if strings.Contains(err.Error(), "cannot read property 'toString' of undefined") {
 					log.Debug("error when debug with callTracer", "err", err.Error())
 					callTracer, _ := tracers.New(*config.Tracer, txctx)
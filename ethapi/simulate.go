@@ -0,0 +1,374 @@
+package ethapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/common/bigendian"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/state"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/core/vm"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/eth/tracers"
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/rpc"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+const (
+	// maxSimulateBlocks is the limit of simulated blocks in a single request.
+	maxSimulateBlocks = 256
+
+	// simCallTracer is the tracer of the simulated calls if the traces are requested.
+	simCallTracer = "callTracer"
+
+	errCodeReverted       = 3
+	errCodeVMError        = -32015
+	errCodeInvalidBlocks  = -38020
+	errCodeGasCapExceeded = -38015
+)
+
+// SimBlock is a batch of calls executed within a single simulated block.
+// The overrides are applied before the calls.
+type SimBlock struct {
+	BlockOverrides *BlockOverrides   `json:"blockOverrides"`
+	StateOverrides *StateOverride    `json:"stateOverrides"`
+	Calls          []TransactionArgs `json:"calls"`
+}
+
+// SimOpts are the arguments of the multi-call simulation.
+type SimOpts struct {
+	BlockStateCalls []SimBlock `json:"blockStateCalls"`
+	// Validation enables the nonce, balance and base fee checks of the calls.
+	Validation bool `json:"validation"`
+	// TraceCalls enables the call traces of the calls.
+	TraceCalls bool `json:"traceCalls"`
+}
+
+// SimCallError is the error of a failed simulated call.
+type SimCallError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimCallResult is the outcome of a simulated call.
+type SimCallResult struct {
+	ReturnValue hexutil.Bytes   `json:"returnData"`
+	Logs        []*types.Log    `json:"logs"`
+	GasUsed     hexutil.Uint64  `json:"gasUsed"`
+	Status      hexutil.Uint64  `json:"status"`
+	Error       *SimCallError   `json:"error,omitempty"`
+	Trace       json.RawMessage `json:"trace,omitempty"`
+}
+
+// SimBlockResult is the simulated block with the outcomes of its calls.
+type SimBlockResult struct {
+	Number     hexutil.Uint64  `json:"number"`
+	Hash       common.Hash     `json:"hash"`
+	ParentHash common.Hash     `json:"parentHash"`
+	StateRoot  common.Hash     `json:"stateRoot"`
+	Timestamp  hexutil.Uint64  `json:"timestamp"`
+	GasLimit   hexutil.Uint64  `json:"gasLimit"`
+	GasUsed    hexutil.Uint64  `json:"gasUsed"`
+	Miner      common.Address  `json:"miner"`
+	BaseFee    *hexutil.Big    `json:"baseFeePerGas,omitempty"`
+	Calls      []SimCallResult `json:"calls"`
+}
+
+// simError is an API error of the simulation input.
+type simError struct {
+	error
+	code int
+}
+
+// ErrorCode returns the JSON error code of the simulation error.
+func (e *simError) ErrorCode() int {
+	return e.code
+}
+
+// simulator executes the simulated blocks one by one on top of the base block state.
+type simulator struct {
+	b       Backend
+	state   *state.StateDB
+	base    *evmcore.EvmHeader
+	opts    *SimOpts
+	gasCap  uint64
+	gasLeft uint64
+
+	// hashes of the simulated blocks, used by the BLOCKHASH opcode
+	hashes    map[uint64]common.Hash
+	chainHash vm.GetHashFunc
+}
+
+// SimulateV1 executes the ordered lists of calls in one or more simulated blocks
+// on top of the given block. The state changes of every call are visible to the
+// following calls.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to preview the outcome of a sequence of transactions.
+func (s *PublicBlockChainAPI) SimulateV1(ctx context.Context, opts SimOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]*SimBlockResult, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, &simError{errors.New("empty input"), errCodeInvalidBlocks}
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &simError{fmt.Errorf("too many blocks, the limit is %d", maxSimulateBlocks), errCodeInvalidBlocks}
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	statedb, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+
+	// Setup context so it may be cancelled when the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if timeout := s.b.RPCTimeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	sim := &simulator{
+		b:         s.b,
+		state:     statedb,
+		base:      header,
+		opts:      &opts,
+		gasCap:    s.b.RPCGasCap(),
+		gasLeft:   s.b.RPCGasCap(),
+		hashes:    map[uint64]common.Hash{header.Number.Uint64(): header.Hash},
+		chainHash: evmcore.GetHashFn(header, NewChainContext(ctx, s.b)),
+	}
+	return sim.execute(ctx)
+}
+
+func (sim *simulator) getHash(n uint64) common.Hash {
+	if h, ok := sim.hashes[n]; ok {
+		return h
+	}
+	return sim.chainHash(n)
+}
+
+func (sim *simulator) execute(ctx context.Context) ([]*SimBlockResult, error) {
+	defer func(start time.Time) { log.Debug("Executing EVM simulation finished", "runtime", time.Since(start)) }(time.Now())
+
+	parent := evmcore.NewEVMBlockContext(sim.base, NewChainContext(ctx, sim.b), nil)
+	parentHash := sim.base.Hash
+	results := make([]*SimBlockResult, 0, len(sim.opts.BlockStateCalls))
+	for i, block := range sim.opts.BlockStateCalls {
+		blockCtx, err := sim.makeBlockContext(&parent, block.BlockOverrides)
+		if err != nil {
+			return nil, &simError{fmt.Errorf("block %d: %w", i, err), errCodeInvalidBlocks}
+		}
+		if err := block.StateOverrides.Apply(sim.state); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		res, err := sim.executeBlock(ctx, &blockCtx, parentHash, block.Calls)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		sim.hashes[uint64(res.Number)] = res.Hash
+		results = append(results, res)
+		parent, parentHash = blockCtx, res.Hash
+	}
+	return results, nil
+}
+
+// makeBlockContext returns the context of the simulated block following the parent.
+func (sim *simulator) makeBlockContext(parent *vm.BlockContext, overrides *BlockOverrides) (vm.BlockContext, error) {
+	blockCtx := vm.BlockContext{
+		CanTransfer: evmcore.CanTransfer,
+		Transfer:    evmcore.Transfer,
+		GetHash:     sim.getHash,
+		Coinbase:    parent.Coinbase,
+		GasLimit:    parent.GasLimit,
+		BlockNumber: new(big.Int).Add(parent.BlockNumber, common.Big1),
+		Time:        new(big.Int).Add(parent.Time, common.Big1),
+		Difficulty:  parent.Difficulty,
+		BaseFee:     parent.BaseFee,
+	}
+	overrides.Apply(&blockCtx)
+	if blockCtx.BlockNumber.Cmp(parent.BlockNumber) <= 0 {
+		return blockCtx, fmt.Errorf("block number %d isn't greater than %d", blockCtx.BlockNumber, parent.BlockNumber)
+	}
+	if blockCtx.Time.Cmp(parent.Time) <= 0 {
+		return blockCtx, fmt.Errorf("block timestamp %d isn't greater than %d", blockCtx.Time, parent.Time)
+	}
+	return blockCtx, nil
+}
+
+func (sim *simulator) executeBlock(ctx context.Context, blockCtx *vm.BlockContext, parentHash common.Hash, calls []TransactionArgs) (*SimBlockResult, error) {
+	var (
+		gp       = new(evmcore.GasPool).AddGas(blockCtx.GasLimit)
+		gasUsed  uint64
+		txHashes = make([]common.Hash, len(calls))
+		results  = make([]SimCallResult, len(calls))
+	)
+	for i, args := range calls {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if sim.gasCap != 0 && sim.gasLeft == 0 {
+			return nil, &simError{fmt.Errorf("call %d: gas cap %d exceeded", i, sim.gasCap), errCodeGasCapExceeded}
+		}
+		msg, txHash, err := sim.toMessage(args, blockCtx, i)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		txHashes[i] = txHash
+		sim.state.Prepare(txHash, i)
+
+		res, err := sim.applyMessage(ctx, blockCtx, msg, gp, i, txHash)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		gasUsed += uint64(res.GasUsed)
+		if sim.gasCap != 0 {
+			sim.gasLeft -= uint64(res.GasUsed)
+		}
+		results[i] = *res
+	}
+
+	header := &types.Header{
+		ParentHash: parentHash,
+		Coinbase:   blockCtx.Coinbase,
+		Root:       sim.state.IntermediateRoot(true),
+		Number:     blockCtx.BlockNumber,
+		GasLimit:   blockCtx.GasLimit,
+		GasUsed:    gasUsed,
+		Time:       blockCtx.Time.Uint64(),
+		BaseFee:    blockCtx.BaseFee,
+	}
+	blockHash := header.Hash()
+	// the logs are numbered within the simulated block
+	var logIndex uint
+	for i := range results {
+		logs := sim.state.GetLogs(txHashes[i], blockHash)
+		for _, l := range logs {
+			l.BlockNumber = header.Number.Uint64()
+			l.Index = logIndex
+			logIndex++
+		}
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+		results[i].Logs = logs
+	}
+
+	return &SimBlockResult{
+		Number:     hexutil.Uint64(header.Number.Uint64()),
+		Hash:       blockHash,
+		ParentHash: parentHash,
+		StateRoot:  header.Root,
+		Timestamp:  hexutil.Uint64(header.Time),
+		GasLimit:   hexutil.Uint64(header.GasLimit),
+		GasUsed:    hexutil.Uint64(gasUsed),
+		Miner:      header.Coinbase,
+		BaseFee:    (*hexutil.Big)(header.BaseFee),
+		Calls:      results,
+	}, nil
+}
+
+// toMessage converts the call arguments into the message and the synthetic hash of the call,
+// which identifies the call logs. The hash is derived from the equivalent unsigned transaction,
+// the block number and the call index, so identical calls don't share it.
+func (sim *simulator) toMessage(args TransactionArgs, blockCtx *vm.BlockContext, index int) (types.Message, common.Hash, error) {
+	msg, err := args.ToMessage(sim.gasLeft, blockCtx.BaseFee)
+	if err != nil {
+		return msg, common.Hash{}, err
+	}
+	nonce := sim.state.GetNonce(msg.From())
+	if args.Nonce != nil {
+		nonce = uint64(*args.Nonce)
+	}
+	if sim.opts.Validation {
+		msg = types.NewMessage(msg.From(), msg.To(), nonce, msg.Value(), msg.Gas(), msg.GasPrice(), msg.GasFeeCap(), msg.GasTipCap(), msg.Data(), msg.AccessList(), false)
+	}
+
+	gas := hexutil.Uint64(msg.Gas())
+	args.Gas = &gas
+	args.Nonce = (*hexutil.Uint64)(&nonce)
+	if args.ChainID == nil {
+		args.ChainID = (*hexutil.Big)(sim.b.ChainConfig().ChainID)
+	}
+	txHash := crypto.Keccak256Hash(args.toTransaction().Hash().Bytes(), blockCtx.BlockNumber.Bytes(), bigendian.Uint64ToBytes(uint64(index)))
+	return msg, txHash, nil
+}
+
+func (sim *simulator) applyMessage(ctx context.Context, blockCtx *vm.BlockContext, msg types.Message, gp *evmcore.GasPool, index int, txHash common.Hash) (*SimCallResult, error) {
+	vmConfig := sesa.DefaultVMConfig
+	vmConfig.NoBaseFee = !sim.opts.Validation
+
+	var tracer tracers.TxTracer
+	if sim.opts.TraceCalls {
+		var err error
+		tracer, err = tracers.NewTracer(simCallTracer, &tracers.Context{TxIndex: index, TxHash: txHash}, nil)
+		if err != nil {
+			return nil, err
+		}
+		vmConfig.Tracer = tracer
+		vmConfig.Debug = true
+	}
+	evm := vm.NewEVM(*blockCtx, evmcore.NewEVMTxContext(msg), sim.state, sim.b.ChainConfig(), vmConfig)
+
+	// Wait for the context to be done and cancel the evm
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			evm.Cancel()
+		case <-done:
+		}
+	}()
+
+	result, err := evmcore.ApplyMessage(evm, msg, gp)
+	if evm.Cancelled() {
+		return nil, fmt.Errorf("execution aborted (timeout = %v)", sim.b.RPCTimeout())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("err: %w (supplied gas %d)", err, msg.Gas())
+	}
+	sim.state.Finalise(true)
+
+	res := &SimCallResult{
+		ReturnValue: result.Return(),
+		GasUsed:     hexutil.Uint64(result.UsedGas),
+		Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+	}
+	if result.Failed() {
+		res.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+		if len(result.Revert()) > 0 {
+			revertErr := newRevertError(result)
+			res.ReturnValue = result.Revert()
+			res.Error = &SimCallError{
+				Message: revertErr.Error(),
+				Code:    errCodeReverted,
+				Data:    revertErr.reason,
+			}
+		} else {
+			res.Error = &SimCallError{
+				Message: result.Err.Error(),
+				Code:    errCodeVMError,
+			}
+		}
+	}
+	if tracer != nil {
+		res.Trace, err = tracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
@@ -25,6 +25,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/sesanetwork/go-vassalo/native/idx"
+
 	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/common/hexutil"
 	"github.com/sesanetwork/go-sesa/core/state"
@@ -419,6 +421,9 @@ func (s *PublicTxTraceAPI) Filter(ctx context.Context, args FilterArgs) (*[]txtr
 	} else {
 		toBlock = rpc.BlockNumber(s.b.CurrentBlock().NumberU64())
 	}
+	if fromBlock >= 0 && idx.Block(fromBlock) < s.b.HistoryLowestBlock() {
+		return nil, evmcore.ErrPrunedHistory
+	}
 
 	// counter of processed traces
 	var traceAdded, traceCount uint
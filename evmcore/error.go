@@ -25,6 +25,10 @@ import (
 var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
+
+	// ErrPrunedHistory is returned when the requested logs or traces are below
+	// the history retention horizon and were already pruned.
+	ErrPrunedHistory = errors.New("pruned history unavailable")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
@@ -62,6 +66,10 @@ var (
 	// than required to start the invocation.
 	ErrIntrinsicGas = errors.New("intrinsic gas too low")
 
+	// ErrMaxInitCodeSizeExceeded is returned if creation transaction provides the init code bigger
+	// than init code size limit.
+	ErrMaxInitCodeSizeExceeded = errors.New("max initcode size exceeded")
+
 	// ErrTxTypeNotSupported is returned if a transaction is not supported in the
 	// current network configuration.
 	ErrTxTypeNotSupported = types.ErrTxTypeNotSupported
@@ -18,6 +18,7 @@ package evmcore
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"math/rand"
@@ -150,7 +151,9 @@ type TxPoolConfig struct {
 	Locals    []common.Address // Addresses that should be treated by default as local
 	NoLocals  bool             // Whether local transaction handling should be disabled
 	Journal   string           // Journal of local transactions to survive node restarts
-	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
+	Rejournal time.Duration    // Time interval to regenerate the transaction journals
+
+	FullJournal string // Journal of all the pending and queued transactions to survive node restarts (disabled if empty)
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
 	PriceBump  uint64 // Minimum price bump percentage to replace an already existing transaction (nonce)
@@ -161,6 +164,8 @@ type TxPoolConfig struct {
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+
+	PrivateLifetime uint64 // Number of blocks private transactions aren't broadcast for
 }
 
 // DefaultTxPoolConfig contains the default configurations for the transaction
@@ -178,6 +183,8 @@ var DefaultTxPoolConfig = TxPoolConfig{
 	GlobalQueue:  512,
 
 	Lifetime: 1 * time.Hour,
+
+	PrivateLifetime: 16,
 }
 
 // sanitize checks the provided user configurations and changes anything that's
@@ -212,6 +219,10 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		log.Warn("Sanitizing invalid txpool lifetime", "provided", conf.Lifetime, "updated", DefaultTxPoolConfig.Lifetime)
 		conf.Lifetime = DefaultTxPoolConfig.Lifetime
 	}
+	if conf.PrivateLifetime < 1 {
+		log.Warn("Sanitizing invalid txpool private lifetime", "provided", conf.PrivateLifetime, "updated", DefaultTxPoolConfig.PrivateLifetime)
+		conf.PrivateLifetime = DefaultTxPoolConfig.PrivateLifetime
+	}
 	return conf
 }
 
@@ -235,6 +246,7 @@ type TxPool struct {
 	istanbul bool // Fork indicator whether we are in the istanbul stage.
 	eip2718  bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
+	shanghai bool // Fork indicator whether we are in the Shanghai stage.
 
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
@@ -243,11 +255,14 @@ type TxPool struct {
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
 
+	poolJournal *poolJournal // Journal of all the pool transactions to back up to disk
+
 	pending map[common.Address]*txList   // All currently processable transactions
 	queue   map[common.Address]*txList   // Queued but non-processable transactions
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
 	all     *txLookup                    // All transactions to allow lookups
 	priced  *txPricedList                // All transactions sorted by price
+	private *txPrivateLane               // Private transactions which aren't broadcast
 
 	chainHeadCh     chan ChainHeadNotify
 	chainHeadSub    notify.Subscription
@@ -279,6 +294,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain State
 		queue:           make(map[common.Address]*txList),
 		beats:           make(map[common.Address]time.Time),
 		all:             newTxLookup(),
+		private:         newTxPrivateLane(),
 		chainHeadCh:     make(chan ChainHeadNotify, chainHeadChanSize),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
@@ -310,6 +326,15 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain State
 			log.Warn("Failed to rotate transaction journal", "err", err)
 		}
 	}
+	// If full pool journaling is enabled, load from disk and revalidate
+	if config.FullJournal != "" {
+		pool.poolJournal = newPoolJournal(config.FullJournal)
+
+		if err := pool.poolJournal.load(pool.addJournaled); err != nil {
+			log.Warn("Failed to load txpool journal", "err", err)
+		}
+		pool.rotatePoolJournal()
+	}
 
 	// Subscribe events from blockchain and start the main event loop.
 	pool.chainHeadSub = pool.chain.SubscribeNewBlock(pool.chainHeadCh)
@@ -383,7 +408,7 @@ func (pool *TxPool) loop() {
 			}
 			pool.mu.Unlock()
 
-		// Handle local transaction journal rotation
+		// Handle transaction journals rotation
 		case <-journal.C:
 			if pool.journal != nil {
 				pool.mu.Lock()
@@ -392,6 +417,9 @@ func (pool *TxPool) loop() {
 				}
 				pool.mu.Unlock()
 			}
+			if pool.poolJournal != nil {
+				pool.rotatePoolJournal()
+			}
 		}
 	}
 }
@@ -408,6 +436,9 @@ func (pool *TxPool) Stop() {
 	if pool.journal != nil {
 		pool.journal.close()
 	}
+	if pool.poolJournal != nil {
+		pool.rotatePoolJournal()
+	}
 	log.Info("Transaction pool stopped")
 }
 
@@ -487,35 +518,41 @@ func (pool *TxPool) stats() (int, int) {
 }
 
 // Content retrieves the data content of the transaction pool, returning all the
-// pending as well as queued transactions, grouped by account and sorted by nonce.
+// pending as well as queued non-private transactions, grouped by account and sorted
+// by nonce.
 func (pool *TxPool) Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions) {
 	pool.mu.Lock()
 	defer pool.mu.Unlock()
 
 	pending := make(map[common.Address]types.Transactions)
 	for addr, list := range pool.pending {
-		pending[addr] = list.Flatten()
+		if txs := pool.private.filter(list.Flatten()); len(txs) > 0 {
+			pending[addr] = txs
+		}
 	}
 	queued := make(map[common.Address]types.Transactions)
 	for addr, list := range pool.queue {
-		queued[addr] = list.Flatten()
+		if txs := pool.private.filter(list.Flatten()); len(txs) > 0 {
+			queued[addr] = txs
+		}
 	}
 	return pending, queued
 }
 
 // ContentFrom retrieves the data content of the transaction pool, returning the
-// pending as well as queued transactions of this address, grouped by nonce.
+// pending as well as queued non-private transactions of this address, grouped by
+// nonce.
 func (pool *TxPool) ContentFrom(addr common.Address) (types.Transactions, types.Transactions) {
 	pool.mu.RLock()
 	defer pool.mu.RUnlock()
 
 	var pending types.Transactions
 	if list, ok := pool.pending[addr]; ok {
-		pending = list.Flatten()
+		pending = pool.private.filter(list.Flatten())
 	}
 	var queued types.Transactions
 	if list, ok := pool.queue[addr]; ok {
-		queued = list.Flatten()
+		queued = pool.private.filter(list.Flatten())
 	}
 	return pending, queued
 }
@@ -552,7 +589,7 @@ func (pool *TxPool) Pending(enforceTips bool) (map[common.Address]types.Transact
 }
 
 func (pool *TxPool) SampleHashes(max int) []common.Hash {
-	return pool.all.SampleHashes(max)
+	return pool.private.filterHashes(pool.all.SampleHashes(max))
 }
 
 // Locals retrieves the accounts currently considered local by the pool.
@@ -563,22 +600,98 @@ func (pool *TxPool) Locals() []common.Address {
 	return pool.locals.flatten()
 }
 
-// local retrieves all currently known local transactions, grouped by origin
-// account and sorted by nonce. The returned transaction set is a copy and can be
-// freely modified by calling code.
+// local retrieves all currently known non-private local transactions, grouped by
+// origin account and sorted by nonce. The returned transaction set is a copy and
+// can be freely modified by calling code.
 func (pool *TxPool) local() map[common.Address]types.Transactions {
 	txs := make(map[common.Address]types.Transactions)
 	for addr := range pool.locals.accounts {
 		if pending := pool.pending[addr]; pending != nil {
-			txs[addr] = append(txs[addr], pending.Flatten()...)
+			txs[addr] = append(txs[addr], pool.private.filter(pending.Flatten())...)
 		}
 		if queued := pool.queue[addr]; queued != nil {
-			txs[addr] = append(txs[addr], queued.Flatten()...)
+			txs[addr] = append(txs[addr], pool.private.filter(queued.Flatten())...)
 		}
 	}
 	return txs
 }
 
+// journaled retrieves all currently known non-private transactions with their
+// arrival times, grouped by origin account and sorted by nonce.
+func (pool *TxPool) journaled() []*poolJournalEntry {
+	entries := make([]*poolJournalEntry, 0, pool.all.Count())
+	appendList := func(addr common.Address, list *txList) {
+		if list == nil {
+			return
+		}
+		local := pool.locals.contains(addr)
+		for _, tx := range pool.private.filter(list.Flatten()) {
+			entries = append(entries, &poolJournalEntry{
+				Tx:      tx,
+				Arrival: uint64(pool.all.Arrival(tx.Hash()).UnixNano()),
+				Local:   local,
+			})
+		}
+	}
+	for addr, list := range pool.pending {
+		appendList(addr, list)
+		appendList(addr, pool.queue[addr])
+	}
+	for addr, list := range pool.queue {
+		if pool.pending[addr] == nil {
+			appendList(addr, list)
+		}
+	}
+	return entries
+}
+
+// rotatePoolJournal regenerates the full pool journal.
+func (pool *TxPool) rotatePoolJournal() {
+	pool.mu.RLock()
+	entries := pool.journaled()
+	pool.mu.RUnlock()
+
+	if err := pool.poolJournal.rotate(entries); err != nil {
+		log.Warn("Failed to rotate txpool journal", "err", err)
+	}
+}
+
+// addJournaled enqueues a batch of journaled transactions into the pool. The
+// transactions are validated against the current state as the new ones, and
+// their original arrival times are restored.
+func (pool *TxPool) addJournaled(entries []*poolJournalEntry) []error {
+	var (
+		errs    = make([]error, len(entries))
+		locals  = make(types.Transactions, 0, len(entries))
+		remotes = make(types.Transactions, 0, len(entries))
+	)
+	for _, entry := range entries {
+		if entry.Local {
+			locals = append(locals, entry.Tx)
+		} else {
+			remotes = append(remotes, entry.Tx)
+		}
+	}
+	localErrs := pool.AddLocals(locals)
+	remoteErrs := pool.AddRemotesSync(remotes)
+
+	for i, entry := range entries {
+		if entry.Local {
+			errs[i], localErrs = localErrs[0], localErrs[1:]
+		} else {
+			errs[i], remoteErrs = remoteErrs[0], remoteErrs[1:]
+		}
+		// the local journal may have restored the tx already
+		if errs[i] == ErrAlreadyKnown {
+			errs[i] = nil
+		}
+		if errs[i] == nil {
+			pool.all.SetArrival(entry.Tx.Hash(), entry.arrival())
+		}
+	}
+	return errs
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
@@ -594,6 +707,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if uint64(tx.Size()) > txMaxSize {
 		return ErrOversizedData
 	}
+	// Check whether the init code size has been exceeded.
+	if pool.shanghai && tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
+		return fmt.Errorf("%w: code size %v limit %v", ErrMaxInitCodeSizeExceeded, len(tx.Data()), params.MaxInitCodeSize)
+	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur if you create a transaction using the RPC.
 	if tx.Value().Sign() < 0 {
@@ -643,7 +760,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 		return ErrInsufficientFunds
 	}
 	// Ensure the transaction has more gas than the basic tx fee.
-	intrGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil)
+	intrGas, err := IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, pool.shanghai)
 	if err != nil {
 		return err
 	}
@@ -793,8 +910,9 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 // journalTx adds the specified transaction to the local disk journal if it is
 // deemed to have been sent from a local account.
 func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
-	// Only journal if it's enabled and the transaction is local
-	if pool.journal == nil || !pool.locals.contains(from) {
+	// Only journal if it's enabled and the transaction is local. Private transactions
+	// aren't journaled, so they aren't reloaded as the public ones after restart
+	if pool.journal == nil || !pool.locals.contains(from) || pool.private.contains(tx.Hash()) {
 		return
 	}
 	if err := pool.journal.insert(tx); err != nil {
@@ -854,6 +972,31 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	return errs[0]
 }
 
+// AddPrivate enqueues a single local transaction into the private lane of the pool.
+// Private transactions aren't broadcast to the peers until PrivateLifetime blocks
+// have passed, after which they are broadcast as the regular ones.
+func (pool *TxPool) AddPrivate(tx *types.Transaction) error {
+	hash := tx.Hash()
+	if pool.all.Get(hash) != nil {
+		return ErrAlreadyKnown
+	}
+	// Mark the transaction before adding, so it's never announced
+	expiration := pool.chain.CurrentBlock().NumberU64() + pool.config.PrivateLifetime
+	if !pool.private.add(hash, expiration) {
+		return ErrAlreadyKnown
+	}
+	if err := pool.AddLocal(tx); err != nil {
+		pool.private.remove(hash)
+		return err
+	}
+	return nil
+}
+
+// IsPrivate returns true if the transaction is in the private lane of the pool.
+func (pool *TxPool) IsPrivate(hash common.Hash) bool {
+	return pool.private.contains(hash)
+}
+
 // AddRemotes enqueues a batch of transactions into the pool if they are valid. If the
 // senders are not among the locally tracked ones, full pricing constraints will apply.
 //
@@ -995,6 +1138,45 @@ func (pool *TxPool) OnlyNotExisting(hashes []common.Hash) []common.Hash {
 	return pool.all.OnlyNotExisting(hashes)
 }
 
+// TxArrival returns the time the transaction has arrived into the pool,
+// or the zero time if the transaction isn't in the pool.
+func (pool *TxPool) TxArrival(hash common.Hash) time.Time {
+	return pool.all.Arrival(hash)
+}
+
+// RemoveTx evicts a single transaction from the pool, moving all subsequent
+// transactions of the sender back to the future queue. It returns false if
+// the transaction isn't in the pool.
+func (pool *TxPool) RemoveTx(hash common.Hash) bool {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.all.Get(hash) == nil {
+		return false
+	}
+	pool.removeTx(hash, true)
+	return true
+}
+
+// RemoveSender evicts all the pending and queued transactions of the sender
+// from the pool. It returns the number of evicted transactions.
+func (pool *TxPool) RemoveSender(addr common.Address) int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	var txs types.Transactions
+	if pending := pool.pending[addr]; pending != nil {
+		txs = append(txs, pending.Flatten()...)
+	}
+	if queued := pool.queue[addr]; queued != nil {
+		txs = append(txs, queued.Flatten()...)
+	}
+	for _, tx := range txs {
+		pool.removeTx(tx.Hash(), true)
+	}
+	return len(txs)
+}
+
 // removeTx removes a single transaction from the queue, moving all subsequent
 // transactions back to the future queue.
 func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
@@ -1206,6 +1388,11 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	}
 	pool.mu.Unlock()
 
+	// Private transactions which have expired are announced as the new ones
+	if reset != nil {
+		promoted = append(promoted, pool.private.expire(pool.chain.CurrentBlock().NumberU64(), pool.all.Get)...)
+	}
+
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
 		addr, _ := types.Sender(pool.signer, tx)
@@ -1215,11 +1402,13 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 		events[addr].Put(tx)
 	}
 	if len(events) > 0 {
-		var txs []*types.Transaction
+		var txs types.Transactions
 		for _, set := range events {
 			txs = append(txs, set.Flatten()...)
 		}
-		pool.txFeed.Send(NewTxsNotify{txs})
+		if txs = pool.private.filter(txs); len(txs) > 0 {
+			pool.txFeed.Send(NewTxsNotify{txs})
+		}
 	}
 }
 
@@ -1321,6 +1510,7 @@ func (pool *TxPool) reset(oldHead, newHead *EvmHeader) {
 	pool.istanbul = pool.chainconfig.IsIstanbul(next)
 	pool.eip2718 = pool.chainconfig.IsBerlin(next)
 	pool.eip1559 = pool.chainconfig.IsLondon(next)
+	pool.shanghai = pool.chainconfig.IsShanghai(next)
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1680,17 +1870,19 @@ func (as *accountSet) merge(other *accountSet) {
 // This lookup set combines the notion of "local transactions", which is useful
 // to build upper-level structure.
 type txLookup struct {
-	slots   int
-	lock    sync.RWMutex
-	locals  map[common.Hash]*types.Transaction
-	remotes map[common.Hash]*types.Transaction
+	slots    int
+	lock     sync.RWMutex
+	locals   map[common.Hash]*types.Transaction
+	remotes  map[common.Hash]*types.Transaction
+	arrivals map[common.Hash]time.Time
 }
 
 // newTxLookup returns a new txLookup structure.
 func newTxLookup() *txLookup {
 	return &txLookup{
-		locals:  make(map[common.Hash]*types.Transaction),
-		remotes: make(map[common.Hash]*types.Transaction),
+		locals:   make(map[common.Hash]*types.Transaction),
+		remotes:  make(map[common.Hash]*types.Transaction),
+		arrivals: make(map[common.Hash]time.Time),
 	}
 }
 
@@ -1834,6 +2026,26 @@ func (t *txLookup) Add(tx *types.Transaction, local bool) {
 	} else {
 		t.remotes[tx.Hash()] = tx
 	}
+	t.arrivals[tx.Hash()] = time.Now()
+}
+
+// Arrival returns the time the transaction was added to the lookup, or the zero
+// time if not found.
+func (t *txLookup) Arrival(hash common.Hash) time.Time {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.arrivals[hash]
+}
+
+// SetArrival overrides the time the transaction was added to the lookup.
+func (t *txLookup) SetArrival(hash common.Hash, arrival time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.arrivals[hash]; ok {
+		t.arrivals[hash] = arrival
+	}
 }
 
 // Remove removes a transaction from the lookup.
@@ -1854,6 +2066,7 @@ func (t *txLookup) Remove(hash common.Hash) {
 
 	delete(t.locals, hash)
 	delete(t.remotes, hash)
+	delete(t.arrivals, hash)
 }
 
 // RemoteToLocals migrates the transactions belongs to the given locals to locals
@@ -0,0 +1,114 @@
+package evmcore
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/rlp"
+)
+
+// poolJournalEntry is a journaled transaction of the full pool journal.
+type poolJournalEntry struct {
+	Tx      *types.Transaction
+	Arrival uint64 // Unix time (in nanoseconds) the tx has arrived into the pool
+	Local   bool
+}
+
+// arrival returns the time the journaled tx has arrived into the pool.
+func (e *poolJournalEntry) arrival() time.Time {
+	return time.Unix(0, int64(e.Arrival))
+}
+
+// poolJournal is a snapshot of all the pending and queued transactions of the pool,
+// which is regenerated periodically to allow the whole pool to survive node restarts.
+// Unlike txJournal it isn't appended on every new transaction.
+type poolJournal struct {
+	path string // Filesystem path to store the transactions at
+}
+
+// newPoolJournal creates a new full pool journal.
+func newPoolJournal(path string) *poolJournal {
+	return &poolJournal{
+		path: path,
+	}
+}
+
+// load parses a pool journal dump from disk, loading its contents into
+// the specified pool.
+func (journal *poolJournal) load(add func([]*poolJournalEntry) []error) error {
+	// Skip the parsing if the journal file doesn't exist at all
+	if _, err := os.Stat(journal.path); os.IsNotExist(err) {
+		return nil
+	}
+	input, err := os.Open(journal.path)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	stream := rlp.NewStream(input, 0)
+	total, dropped := 0, 0
+
+	loadBatch := func(entries []*poolJournalEntry) {
+		for _, err := range add(entries) {
+			if err != nil {
+				log.Debug("Failed to add journaled transaction", "err", err)
+				dropped++
+			}
+		}
+	}
+	var (
+		failure error
+		batch   []*poolJournalEntry
+	)
+	for {
+		entry := new(poolJournalEntry)
+		if err = stream.Decode(entry); err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			if len(batch) > 0 {
+				loadBatch(batch)
+			}
+			break
+		}
+		total++
+
+		if batch = append(batch, entry); len(batch) > 1024 {
+			loadBatch(batch)
+			batch = batch[:0]
+		}
+	}
+	log.Info("Loaded txpool journal", "transactions", total, "dropped", dropped)
+
+	return failure
+}
+
+// rotate regenerates the pool journal based on the current contents of
+// the transaction pool.
+func (journal *poolJournal) rotate(entries []*poolJournalEntry) error {
+	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err = rlp.Encode(replacement, entry); err != nil {
+			replacement.Close()
+			return err
+		}
+	}
+	if err = replacement.Close(); err != nil {
+		return err
+	}
+
+	// Replace the live journal with the newly generated one
+	if err = os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	log.Info("Regenerated txpool journal", "transactions", len(entries))
+
+	return nil
+}
@@ -24,6 +24,8 @@ import (
 	"math/big"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -2372,6 +2374,174 @@ func testTransactionJournaling(t *testing.T, nolocals bool) {
 	pool.Stop()
 }
 
+// TestTransactionFullJournaling tests that both local and remote transactions
+// survive pool restarts with their arrival times if the full pool journal is enabled.
+func TestTransactionFullJournaling(t *testing.T) {
+	t.Parallel()
+
+	journal := filepath.Join(t.TempDir(), "txpool.rlp")
+
+	// Create the original pool to inject transaction into the journal
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	config := testTxPoolConfig
+	config.NoLocals = true
+	config.FullJournal = journal
+	config.Rejournal = time.Second
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+
+	remote1, _ := crypto.GenerateKey()
+	remote2, _ := crypto.GenerateKey()
+
+	testAddBalance(pool, crypto.PubkeyToAddress(remote1.PublicKey), big.NewInt(1000000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(remote2.PublicKey), big.NewInt(1000000000))
+
+	// Add two pending and a queued transactions
+	txs := []*types.Transaction{
+		pricedTransaction(0, 100000, big.NewInt(1), remote1),
+		pricedTransaction(1, 100000, big.NewInt(1), remote1),
+		pricedTransaction(2, 100000, big.NewInt(1), remote2),
+	}
+	for _, err := range pool.AddRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("failed to add remote transaction: %v", err)
+		}
+	}
+	arrival := pool.TxArrival(txs[1].Hash())
+	if arrival.IsZero() {
+		t.Fatalf("arrival time isn't tracked")
+	}
+	pending, queued := pool.Stats()
+	if pending != 2 || queued != 1 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 2, 1)
+	}
+	// Terminate the old pool, bump the nonce, create a new pool and ensure valid transactions survive
+	pool.Stop()
+	statedb.SetNonce(crypto.PubkeyToAddress(remote1.PublicKey), 1)
+	blockchain = &testBlockChain{statedb, 1000000, new(event.Feed)}
+
+	pool = NewTxPool(config, params.TestChainConfig, blockchain)
+
+	pending, queued = pool.Stats()
+	if pending != 1 || queued != 1 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 1, 1)
+	}
+	if have := pool.TxArrival(txs[1].Hash()); !have.Equal(arrival) {
+		t.Fatalf("arrival time isn't restored: have %v, want %v", have, arrival)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// Evict the transactions and ensure they don't survive the restart
+	if !pool.RemoveTx(txs[1].Hash()) {
+		t.Fatalf("failed to evict transaction")
+	}
+	if pool.RemoveTx(txs[1].Hash()) {
+		t.Fatalf("evicted unknown transaction")
+	}
+	if removed := pool.RemoveSender(crypto.PubkeyToAddress(remote2.PublicKey)); removed != 1 {
+		t.Fatalf("evicted transactions mismatched: have %d, want %d", removed, 1)
+	}
+	pool.Stop()
+
+	pool = NewTxPool(config, params.TestChainConfig, blockchain)
+
+	pending, queued = pool.Stats()
+	if pending != 0 || queued != 0 {
+		t.Fatalf("pool stats mismatched: have %d/%d, want %d/%d", pending, queued, 0, 0)
+	}
+	pool.Stop()
+}
+
+// numberedTestBlockChain is a testBlockChain with a movable head block number.
+type numberedTestBlockChain struct {
+	*testBlockChain
+	number uint64
+}
+
+func (bc *numberedTestBlockChain) CurrentBlock() *EvmBlock {
+	block := bc.testBlockChain.CurrentBlock()
+	block.Number = new(big.Int).SetUint64(atomic.LoadUint64(&bc.number))
+	return block
+}
+
+// TestTransactionPrivateLane tests that private transactions aren't announced
+// until they are expired.
+func TestTransactionPrivateLane(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &numberedTestBlockChain{&testBlockChain{statedb, 1000000, new(event.Feed)}, 1}
+
+	config := testTxPoolConfig
+	config.PrivateLifetime = 2
+
+	pool := NewTxPool(config, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	events := make(chan NewTxsNotify, 32)
+	sub := pool.txFeed.Subscribe(events)
+	defer sub.Unsubscribe()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	private := transaction(0, 100000, key)
+	if err := pool.AddPrivate(private); err != nil {
+		t.Fatalf("failed to add private transaction: %v", err)
+	}
+	if err := pool.AddPrivate(private); err != ErrAlreadyKnown {
+		t.Fatalf("duplicate private transaction error mismatch: have %v, want %v", err, ErrAlreadyKnown)
+	}
+	if !pool.IsPrivate(private.Hash()) {
+		t.Fatalf("transaction isn't private")
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Fatalf("pending transactions mismatched: have %d, want %d", pending, 1)
+	}
+	if hashes := pool.SampleHashes(10); len(hashes) != 0 {
+		t.Fatalf("private transaction is sampled: %v", hashes)
+	}
+	// Public transactions are announced as usual
+	public := transaction(1, 100000, key)
+	if err := pool.AddLocal(public); err != nil {
+		t.Fatalf("failed to add local transaction: %v", err)
+	}
+	if err := validateEvents(events, 1); err != nil {
+		t.Fatalf("public transaction event firing failed: %v", err)
+	}
+	// Private transactions aren't exposed in the pool content
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	if pending, _ := pool.Content(); len(pending[from]) != 1 || pending[from][0].Hash() != public.Hash() {
+		t.Fatalf("pending content mismatched: have %v, want %v", pending[from], types.Transactions{public})
+	}
+	if pending, _ := pool.ContentFrom(from); len(pending) != 1 || pending[0].Hash() != public.Hash() {
+		t.Fatalf("pending content of the sender mismatched: have %v, want %v", pending, types.Transactions{public})
+	}
+	// Private transaction is still private until the expiration block
+	atomic.StoreUint64(&blockchain.number, 2)
+	<-pool.requestReset(nil, nil)
+	if err := validateEvents(events, 0); err != nil {
+		t.Fatalf("private transaction event fired: %v", err)
+	}
+	atomic.StoreUint64(&blockchain.number, 3)
+	<-pool.requestReset(nil, nil)
+	if err := validateEvents(events, 1); err != nil {
+		t.Fatalf("expired transaction event firing failed: %v", err)
+	}
+	if pool.IsPrivate(private.Hash()) {
+		t.Fatalf("expired transaction is private")
+	}
+	if pending, _ := pool.ContentFrom(from); len(pending) != 2 {
+		t.Fatalf("expired transaction isn't exposed in the pool content: have %d, want %d", len(pending), 2)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // TestTransactionStatusCheck tests that the pool can correctly retrieve the
 // pending status of individual transactions.
 func TestTransactionStatusCheck(t *testing.T) {
@@ -0,0 +1,105 @@
+package evmcore
+
+import (
+	"sync"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+)
+
+// txPrivateLane tracks the private transactions of the pool. Private transactions
+// are included into events by the local validator only, and aren't broadcast to
+// the peers until the expiration block.
+type txPrivateLane struct {
+	lock    sync.RWMutex
+	expires map[common.Hash]uint64 // Block number the private tx becomes public at
+}
+
+func newTxPrivateLane() *txPrivateLane {
+	return &txPrivateLane{
+		expires: make(map[common.Hash]uint64),
+	}
+}
+
+// add marks the transaction as private until the expiration block. It returns
+// false if the transaction is already private.
+func (l *txPrivateLane) add(hash common.Hash, expiration uint64) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.expires[hash]; ok {
+		return false
+	}
+	l.expires[hash] = expiration
+	return true
+}
+
+// remove unmarks the transaction.
+func (l *txPrivateLane) remove(hash common.Hash) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.expires, hash)
+}
+
+// contains returns true if the transaction is private.
+func (l *txPrivateLane) contains(hash common.Hash) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	_, ok := l.expires[hash]
+	return ok
+}
+
+// filterHashes returns the hashes of non-private transactions.
+func (l *txPrivateLane) filterHashes(hashes []common.Hash) []common.Hash {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if len(l.expires) == 0 {
+		return hashes
+	}
+	public := make([]common.Hash, 0, len(hashes))
+	for _, hash := range hashes {
+		if _, ok := l.expires[hash]; !ok {
+			public = append(public, hash)
+		}
+	}
+	return public
+}
+
+// filter returns the non-private transactions.
+func (l *txPrivateLane) filter(txs types.Transactions) types.Transactions {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if len(l.expires) == 0 {
+		return txs
+	}
+	public := make(types.Transactions, 0, len(txs))
+	for _, tx := range txs {
+		if _, ok := l.expires[tx.Hash()]; !ok {
+			public = append(public, tx)
+		}
+	}
+	return public
+}
+
+// expire unmarks the transactions which are expired at the block. It returns
+// the expired transactions which are still in the pool.
+func (l *txPrivateLane) expire(block uint64, get func(common.Hash) *types.Transaction) types.Transactions {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var expired types.Transactions
+	for hash, expiration := range l.expires {
+		if block < expiration {
+			continue
+		}
+		delete(l.expires, hash)
+		if tx := get(hash); tx != nil {
+			expired = append(expired, tx)
+		}
+	}
+	return expired
+}
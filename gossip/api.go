@@ -35,3 +35,19 @@ func (api *PublicEthereumAPI) Hashrate() hexutil.Uint64 {
 func (api *PublicEthereumAPI) ChainId() hexutil.Uint64 {
 	return hexutil.Uint64(api.s.store.GetRules().NetworkID)
 }
+
+// PrivateAdminAPI is the collection of administrative API methods of the service.
+type PrivateAdminAPI struct {
+	s *Service
+}
+
+// NewPrivateAdminAPI creates a new API definition for the administrative methods of the service.
+func NewPrivateAdminAPI(s *Service) *PrivateAdminAPI {
+	return &PrivateAdminAPI{s}
+}
+
+// Backup writes a consistent point-in-time backup of the node DBs into the directory,
+// which must be empty or non-existent. The node keeps running during the backup.
+func (api *PrivateAdminAPI) Backup(dir string) (*BackupManifest, error) {
+	return api.s.Backup(dir)
+}
@@ -0,0 +1,149 @@
+package gossip
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/sesadb"
+	"github.com/sesanetwork/go-vassalo/sesadb/multidb"
+	"github.com/sesanetwork/go-sesa/common"
+)
+
+// BackupVersion is the version of the DBs backup layout
+const BackupVersion = 1
+
+type (
+	// DBsSnapshots are the snapshots of the physical DBs, by DB type and name.
+	DBsSnapshots map[multidb.TypeName]map[string]sesadb.Snapshot
+
+	// DBsSnapshoter is a DBs producer which is able to take consistent snapshots of all its physical DBs.
+	// The DBs which are able to write their checkpoints are checkpointed into the backup directory
+	// instead, and have no snapshots.
+	DBsSnapshoter interface {
+		SnapshotDBs(dir string) (DBsSnapshots, error)
+	}
+
+	// BackupManifest describes a point-in-time backup of the node DBs.
+	BackupManifest struct {
+		Version   uint64      `json:"version"`
+		GenesisID common.Hash `json:"genesisId"`
+		NetworkID uint64      `json:"networkId"`
+		Epoch     idx.Epoch   `json:"epoch"`
+		Block     idx.Block   `json:"block"`
+		Time      time.Time   `json:"time"`
+		DBs       []BackupDB  `json:"dbs"`
+		// Freezer is nil if the backup has no frozen history
+		Freezer *BackupFreezer `json:"freezer,omitempty"`
+	}
+
+	// BackupDB is a physical DB of the backup.
+	BackupDB struct {
+		Type    multidb.TypeName `json:"type"`
+		Name    string           `json:"name"`
+		Records uint64           `json:"records"`
+		Digest  common.Hash      `json:"digest"` // the hash of all the keys and values
+	}
+
+	// BackupFreezer is the range of the frozen history of the backup.
+	BackupFreezer struct {
+		Blocks uint64 `json:"blocks"` // the next block to freeze
+		Epochs uint64 `json:"epochs"` // the next epoch to freeze
+	}
+
+	// BackupWriter writes the DBs snapshots into the backup directory, which has the DBs checkpoints
+	// of the same point, and fills the DBs of the manifest.
+	BackupWriter func(dir string, snapshots DBsSnapshots, manifest *BackupManifest) error
+)
+
+// Release releases all the snapshots.
+func (ss DBsSnapshots) Release() {
+	for _, snapshots := range ss {
+		for _, snap := range snapshots {
+			snap.Release()
+		}
+	}
+}
+
+// SnapshotDBs takes the snapshots or the checkpoints of all the physical DBs, writing the
+// checkpoints into the backup directory. The data which isn't flushed yet isn't included.
+func (s *Store) SnapshotDBs(dir string) (DBsSnapshots, error) {
+	snapshoter, ok := s.dbs.(DBsSnapshoter)
+	if !ok {
+		return nil, errors.New("DBs snapshots aren't supported")
+	}
+	return snapshoter.SnapshotDBs(dir)
+}
+
+// BackupManifest returns the backup manifest of the current flushed state, without DBs.
+func (s *Store) BackupManifest() *BackupManifest {
+	return &BackupManifest{
+		Version:   BackupVersion,
+		GenesisID: common.Hash(*s.GetGenesisID()),
+		NetworkID: s.GetRules().NetworkID,
+		Epoch:     s.GetEpoch(),
+		Block:     s.GetBlockState().LastBlock.Idx,
+		Time:      time.Now().UTC(),
+	}
+}
+
+// backuper writes the backups of the service DBs one at a time.
+type backuper struct {
+	mu     sync.Mutex
+	writer BackupWriter
+}
+
+// RegisterBackupWriter sets the writer of the DBs backups. Backups aren't supported without a writer.
+func (s *Service) RegisterBackupWriter(w BackupWriter) {
+	s.backuper.writer = w
+}
+
+// Backup writes a consistent point-in-time backup of the node DBs into the directory.
+// The DBs are flushed at the current block boundary, and the events and blocks processing
+// is paused only for the time of taking the DBs snapshots and checkpoints. The history freezing is paused
+// until the backup is written, so the freezer files are copied as of the snapshots.
+func (s *Service) Backup(dir string) (*BackupManifest, error) {
+	if s.backuper.writer == nil {
+		return nil, errors.New("DBs backups aren't supported")
+	}
+	s.backuper.mu.Lock()
+	defer s.backuper.mu.Unlock()
+
+	snapshots, manifest, err := s.snapshotDBs(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer snapshots.Release()
+
+	start := time.Now()
+	s.Log.Info("Writing DBs backup", "dir", dir, "epoch", manifest.Epoch, "block", manifest.Block)
+	err = s.backuper.writer(dir, snapshots, manifest)
+	if err != nil {
+		s.Log.Error("Failed to write DBs backup", "dir", dir, "err", err)
+		return nil, err
+	}
+	s.Log.Info("DBs backup is written", "dir", dir, "dbs", len(manifest.DBs), "elapsed", common.PrettyDuration(time.Since(start)))
+	return manifest, nil
+}
+
+func (s *Service) snapshotDBs(dir string) (DBsSnapshots, *BackupManifest, error) {
+	s.engineMu.Lock()
+	defer s.engineMu.Unlock()
+	if s.stopped {
+		return nil, nil, errors.New("service is stopped")
+	}
+	s.blockProcWg.Wait()
+
+	// write the state of the last block, to not re-execute blocks after restoring
+	s.store.commitEVM(true)
+	err := s.store.Commit()
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshots, err := s.store.SnapshotDBs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return snapshots, s.store.BackupManifest(), nil
+}
@@ -0,0 +1,122 @@
+package hookmodule
+
+import (
+	"github.com/sesanetwork/go-sesa/core/state"
+	"github.com/sesanetwork/go-sesa/core/types"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/gossip/blockproc"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+)
+
+// TxListenerModule runs the extra tx listeners along with the primary one.
+// Only the primary listener may modify the block state, the states returned by the extra listeners are ignored.
+type TxListenerModule struct {
+	primary blockproc.TxListenerModule
+	extra   []blockproc.TxListenerModule
+}
+
+func NewTxListenerModule(primary blockproc.TxListenerModule, extra ...blockproc.TxListenerModule) blockproc.TxListenerModule {
+	if len(extra) == 0 {
+		return primary
+	}
+	return &TxListenerModule{
+		primary: primary,
+		extra:   extra,
+	}
+}
+
+func (m *TxListenerModule) Start(block iblockproc.BlockCtx, bs iblockproc.BlockState, es iblockproc.EpochState, statedb *state.StateDB) blockproc.TxListener {
+	l := &TxListener{
+		primary: m.primary.Start(block, bs, es, statedb),
+		extra:   make([]blockproc.TxListener, len(m.extra)),
+	}
+	for i, e := range m.extra {
+		// the extra listeners get a copy, so the primary one's state cannot be altered by them
+		l.extra[i] = e.Start(block, bs.Copy(), es.Copy(), statedb)
+	}
+	return l
+}
+
+type TxListener struct {
+	primary blockproc.TxListener
+	extra   []blockproc.TxListener
+}
+
+func (l *TxListener) OnNewLog(log *types.Log) {
+	l.primary.OnNewLog(log)
+	for _, e := range l.extra {
+		e.OnNewLog(log)
+	}
+}
+
+func (l *TxListener) OnNewReceipt(tx *types.Transaction, r *types.Receipt, originator idx.ValidatorID) {
+	l.primary.OnNewReceipt(tx, r, originator)
+	for _, e := range l.extra {
+		e.OnNewReceipt(tx, r, originator)
+	}
+}
+
+func (l *TxListener) Finalize() iblockproc.BlockState {
+	bs := l.primary.Finalize()
+	for _, e := range l.extra {
+		_ = e.Finalize()
+	}
+	return bs
+}
+
+func (l *TxListener) Update(bs iblockproc.BlockState, es iblockproc.EpochState) {
+	l.primary.Update(bs, es)
+	for _, e := range l.extra {
+		e.Update(bs.Copy(), es.Copy())
+	}
+}
+
+// ConfirmedEventsModule runs the extra confirmed events observers along with the primary processor.
+// Only the primary processor may modify the block state, the states returned by the observers are ignored.
+type ConfirmedEventsModule struct {
+	primary blockproc.ConfirmedEventsModule
+	extra   []blockproc.ConfirmedEventsModule
+}
+
+func NewConfirmedEventsModule(primary blockproc.ConfirmedEventsModule, extra ...blockproc.ConfirmedEventsModule) blockproc.ConfirmedEventsModule {
+	if len(extra) == 0 {
+		return primary
+	}
+	return &ConfirmedEventsModule{
+		primary: primary,
+		extra:   extra,
+	}
+}
+
+func (m *ConfirmedEventsModule) Start(bs iblockproc.BlockState, es iblockproc.EpochState) blockproc.ConfirmedEventsProcessor {
+	p := &ConfirmedEventsProcessor{
+		primary: m.primary.Start(bs, es),
+		extra:   make([]blockproc.ConfirmedEventsProcessor, len(m.extra)),
+	}
+	for i, e := range m.extra {
+		p.extra[i] = e.Start(bs.Copy(), es.Copy())
+	}
+	return p
+}
+
+type ConfirmedEventsProcessor struct {
+	primary blockproc.ConfirmedEventsProcessor
+	extra   []blockproc.ConfirmedEventsProcessor
+}
+
+func (p *ConfirmedEventsProcessor) ProcessConfirmedEvent(e native.EventI) {
+	p.primary.ProcessConfirmedEvent(e)
+	for _, x := range p.extra {
+		x.ProcessConfirmedEvent(e)
+	}
+}
+
+func (p *ConfirmedEventsProcessor) Finalize(block iblockproc.BlockCtx, blockSkipped bool) iblockproc.BlockState {
+	bs := p.primary.Finalize(block, blockSkipped)
+	for _, x := range p.extra {
+		_ = x.Finalize(block, blockSkipped)
+	}
+	return bs
+}
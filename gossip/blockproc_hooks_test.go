@@ -0,0 +1,147 @@
+package gossip
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/utils/cachescale"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/state"
+	"github.com/sesanetwork/go-sesa/core/types"
+
+	"github.com/sesanetwork/go-sesa/gossip/blockproc"
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+type recordingHooks struct {
+	mu        sync.Mutex
+	logs      int
+	receipts  map[common.Hash]*types.Receipt
+	events    map[hash.Event]bool
+	finalized []idx.Block
+}
+
+func newRecordingHooks() *recordingHooks {
+	return &recordingHooks{
+		receipts: make(map[common.Hash]*types.Receipt),
+		events:   make(map[hash.Event]bool),
+	}
+}
+
+func (h *recordingHooks) Start(block iblockproc.BlockCtx, bs iblockproc.BlockState, es iblockproc.EpochState, statedb *state.StateDB) blockproc.TxListener {
+	return &recordingTxListener{h, bs}
+}
+
+type recordingTxListener struct {
+	h  *recordingHooks
+	bs iblockproc.BlockState
+}
+
+func (l *recordingTxListener) OnNewLog(*types.Log) {
+	l.h.mu.Lock()
+	defer l.h.mu.Unlock()
+	l.h.logs++
+}
+
+func (l *recordingTxListener) OnNewReceipt(tx *types.Transaction, r *types.Receipt, originator idx.ValidatorID) {
+	l.h.mu.Lock()
+	defer l.h.mu.Unlock()
+	l.h.receipts[tx.Hash()] = r
+}
+
+func (l *recordingTxListener) Finalize() iblockproc.BlockState {
+	// try to corrupt the block state, which must be ignored
+	l.bs.EpochGas = 0
+	return l.bs
+}
+
+func (l *recordingTxListener) Update(bs iblockproc.BlockState, es iblockproc.EpochState) {}
+
+type recordingEventsModule struct {
+	h *recordingHooks
+}
+
+func (m recordingEventsModule) Start(bs iblockproc.BlockState, es iblockproc.EpochState) blockproc.ConfirmedEventsProcessor {
+	return &recordingEventsProcessor{m.h, bs}
+}
+
+type recordingEventsProcessor struct {
+	h  *recordingHooks
+	bs iblockproc.BlockState
+}
+
+func (p *recordingEventsProcessor) ProcessConfirmedEvent(e native.EventI) {
+	p.h.mu.Lock()
+	defer p.h.mu.Unlock()
+	p.h.events[e.ID()] = true
+}
+
+func (p *recordingEventsProcessor) Finalize(block iblockproc.BlockCtx, blockSkipped bool) iblockproc.BlockState {
+	p.h.mu.Lock()
+	defer p.h.mu.Unlock()
+	if !blockSkipped {
+		p.h.finalized = append(p.h.finalized, block.Idx)
+	}
+	return p.bs
+}
+
+func TestBlockProcHooks(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	const validatorsNum = 3
+
+	hooks := newRecordingHooks()
+	disabled := newRecordingHooks()
+
+	cfg := DefaultConfig(cachescale.Identity)
+	cfg.BlockProcHooks.Disabled = []string{"disabled"}
+	env := newTestEnvWithHooks(2, validatorsNum, cfg, func(svc *Service) {
+		svc.RegisterTxListenerModule("recorder", hooks)
+		svc.RegisterConfirmedEventsModule("recorder", recordingEventsModule{hooks})
+		svc.RegisterTxListenerModule("disabled", disabled)
+		svc.RegisterConfirmedEventsModule("disabled", recordingEventsModule{disabled})
+	})
+	defer env.Close()
+
+	for n := 0; n < 3; n++ {
+		txs := make([]*types.Transaction, validatorsNum)
+		for i := idx.Validator(0); i < validatorsNum; i++ {
+			txs[i] = env.Transfer(idx.ValidatorID(i+1), 1, utils.Tosesa(100))
+		}
+		rr, err := env.ApplyTxs(sameEpoch, txs...)
+		require.NoError(err)
+
+		hooks.mu.Lock()
+		for _, r := range rr {
+			got, ok := hooks.receipts[r.TxHash]
+			require.True(ok, r.TxHash.String())
+			require.Equal(r.Status, got.Status)
+			require.Equal(r.BlockNumber, got.BlockNumber)
+		}
+		hooks.mu.Unlock()
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	require.NotEmpty(hooks.events)
+	for id := range hooks.events {
+		require.True(env.store.HasEvent(id))
+	}
+	require.NotEmpty(hooks.finalized)
+	for i := 1; i < len(hooks.finalized); i++ {
+		require.Greater(hooks.finalized[i], hooks.finalized[i-1])
+	}
+	// the hooks cannot modify the block state
+	require.NotZero(env.store.GetBlockState().EpochGas)
+
+	require.Empty(disabled.receipts)
+	require.Empty(disabled.events)
+	require.Empty(disabled.finalized)
+}
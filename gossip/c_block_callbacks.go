@@ -26,6 +26,7 @@ import (
 	"github.com/sesanetwork/go-sesa/native"
 	"github.com/sesanetwork/go-sesa/native/iblockproc"
 	"github.com/sesanetwork/go-sesa/sesa"
+	"github.com/sesanetwork/go-sesa/tracing"
 	"github.com/sesanetwork/go-sesa/utils"
 )
 
@@ -69,7 +70,7 @@ func (s *Service) GetConsensusCallbacks() utypes.ConsensusCallbacks {
 			&s.blockProcWg,
 			&s.blockBusyFlag,
 			s.store,
-			s.blockProcModules,
+			s.blockProcModules.withHooks(),
 			s.config.TxIndex,
 			&s.feed,
 			&s.emitters,
@@ -193,6 +194,14 @@ func consensusCallbackBeginBlockFn(
 						}
 					}
 				}
+				if tracing.Enabled() && e.AnyTxs() {
+					for _, tx := range store.GetEventPayload(e.ID()).Txs() {
+						tracing.TxStage(tx.Hash(), "Consensus.ConfirmEvent()",
+							tracing.EventKey.String(e.ID().String()),
+							tracing.EpochKey.Int64(int64(e.Epoch())),
+							tracing.AtroposKey.String(cBlock.Event.String()))
+					}
+				}
 				eventProcessor.ProcessConfirmedEvent(e)
 				for _, em := range *emitters {
 					em.OnEventConfirmed(e)
@@ -369,6 +378,9 @@ func consensusCallbackBeginBlockFn(
 							creator = 0
 						}
 						txListener.OnNewReceipt(evmBlock.Transactions[i], r, creator)
+						tracing.FinishTx(r.TxHash, "BlockProc.Execute()",
+							tracing.BlockKey.Int64(int64(blockCtx.Idx)),
+							tracing.StatusKey.Int64(int64(r.Status)))
 					}
 					bs = txListener.Finalize() // TODO: refactor to not mutate the bs
 					bs.FinalizedStateRoot = block.Root
@@ -15,6 +15,7 @@ import (
 	"github.com/sesanetwork/go-sesa/eventcheck"
 	"github.com/sesanetwork/go-sesa/eventcheck/epochcheck"
 	"github.com/sesanetwork/go-sesa/gossip/emitter"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
 	"github.com/sesanetwork/go-sesa/native"
 	"github.com/sesanetwork/go-sesa/native/iblockproc"
 	"github.com/sesanetwork/go-sesa/utils/concurrent"
@@ -137,6 +138,8 @@ func (s *Service) switchEpochTo(newEpoch idx.Epoch) {
 		em.OnNewEpoch(s.store.GetValidators(), newEpoch)
 	}
 	s.feed.newEpoch.Send(newEpoch)
+	bs, epochState := s.store.GetBlockEpochState()
+	s.notifier.Send(&s.feed.newEpochState, filters.EpochNotify{BlockState: &bs, EpochState: &epochState})
 }
 
 func (s *Service) SwitchEpochTo(newEpoch idx.Epoch) error {
@@ -264,6 +267,7 @@ func (s *Service) processEvent(e *native.EventPayload) error {
 	for _, em := range s.emitters {
 		em.OnEventConnected(e)
 	}
+	s.notifier.Send(&s.feed.newEvent, e)
 
 	if newEpoch != oldEpoch {
 		s.switchEpochTo(newEpoch)
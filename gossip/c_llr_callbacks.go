@@ -10,6 +10,7 @@ import (
 	"github.com/sesanetwork/go-sesa/core/types"
 
 	"github.com/sesanetwork/go-sesa/eventcheck"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
 	"github.com/sesanetwork/go-sesa/gossip/evmstore"
 	"github.com/sesanetwork/go-sesa/native"
 	"github.com/sesanetwork/go-sesa/native/iblockproc"
@@ -30,7 +31,7 @@ func actualizeLowestIndex(current, upd uint64, exists func(uint64) bool) uint64
 	return current
 }
 
-func (s *Service) processBlockVote(block idx.Block, epoch idx.Epoch, bv hash.Hash, val idx.Validator, vals *pos.Validators, llrs *LlrState) {
+func (s *Service) processBlockVote(block idx.Block, epoch idx.Epoch, bv hash.Hash, val idx.Validator, vals *pos.Validators, llrs *LlrState) (decided bool) {
 	newWeight := s.store.AddLlrBlockVoteWeight(block, epoch, bv, val, vals.Len(), vals.GetWeightByIdx(val))
 	if newWeight >= vals.TotalWeight()/3+1 {
 		wonBr := s.store.GetLlrBlockResult(block)
@@ -39,10 +40,12 @@ func (s *Service) processBlockVote(block idx.Block, epoch idx.Epoch, bv hash.Has
 			llrs.LowestBlockToDecide = idx.Block(actualizeLowestIndex(uint64(llrs.LowestBlockToDecide), uint64(block), func(u uint64) bool {
 				return s.store.GetLlrBlockResult(idx.Block(u)) != nil
 			}))
+			return true
 		} else if *wonBr != bv {
 			s.Log.Error("LLR voting doublesign is met", "block", block)
 		}
 	}
+	return false
 }
 
 func (s *Service) processBlockVotes(bvs native.LlrSignedBlockVotes) error {
@@ -69,13 +72,20 @@ func (s *Service) processBlockVotes(bvs native.LlrSignedBlockVotes) error {
 		return errValidatorNotExist
 	}
 
+	var decided []filters.LlrBlockResultNotify
 	s.store.ModifyLlrState(func(llrs *LlrState) {
 		b := bvs.Val.Start
 		for _, bv := range bvs.Val.Votes {
-			s.processBlockVote(b, bvs.Val.Epoch, bv, es.Validators.GetIdx(vid), es.Validators, llrs)
+			if s.processBlockVote(b, bvs.Val.Epoch, bv, es.Validators.GetIdx(vid), es.Validators, llrs) {
+				decided = append(decided, filters.LlrBlockResultNotify{Block: b, Hash: bv})
+			}
 			b++
 		}
 	})
+	// notify outside of the LLR state lock
+	for _, n := range decided {
+		s.notifier.Send(&s.feed.llrBlockResult, n)
+	}
 	s.store.SetBlockVotes(bvs)
 	lBVs := s.store.GetLastBVs()
 	lBVs.Lock()
@@ -171,7 +181,7 @@ func (s *Service) ProcessFullBlockRecord(br ibr.LlrIdxFullBlockRecord) error {
 	return nil
 }
 
-func (s *Service) processRawEpochVote(epoch idx.Epoch, ev hash.Hash, val idx.Validator, vals *pos.Validators, llrs *LlrState) {
+func (s *Service) processRawEpochVote(epoch idx.Epoch, ev hash.Hash, val idx.Validator, vals *pos.Validators, llrs *LlrState) (decided bool) {
 	newWeight := s.store.AddLlrEpochVoteWeight(epoch, ev, val, vals.Len(), vals.GetWeightByIdx(val))
 	if newWeight >= vals.TotalWeight()/3+1 {
 		wonEr := s.store.GetLlrEpochResult(epoch)
@@ -180,10 +190,12 @@ func (s *Service) processRawEpochVote(epoch idx.Epoch, ev hash.Hash, val idx.Val
 			llrs.LowestEpochToDecide = idx.Epoch(actualizeLowestIndex(uint64(llrs.LowestEpochToDecide), uint64(epoch), func(u uint64) bool {
 				return s.store.GetLlrEpochResult(idx.Epoch(u)) != nil
 			}))
+			return true
 		} else if *wonEr != ev {
 			s.Log.Error("LLR voting doublesign is met", "epoch", epoch)
 		}
 	}
+	return false
 }
 
 func (s *Service) processEpochVote(ev native.LlrSignedEpochVote) error {
@@ -209,9 +221,13 @@ func (s *Service) processEpochVote(ev native.LlrSignedEpochVote) error {
 		return errValidatorNotExist
 	}
 
+	var decided bool
 	s.store.ModifyLlrState(func(llrs *LlrState) {
-		s.processRawEpochVote(ev.Val.Epoch, ev.Val.Vote, es.Validators.GetIdx(vid), es.Validators, llrs)
+		decided = s.processRawEpochVote(ev.Val.Epoch, ev.Val.Vote, es.Validators.GetIdx(vid), es.Validators, llrs)
 	})
+	if decided {
+		s.notifier.Send(&s.feed.llrEpochResult, filters.LlrEpochResultNotify{Epoch: ev.Val.Epoch, Hash: ev.Val.Vote})
+	}
 	s.store.SetEpochVote(ev)
 	lEVs := s.store.GetLastEVs()
 	lEVs.Lock()
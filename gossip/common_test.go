@@ -131,6 +131,16 @@ func (m testConfirmedEventsModule) Start(bs iblockproc.BlockState, es iblockproc
 }
 
 func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
+	return newTestEnvWithHooks(firstEpoch, validatorsNum, DefaultConfig(cachescale.Identity), nil)
+}
+
+// newTestEnvWithHooks calls register to install the block processing hooks before the consensus is started
+func newTestEnvWithHooks(firstEpoch idx.Epoch, validatorsNum idx.Validator, cfg Config, register func(svc *Service)) *testEnv {
+	return newTestEnvWithStore(firstEpoch, validatorsNum, cfg, NewMemStore(), register)
+}
+
+// newTestEnvWithStore applies the genesis to the empty store and runs the service over it
+func newTestEnvWithStore(firstEpoch idx.Epoch, validatorsNum idx.Validator, cfg Config, store *Store, register func(svc *Service)) *testEnv {
 	rules := sesa.FakeNetRules()
 	rules.Epochs.MaxEpochDuration = native.Timestamp(maxEpochDuration)
 	rules.Blocks.MaxEmptyBlockSkipPeriod = 0
@@ -138,7 +148,6 @@ func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
 	genStore := makefakegenesis.FakeGenesisStoreWithRulesAndStart(validatorsNum, utils.Tosesa(genesisBalance), utils.Tosesa(genesisStake), rules, firstEpoch, 2)
 	genesis := genStore.Genesis()
 
-	store := NewMemStore()
 	_, err := store.ApplyGenesis(genesis)
 	if err != nil {
 		panic(err)
@@ -156,12 +165,15 @@ func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
 
 	// create the service
 	txPool := &dummyTxPool{}
-	env.Service, err = newService(DefaultConfig(cachescale.Identity), store, blockProc, engine, vecClock, func(_ evmcore.StateReader) TxPool {
+	env.Service, err = newService(cfg, store, blockProc, engine, vecClock, func(_ evmcore.StateReader) TxPool {
 		return txPool
 	})
 	if err != nil {
 		panic(err)
 	}
+	if register != nil {
+		register(env.Service)
+	}
 	txPool.signer = env.EthAPI.signer
 	err = engine.Bootstrap(env.GetConsensusCallbacks())
 	if err != nil {
@@ -196,11 +208,13 @@ func newTestEnv(firstEpoch idx.Epoch, validatorsNum idx.Validator) *testEnv {
 	_ = env.store.GenerateSnapshotAt(common.Hash(store.GetBlockState().FinalizedStateRoot), false)
 	env.blockProcTasks.Start(1)
 	env.verWatcher.Start()
+	env.notifier.Start()
 
 	return env
 }
 
 func (env *testEnv) Close() {
+	env.notifier.Stop()
 	env.verWatcher.Stop()
 	env.store.Close()
 	env.tflusher.Stop()
@@ -104,6 +104,14 @@ type (
 		AllowUnprotectedTxs bool
 
 		RPCBlockExt bool
+
+		BlockProcHooks BlockProcHooksConfig
+	}
+
+	// BlockProcHooksConfig is the config of the block processing modules registered by the embedding application.
+	BlockProcHooksConfig struct {
+		// Disabled is the list of registered modules names, which aren't run
+		Disabled []string `toml:",omitempty"`
 	}
 
 	StoreCacheConfig struct {
@@ -130,6 +138,10 @@ type (
 		MaxNonFlushedSize   int
 		MaxNonFlushedPeriod time.Duration
 		TraceTransactions   bool
+		// HistoryRetention is a retention policy of EVM logs and transaction traces
+		HistoryRetention HistoryRetentionConfig
+		// Freezer is a policy of moving the old events, blocks, txs and receipts into the freezer
+		Freezer FreezerConfig
 	}
 )
 
@@ -261,6 +273,15 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c BlockProcHooksConfig) isDisabled(name string) bool {
+	for _, d := range c.Disabled {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultStoreConfig for product.
 func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 	return StoreConfig{
@@ -277,6 +298,8 @@ func DefaultStoreConfig(scale cachescale.Func) StoreConfig {
 		EVM:                 evmstore.DefaultStoreConfig(scale),
 		MaxNonFlushedSize:   21*opt.MiB + scale.I(2*opt.MiB),
 		MaxNonFlushedPeriod: 30 * time.Minute,
+		HistoryRetention:    DefaultHistoryRetentionConfig(),
+		Freezer:             DefaultFreezerConfig(),
 	}
 }
 
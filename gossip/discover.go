@@ -17,27 +17,11 @@
 package gossip
 
 import (
-	"github.com/sesanetwork/go-sesa/common"
-	"github.com/sesanetwork/go-sesa/core/forkid"
 	"github.com/sesanetwork/go-sesa/p2p/enode"
-	"github.com/sesanetwork/go-sesa/rlp"
 
 	"github.com/sesanetwork/go-sesa/evmcore"
 )
 
-// enrEntry is the ENR entry which advertises `eth` protocol on the discovery.
-type enrEntry struct {
-	ForkID forkid.ID // Fork identifier per EIP-2124
-
-	// Ignore additional fields (for forward compatibility).
-	Rest []rlp.RawValue `rlp:"tail"`
-}
-
-// ENRKey implements enr.Entry.
-func (e enrEntry) ENRKey() string {
-	return "sesa"
-}
-
 // StartENRUpdater starts the `sesa` ENR updater loop, which listens for chain
 // head events and updates the requested node record whenever a fork is passed.
 func StartENRUpdater(svc *Service, ln *enode.LocalNode) {
@@ -49,7 +33,7 @@ func StartENRUpdater(svc *Service, ln *enode.LocalNode) {
 		for {
 			select {
 			case <-newHead:
-				ln.Set(currentENREntry(svc))
+				ln.Set(svc.currentEnr())
 			case <-sub.Err():
 				// Would be nice to sync with Stop, but there is no
 				// good way to do that.
@@ -58,11 +42,3 @@ func StartENRUpdater(svc *Service, ln *enode.LocalNode) {
 		}
 	}()
 }
-
-// currentENREntry constructs an `eth` ENR entry based on the current state of the chain.
-func currentENREntry(svc *Service) *enrEntry {
-	genesisHash := *svc.store.GetGenesisID()
-	return &enrEntry{
-		ForkID: forkid.NewID(svc.store.GetEvmChainConfig(), common.Hash(genesisHash), uint64(svc.store.GetLatestBlockIndex())),
-	}
-}
@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/core/types"
@@ -14,9 +15,10 @@ import (
 
 // dummyTxPool is a fake, helper transaction pool for testing purposes
 type dummyTxPool struct {
-	txFeed notify.Feed
-	pool   []*types.Transaction        // Collection of all transactions
-	added  chan<- []*types.Transaction // Notification channel for new transactions
+	txFeed  notify.Feed
+	pool    []*types.Transaction        // Collection of all transactions
+	private map[common.Hash]bool        // Hashes of the private transactions
+	added   chan<- []*types.Transaction // Notification channel for new transactions
 
 	signer types.Signer
 
@@ -44,6 +46,22 @@ func (p *dummyTxPool) AddLocal(tx *types.Transaction) error {
 	return p.AddLocals([]*types.Transaction{tx})[0]
 }
 
+func (p *dummyTxPool) AddPrivate(tx *types.Transaction) error {
+	p.lock.Lock()
+	if p.private == nil {
+		p.private = make(map[common.Hash]bool)
+	}
+	p.private[tx.Hash()] = true
+	p.lock.Unlock()
+	return p.AddLocal(tx)
+}
+
+func (p *dummyTxPool) IsPrivate(txid common.Hash) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.private[txid]
+}
+
 func (p *dummyTxPool) Nonce(addr common.Address) uint64 {
 	return 0
 }
@@ -101,6 +119,32 @@ func (p *dummyTxPool) Get(txid common.Hash) *types.Transaction {
 	return nil
 }
 
+func (p *dummyTxPool) TxArrival(txid common.Hash) time.Time {
+	return time.Time{}
+}
+
+func (p *dummyTxPool) RemoveTx(txid common.Hash) bool {
+	if !p.Has(txid) {
+		return false
+	}
+	p.Delete(txid)
+	return true
+}
+
+func (p *dummyTxPool) RemoveSender(addr common.Address) int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	notErased := make([]*types.Transaction, 0, len(p.pool))
+	for _, tx := range p.pool {
+		if from, _ := types.Sender(p.signer, tx); from != addr {
+			notErased = append(notErased, tx)
+		}
+	}
+	removed := len(p.pool) - len(notErased)
+	p.pool = notErased
+	return removed
+}
+
 func (p *dummyTxPool) Has(txid common.Hash) bool {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
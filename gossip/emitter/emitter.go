@@ -22,6 +22,7 @@ import (
 	"github.com/sesanetwork/go-sesa/tracing"
 	"github.com/sesanetwork/go-sesa/utils/errlock"
 	"github.com/sesanetwork/go-sesa/utils/rate"
+	"github.com/sesanetwork/go-sesa/valkeystore"
 )
 
 const (
@@ -269,8 +270,10 @@ func (em *Emitter) EmitEvent() (*native.EventPayload, error) {
 	// metrics
 	if tracing.Enabled() {
 		for _, t := range e.Txs() {
-			span := tracing.CheckTx(t.Hash(), "Emitter.EmitEvent()")
-			defer span.Finish()
+			span := tracing.CheckTx(t.Hash(), "Emitter.EmitEvent()",
+				tracing.EventKey.String(e.ID().String()),
+				tracing.EpochKey.Int64(int64(e.Epoch())))
+			defer span.End()
 		}
 	}
 
@@ -421,7 +424,7 @@ func (em *Emitter) createEvent(sortedTxs *types.TransactionsByPriceAndNonce) (*n
 	mutEvent.SetPayloadHash(native.CalcPayloadHash(mutEvent))
 
 	// sign
-	bSig, err := em.world.Signer.Sign(em.config.Validator.PubKey, mutEvent.HashToSign().Bytes())
+	bSig, err := em.sign(mutEvent)
 	if err != nil {
 		em.Periodic.Error(time.Second, "Failed to sign event", "err", err)
 		return nil, err
@@ -449,6 +452,11 @@ func (em *Emitter) idle() bool {
 	return em.originatedTxs.Empty()
 }
 
+// ValidatorID returns the ID of the validator the emitter is configured for, or 0.
+func (em *Emitter) ValidatorID() idx.ValidatorID {
+	return em.config.Validator.ID
+}
+
 func (em *Emitter) isValidator() bool {
 	return em.config.Validator.ID != 0 && em.validators.Exists(em.config.Validator.ID)
 }
@@ -464,3 +472,17 @@ func (em *Emitter) nameEventForDebug(e *native.EventPayload) {
 		strings.ToLower(string(name)),
 		e.Seq()))
 }
+
+// sign signs the event, passing its position if the signer provides the double-sign protection
+func (em *Emitter) sign(e *native.MutableEventPayload) ([]byte, error) {
+	digest := e.HashToSign().Bytes()
+	if signer, ok := em.world.Signer.(valkeystore.EventSignerI); ok {
+		pos := valkeystore.EventPosition{
+			Epoch:   uint32(e.Epoch()),
+			Seq:     uint32(e.Seq()),
+			Lamport: uint32(e.Lamport()),
+		}
+		return signer.SignEvent(em.config.Validator.PubKey, pos, digest)
+	}
+	return em.world.Signer.Sign(em.config.Validator.PubKey, digest)
+}
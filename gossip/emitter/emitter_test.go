@@ -125,6 +125,9 @@ func TestEmitter(t *testing.T) {
 			Return(map[common.Address]types.Transactions{
 				common.Address{}: {tx1, tx2},
 			}, nil).AnyTimes()
+		txPool.EXPECT().IsPrivate(gomock.Any()).
+			Return(false).
+			AnyTimes()
 
 		external.EXPECT().IsBusy().
 			Return(false).
@@ -454,6 +454,20 @@ func (mr *MockTxPoolMockRecorder) Has(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Has", reflect.TypeOf((*MockTxPool)(nil).Has), arg0)
 }
 
+// IsPrivate mocks base method.
+func (m *MockTxPool) IsPrivate(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPrivate", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPrivate indicates an expected call of IsPrivate.
+func (mr *MockTxPoolMockRecorder) IsPrivate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrivate", reflect.TypeOf((*MockTxPool)(nil).IsPrivate), arg0)
+}
+
 // Pending mocks base method.
 func (m *MockTxPool) Pending(arg0 bool) (map[common.Address]types.Transactions, error) {
 	m.ctrl.T.Helper()
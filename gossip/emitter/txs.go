@@ -155,8 +155,9 @@ func (em *Emitter) addTxs(e *native.MutableEventPayload, sorted *types.Transacti
 			sorted.Pop()
 			continue
 		}
-		// my turn, i.e. try to not include the same tx simultaneously by different validators
-		if !em.isMyTxTurn(tx.Hash(), sender, tx.Nonce(), time.Now(), em.validators, e.Creator(), em.epoch) {
+		// my turn, i.e. try to not include the same tx simultaneously by different validators.
+		// Private txs aren't known to other validators, so it's always my turn
+		if !em.world.TxPool.IsPrivate(tx.Hash()) && !em.isMyTxTurn(tx.Hash(), sender, tx.Nonce(), time.Now(), em.validators, e.Creator(), em.epoch) {
 			sorted.Pop()
 			continue
 		}
@@ -81,6 +81,9 @@ type TxPool interface {
 	// Has returns an indicator whether txpool has a transaction cached with the
 	// given hash.
 	Has(hash common.Hash) bool
+	// IsPrivate returns an indicator whether the transaction is known only to
+	// the local node, so it isn't originated by other validators.
+	IsPrivate(hash common.Hash) bool
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
 	Pending(enforceTips bool) (map[common.Address]types.Transactions, error)
@@ -1,14 +1,17 @@
 package gossip
 
 import (
+	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/core/forkid"
 	"github.com/sesanetwork/go-sesa/rlp"
 )
 
-// Enr is ENR entry which advertises eth protocol
+// Enr is ENR entry which advertises sesa protocol
 // on the discovery network.
 type Enr struct {
 	ForkID forkid.ID
+	// NetworkID is zero in the entries of the older nodes.
+	NetworkID uint64 `rlp:"optional"`
 	// Ignore additional fields (for forward compatibility).
 	Rest []rlp.RawValue `rlp:"tail"`
 }
@@ -18,6 +21,11 @@ func (e Enr) ENRKey() string {
 	return "sesa"
 }
 
+// currentEnr constructs the ENR entry based on the current state of the chain.
 func (s *Service) currentEnr() *Enr {
-	return &Enr{}
+	genesisHash := *s.store.GetGenesisID()
+	return &Enr{
+		ForkID:    forkid.NewID(s.store.GetEvmChainConfig(), common.Hash(genesisHash), uint64(s.store.GetLatestBlockIndex())),
+		NetworkID: s.store.GetRules().NetworkID,
+	}
 }
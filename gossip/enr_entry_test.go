@@ -0,0 +1,37 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/core/forkid"
+	"github.com/sesanetwork/go-sesa/rlp"
+)
+
+func TestEnrCompatibility(t *testing.T) {
+	require := require.New(t)
+
+	// the entry of an older node has no network ID
+	type oldEnr struct {
+		ForkID forkid.ID
+		Rest   []rlp.RawValue `rlp:"tail"`
+	}
+	forkID := forkid.ID{Hash: [4]byte{1, 2, 3, 4}, Next: 5}
+
+	b, err := rlp.EncodeToBytes(&oldEnr{ForkID: forkID})
+	require.NoError(err)
+	var e Enr
+	require.NoError(rlp.DecodeBytes(b, &e))
+	require.Equal(forkID, e.ForkID)
+	require.Zero(e.NetworkID)
+
+	b, err = rlp.EncodeToBytes(&Enr{ForkID: forkID, NetworkID: 4439})
+	require.NoError(err)
+	var old oldEnr
+	require.NoError(rlp.DecodeBytes(b, &old))
+	require.Equal(forkID, old.ForkID)
+	require.Len(old.Rest, 1)
+	require.NoError(rlp.DecodeBytes(b, &e))
+	require.Equal(uint64(4439), e.NetworkID)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/sesanetwork/go-sesa/evmcore"
 	"github.com/sesanetwork/go-sesa/evmcore/txtracer"
 	"github.com/sesanetwork/go-sesa/gossip/evmstore"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
 	"github.com/sesanetwork/go-sesa/native"
 	"github.com/sesanetwork/go-sesa/native/iblockproc"
 	"github.com/sesanetwork/go-sesa/params"
@@ -357,10 +358,27 @@ func (b *EthAPIBackend) GetBlockContext(header *evmcore.EvmHeader) vm.BlockConte
 }
 
 func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
+	// NOTE: only sent txs tracing
+	tracing.StartTx(signedTx.Hash(), "EthAPIBackend.SendTx()")
 	err := b.svc.txpool.AddLocal(signedTx)
-	if err == nil {
-		// NOTE: only sent txs tracing, see TxPool.addTxs() for all
-		tracing.StartTx(signedTx.Hash(), "EthAPIBackend.SendTx()")
+	if err != nil {
+		tracing.FailTx(signedTx.Hash(), "TxPool.AddLocal()", err)
+	} else {
+		tracing.TxStage(signedTx.Hash(), "TxPool.AddLocal()")
+	}
+	return err
+}
+
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction) error {
+	if !b.svc.isValidatorNode() {
+		return errors.New("private transactions are accepted by validator nodes only")
+	}
+	tracing.StartTx(signedTx.Hash(), "EthAPIBackend.SendPrivateTx()")
+	err := b.svc.txpool.AddPrivate(signedTx)
+	if err != nil {
+		tracing.FailTx(signedTx.Hash(), "TxPool.AddPrivate()", err)
+	} else {
+		tracing.TxStage(signedTx.Hash(), "TxPool.AddPrivate()")
 	}
 	return err
 }
@@ -373,6 +391,22 @@ func (b *EthAPIBackend) SubscribeNewBlockNotify(ch chan<- evmcore.ChainHeadNotif
 	return b.svc.feed.SubscribeNewBlock(ch)
 }
 
+func (b *EthAPIBackend) SubscribeNewEventsNotify(ch chan<- *native.EventPayload) notify.Subscription {
+	return b.svc.feed.SubscribeNewEvent(ch)
+}
+
+func (b *EthAPIBackend) SubscribeNewEpochNotify(ch chan<- filters.EpochNotify) notify.Subscription {
+	return b.svc.feed.SubscribeNewEpochState(ch)
+}
+
+func (b *EthAPIBackend) SubscribeLlrBlockResultNotify(ch chan<- filters.LlrBlockResultNotify) notify.Subscription {
+	return b.svc.feed.SubscribeLlrBlockResult(ch)
+}
+
+func (b *EthAPIBackend) SubscribeLlrEpochResultNotify(ch chan<- filters.LlrEpochResultNotify) notify.Subscription {
+	return b.svc.feed.SubscribeLlrEpochResult(ch)
+}
+
 func (b *EthAPIBackend) SubscribeNewTxsNotify(ch chan<- evmcore.NewTxsNotify) notify.Subscription {
 	return b.svc.txpool.SubscribeNewTxsNotify(ch)
 }
@@ -389,7 +423,11 @@ func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	return txs, nil
 }
 
+// GetPoolTransaction returns the pooled transaction, private transactions aren't exposed.
 func (b *EthAPIBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	if b.svc.txpool.IsPrivate(hash) {
+		return nil
+	}
 	return b.svc.txpool.Get(hash)
 }
 
@@ -458,6 +496,18 @@ func (b *EthAPIBackend) TxPoolContentFrom(addr common.Address) (types.Transactio
 	return b.svc.txpool.ContentFrom(addr)
 }
 
+func (b *EthAPIBackend) TxPoolTxArrival(hash common.Hash) time.Time {
+	return b.svc.txpool.TxArrival(hash)
+}
+
+func (b *EthAPIBackend) TxPoolRemoveTx(hash common.Hash) bool {
+	return b.svc.txpool.RemoveTx(hash)
+}
+
+func (b *EthAPIBackend) TxPoolRemoveSender(addr common.Address) int {
+	return b.svc.txpool.RemoveSender(addr)
+}
+
 func (b *EthAPIBackend) SuggestGasTipCap(ctx context.Context, certainty uint64) *big.Int {
 	return b.svc.gpo.SuggestTip(certainty)
 }
@@ -498,6 +548,11 @@ func (b *EthAPIBackend) EvmLogIndex() topicsdb.Index {
 	return b.svc.store.evm.EvmLogs
 }
 
+// HistoryLowestBlock returns the lowest block which logs and tx traces are available for.
+func (b *EthAPIBackend) HistoryLowestBlock() idx.Block {
+	return b.svc.store.GetHistoryLowestBlock()
+}
+
 // CurrentEpoch returns current epoch number.
 func (b *EthAPIBackend) CurrentEpoch(ctx context.Context) idx.Epoch {
 	return b.svc.store.GetEpoch()
@@ -0,0 +1,345 @@
+package gossip
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/ethapi"
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/params"
+	"github.com/sesanetwork/go-sesa/rpc"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestEthAPI_GetBlockReceipts(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicTransactionPoolAPI(env.EthAPI, new(ethapi.AddrLocker))
+
+	rr, err := env.ApplyTxs(sameEpoch,
+		env.Transfer(1, 2, utils.Tosesa(1)),
+		env.Transfer(2, 3, utils.Tosesa(1)),
+		env.Transfer(3, 1, utils.Tosesa(1)),
+	)
+	require.NoError(err)
+	number := rr[0].BlockNumber
+	for _, r := range rr {
+		require.Equal(number, r.BlockNumber, "the txs are expected in the same block")
+	}
+
+	block, err := env.EthAPI.BlockByNumber(ctx, rpc.BlockNumber(number.Int64()))
+	require.NoError(err)
+	for _, sel := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number.Int64())),
+		rpc.BlockNumberOrHashWithHash(block.Hash, false),
+	} {
+		receipts, err := api.GetBlockReceipts(ctx, sel)
+		require.NoError(err)
+		require.Len(receipts, len(block.Transactions))
+		// every receipt matches the single receipt of the tx
+		for i, tx := range block.Transactions {
+			require.Equal(tx.Hash(), receipts[i]["transactionHash"])
+			require.Equal(hexutil.Uint64(i), receipts[i]["transactionIndex"])
+			expected, err := api.GetTransactionReceipt(ctx, tx.Hash())
+			require.NoError(err)
+			require.Equal(expected, receipts[i])
+		}
+	}
+
+	// unknown blocks
+	latest := env.store.GetLatestBlockIndex()
+	_, err = api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(latest+1)))
+	require.Error(err)
+	_, err = api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(rr[0].TxHash, false))
+	require.Error(err)
+}
+
+func TestEthAPI_PrivateTxsArentExposed(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicTransactionPoolAPI(env.EthAPI, new(ethapi.AddrLocker))
+
+	private := env.Transfer(1, 2, utils.Tosesa(1))
+	public := env.Transfer(2, 3, utils.Tosesa(1))
+	require.NoError(env.txpool.AddPrivate(private))
+	require.NoError(env.txpool.AddLocal(public))
+
+	tx, err := api.GetTransactionByHash(ctx, private.Hash())
+	require.NoError(err)
+	require.Nil(tx)
+	raw, err := api.GetRawTransactionByHash(ctx, private.Hash())
+	require.NoError(err)
+	require.Nil(raw)
+
+	tx, err = api.GetTransactionByHash(ctx, public.Hash())
+	require.NoError(err)
+	require.NotNil(tx)
+	require.Equal(public.Hash(), tx.Hash)
+}
+
+func simCode(hex string) *hexutil.Bytes {
+	code := hexutil.Bytes(common.FromHex(hex))
+	return &code
+}
+
+func simBalance(v *big.Int) **hexutil.Big {
+	balance := (*hexutil.Big)(v)
+	return &balance
+}
+
+func TestEthAPI_SimulateV1_StateOverrides(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicBlockChainAPI(env.EthAPI)
+
+	var (
+		from    = common.HexToAddress("0x1000")
+		returns = common.HexToAddress("0x2000")
+		loads   = common.HexToAddress("0x3000")
+	)
+	res, err := api.SimulateV1(ctx, ethapi.SimOpts{
+		BlockStateCalls: []ethapi.SimBlock{{
+			StateOverrides: &ethapi.StateOverride{
+				from: {Balance: simBalance(utils.Tosesa(10))},
+				// returns 42
+				returns: {Code: simCode("602a60005260206000f3")},
+				// returns the slot 0
+				loads: {
+					Code:      simCode("60005460005260206000f3"),
+					StateDiff: &map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(7))},
+				},
+			},
+			Calls: []ethapi.TransactionArgs{
+				{From: &from, To: &returns},
+				{From: &from, To: &loads},
+				{From: &from, To: &returns, Value: (*hexutil.Big)(utils.Tosesa(5))},
+			},
+		}},
+	}, nil)
+	require.NoError(err)
+	require.Len(res, 1)
+	require.Len(res[0].Calls, 3)
+	for _, call := range res[0].Calls {
+		require.Nil(call.Error)
+		require.Equal(hexutil.Uint64(types.ReceiptStatusSuccessful), call.Status)
+	}
+	require.Equal(hexutil.Bytes(common.BigToHash(big.NewInt(42)).Bytes()), res[0].Calls[0].ReturnValue)
+	require.Equal(hexutil.Bytes(common.BigToHash(big.NewInt(7)).Bytes()), res[0].Calls[1].ReturnValue)
+
+	// the overrides aren't persisted
+	statedb, _, err := env.EthAPI.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(err)
+	require.Zero(statedb.GetBalance(from).Sign())
+	require.Empty(statedb.GetCode(returns))
+}
+
+func TestEthAPI_SimulateV1_Blocks(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicBlockChainAPI(env.EthAPI)
+	_, err := env.ApplyTxs(sameEpoch, env.Transfer(1, 2, utils.Tosesa(1)))
+	require.NoError(err)
+	base, err := env.EthAPI.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	require.NoError(err)
+
+	var (
+		from     = common.HexToAddress("0x1000")
+		to       = common.HexToAddress("0x2000")
+		balances = common.HexToAddress("0x3000")
+		hashes   = common.HexToAddress("0x4000")
+		logs     = common.HexToAddress("0x5000")
+	)
+	res, err := api.SimulateV1(ctx, ethapi.SimOpts{
+		BlockStateCalls: []ethapi.SimBlock{
+			{
+				StateOverrides: &ethapi.StateOverride{
+					from: {Balance: simBalance(utils.Tosesa(10))},
+					// returns the balance of the 0x2000 address
+					balances: {Code: simCode("73" + to.Hex()[2:] + "3160005260206000f3")},
+					// returns the hash of the previous block
+					hashes: {Code: simCode("436001900340" + "60005260206000f3")},
+					// emits an empty log
+					logs: {Code: simCode("60006000a000")},
+				},
+				Calls: []ethapi.TransactionArgs{
+					{From: &from, To: &to, Value: (*hexutil.Big)(utils.Tosesa(3))},
+				},
+			},
+			{
+				Calls: []ethapi.TransactionArgs{
+					{From: &from, To: &balances},
+					{From: &from, To: &hashes},
+				},
+			},
+			{
+				BlockOverrides: &ethapi.BlockOverrides{
+					Number: (*hexutil.Big)(new(big.Int).Add(base.Number, big.NewInt(10))),
+				},
+				// identical calls with an explicit nonce have distinct logs
+				Calls: []ethapi.TransactionArgs{
+					{From: &from, To: &logs, Nonce: new(hexutil.Uint64)},
+					{From: &from, To: &logs, Nonce: new(hexutil.Uint64)},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(err)
+	require.Len(res, 3)
+
+	// the blocks are chained
+	require.Equal(base.Hash, res[0].ParentHash)
+	for i, block := range res {
+		if i > 0 {
+			require.Equal(res[i-1].Hash, block.ParentHash)
+			require.Greater(block.Timestamp, res[i-1].Timestamp)
+		}
+		for _, call := range block.Calls {
+			require.Nil(call.Error)
+		}
+	}
+	require.Equal(hexutil.Uint64(base.Number.Uint64()+1), res[0].Number)
+	require.Equal(hexutil.Uint64(base.Number.Uint64()+2), res[1].Number)
+	require.Equal(hexutil.Uint64(base.Number.Uint64()+10), res[2].Number)
+
+	// the state changes of the previous blocks are visible
+	require.Equal(hexutil.Bytes(common.BigToHash(utils.Tosesa(3)).Bytes()), res[1].Calls[0].ReturnValue)
+	require.Equal(hexutil.Bytes(res[0].Hash.Bytes()), res[1].Calls[1].ReturnValue)
+
+	// every call has its own log
+	require.Len(res[2].Calls[0].Logs, 1)
+	require.Len(res[2].Calls[1].Logs, 1)
+	require.NotEqual(res[2].Calls[0].Logs[0].TxHash, res[2].Calls[1].Logs[0].TxHash)
+	require.Equal(uint(0), res[2].Calls[0].Logs[0].Index)
+	require.Equal(uint(1), res[2].Calls[1].Logs[0].Index)
+	require.Equal(uint64(res[2].Number), res[2].Calls[1].Logs[0].BlockNumber)
+}
+
+func TestEthAPI_SimulateV1_Validation(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicBlockChainAPI(env.EthAPI)
+	base, err := env.EthAPI.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	require.NoError(err)
+
+	from := env.Address(1)
+	to := env.Address(2)
+	gas := hexutil.Uint64(params.TxGas)
+	feeCap := (*hexutil.Big)(new(big.Int).Mul(base.BaseFee, big.NewInt(2)))
+	wrongNonce := hexutil.Uint64(100)
+	simulate := func(validation bool, args ethapi.TransactionArgs) error {
+		_, err := api.SimulateV1(ctx, ethapi.SimOpts{
+			BlockStateCalls: []ethapi.SimBlock{{Calls: []ethapi.TransactionArgs{args}}},
+			Validation:      validation,
+		}, nil)
+		return err
+	}
+
+	valid := ethapi.TransactionArgs{From: &from, To: &to, Gas: &gas, MaxFeePerGas: feeCap}
+	require.NoError(simulate(true, valid))
+
+	// wrong nonce
+	args := valid
+	args.Nonce = &wrongNonce
+	require.Error(simulate(true, args))
+	require.NoError(simulate(false, args))
+
+	// fee cap below the base fee
+	args = valid
+	args.MaxFeePerGas = nil
+	require.Error(simulate(true, args))
+	require.NoError(simulate(false, args))
+
+	// insufficient balance for the gas
+	args = valid
+	args.MaxFeePerGas = (*hexutil.Big)(new(big.Int).Mul(utils.Tosesa(1000000000), big.NewInt(1000000000)))
+	require.Error(simulate(true, args))
+}
+
+func TestEthAPI_SimulateV1_Errors(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	api := ethapi.NewPublicBlockChainAPI(env.EthAPI)
+	base, err := env.EthAPI.BlockByNumber(ctx, rpc.LatestBlockNumber)
+	require.NoError(err)
+
+	var (
+		from    = common.HexToAddress("0x1000")
+		reverts = common.HexToAddress("0x2000")
+		invalid = common.HexToAddress("0x3000")
+	)
+	overrides := &ethapi.StateOverride{
+		// reverts with 42
+		reverts: {Code: simCode("602a60005260206000fd")},
+		invalid: {Code: simCode("fe")},
+	}
+	res, err := api.SimulateV1(ctx, ethapi.SimOpts{
+		BlockStateCalls: []ethapi.SimBlock{{
+			StateOverrides: overrides,
+			Calls: []ethapi.TransactionArgs{
+				{From: &from, To: &reverts},
+				{From: &from, To: &invalid},
+			},
+		}},
+	}, nil)
+	require.NoError(err)
+	calls := res[0].Calls
+	revertData := common.BigToHash(big.NewInt(42)).Bytes()
+	require.Equal(hexutil.Uint64(types.ReceiptStatusFailed), calls[0].Status)
+	require.Equal(hexutil.Bytes(revertData), calls[0].ReturnValue)
+	require.NotNil(calls[0].Error)
+	require.Equal(3, calls[0].Error.Code)
+	require.Equal(hexutil.Encode(revertData), calls[0].Error.Data)
+	require.Equal(hexutil.Uint64(types.ReceiptStatusFailed), calls[1].Status)
+	require.NotNil(calls[1].Error)
+	require.Equal(-32015, calls[1].Error.Code)
+
+	// invalid requests are reported with the error codes
+	errorCode := func(err error) int {
+		require.Error(err)
+		var rpcErr rpc.Error
+		require.ErrorAs(err, &rpcErr)
+		return rpcErr.ErrorCode()
+	}
+	_, err = api.SimulateV1(ctx, ethapi.SimOpts{}, nil)
+	require.Equal(-38020, errorCode(err))
+	_, err = api.SimulateV1(ctx, ethapi.SimOpts{BlockStateCalls: make([]ethapi.SimBlock, 257)}, nil)
+	require.Equal(-38020, errorCode(err))
+	_, err = api.SimulateV1(ctx, ethapi.SimOpts{BlockStateCalls: []ethapi.SimBlock{{
+		BlockOverrides: &ethapi.BlockOverrides{Number: (*hexutil.Big)(base.Number)},
+	}}}, nil)
+	require.Equal(-38020, errorCode(err))
+	_, err = api.SimulateV1(ctx, ethapi.SimOpts{BlockStateCalls: []ethapi.SimBlock{{
+		BlockOverrides: &ethapi.BlockOverrides{Time: (*hexutil.Uint64)(new(uint64))},
+	}}}, nil)
+	require.Equal(-38020, errorCode(err))
+}
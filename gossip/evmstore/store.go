@@ -19,6 +19,7 @@ import (
 	"github.com/sesanetwork/go-sesa/ethdb"
 	"github.com/sesanetwork/go-sesa/trie"
 
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
 	"github.com/sesanetwork/go-sesa/logger"
 	"github.com/sesanetwork/go-sesa/native/iblockproc"
 	"github.com/sesanetwork/go-sesa/topicsdb"
@@ -53,6 +54,9 @@ type Store struct {
 
 	rlp rlpstore.Helper
 
+	// freezer keeps the txs and receipts of the old blocks
+	freezer *freezer.Freezer
+
 	triegc *prque.Prque // Priority queue mapping block numbers to tries to gc
 
 	logger.Instance
@@ -0,0 +1,62 @@
+package evmstore
+
+import (
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/rlp"
+
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
+)
+
+// SetFreezer sets the freezer of the old blocks txs and receipts, which are looked up
+// in the freezer if they are missing in the DB.
+func (s *Store) SetFreezer(f *freezer.Freezer) {
+	s.freezer = f
+}
+
+// GetFrozenBlockTxs returns the non-event transactions of the frozen block, ok is false if the block isn't frozen.
+func (s *Store) GetFrozenBlockTxs(n idx.Block) (txs types.Transactions, ok bool) {
+	if s.freezer == nil || !s.freezer.HasBlock(uint64(n)) {
+		return nil, false
+	}
+	buf, err := s.freezer.BlockTxs(uint64(n))
+	if err != nil {
+		s.Log.Crit("Failed to read freezer", "err", err)
+	}
+	if buf == nil {
+		return nil, true
+	}
+	if err := rlp.DecodeBytes(buf, &txs); err != nil {
+		s.Log.Crit("Failed to decode rlp", "err", err, "size", len(buf))
+	}
+	return txs, true
+}
+
+func (s *Store) getFrozenTx(txid common.Hash) *types.Transaction {
+	if s.freezer == nil {
+		return nil
+	}
+	position := s.GetTxPosition(txid)
+	if position == nil || !position.Event.IsZero() {
+		return nil
+	}
+	txs, _ := s.GetFrozenBlockTxs(position.Block)
+	for _, tx := range txs {
+		if tx.Hash() == txid {
+			return tx
+		}
+	}
+	return nil
+}
+
+func (s *Store) getFrozenReceiptsRLP(n idx.Block) rlp.RawValue {
+	if s.freezer == nil {
+		return nil
+	}
+	buf, err := s.freezer.BlockReceipts(uint64(n))
+	if err != nil {
+		s.Log.Crit("Failed to read freezer", "err", err)
+	}
+	return buf
+}
@@ -15,9 +15,19 @@ func (s *Store) SetTx(txid common.Hash, tx *types.Transaction) {
 	s.rlp.Set(s.table.Txs, txid.Bytes(), tx)
 }
 
+// DelTx deletes non-event transaction.
+func (s *Store) DelTx(txid common.Hash) {
+	if err := s.table.Txs.Delete(txid.Bytes()); err != nil {
+		s.Log.Crit("Failed to delete key", "err", err)
+	}
+}
+
 // GetTx returns stored non-event transaction.
 func (s *Store) GetTx(txid common.Hash) *types.Transaction {
 	tx, _ := s.rlp.Get(s.table.Txs, txid.Bytes(), &types.Transaction{}).(*types.Transaction)
+	if tx == nil {
+		tx = s.getFrozenTx(txid)
+	}
 
 	return tx
 }
@@ -28,21 +38,25 @@ func (s *Store) GetBlockTxs(n idx.Block, block native.Block, getEventPayload fun
 	}
 
 	transactions := make(types.Transactions, 0, len(block.Txs)+len(block.InternalTxs)+len(block.Events)*10)
-	for _, txid := range block.InternalTxs {
-		tx := s.GetTx(txid)
-		if tx == nil {
-			log.Crit("Internal tx not found", "tx", txid.String())
-			continue
+	if frozen, ok := s.GetFrozenBlockTxs(n); ok {
+		transactions = append(transactions, frozen...)
+	} else {
+		for _, txid := range block.InternalTxs {
+			tx := s.GetTx(txid)
+			if tx == nil {
+				log.Crit("Internal tx not found", "tx", txid.String())
+				continue
+			}
+			transactions = append(transactions, tx)
 		}
-		transactions = append(transactions, tx)
-	}
-	for _, txid := range block.Txs {
-		tx := s.GetTx(txid)
-		if tx == nil {
-			log.Crit("Tx not found", "tx", txid.String())
-			continue
+		for _, txid := range block.Txs {
+			tx := s.GetTx(txid)
+			if tx == nil {
+				log.Crit("Tx not found", "tx", txid.String())
+				continue
+			}
+			transactions = append(transactions, tx)
 		}
-		transactions = append(transactions, tx)
 	}
 	for _, id := range block.Events {
 		e := getEventPayload(id)
@@ -0,0 +1,65 @@
+package gossip
+
+import (
+	"fmt"
+	"sync"
+
+	notify "github.com/sesanetwork/go-sesa/event"
+	"github.com/sesanetwork/go-sesa/log"
+)
+
+// feedNotifierQueueSize is a max number of the notifications pending to be sent to the subscribers
+const feedNotifierQueueSize = 1024
+
+type feedNotification struct {
+	feed  *notify.Feed
+	value interface{}
+}
+
+// FeedNotifier sends the notifications to the feed subscribers from its own goroutine,
+// so slow subscribers don't stall the events processing under the engine lock
+type FeedNotifier struct {
+	queue chan feedNotification
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func newFeedNotifier() *FeedNotifier {
+	return &FeedNotifier{
+		queue: make(chan feedNotification, feedNotifierQueueSize),
+		quit:  make(chan struct{}),
+	}
+}
+
+// Send queues the notification without blocking, the notification is dropped
+// if the subscribers are too slow to receive the queued ones.
+func (n *FeedNotifier) Send(feed *notify.Feed, value interface{}) {
+	select {
+	case n.queue <- feedNotification{feed, value}:
+	default:
+		log.Warn("Feed notification is dropped, subscribers are too slow", "type", fmt.Sprintf("%T", value))
+	}
+}
+
+func (n *FeedNotifier) loop() {
+	defer n.wg.Done()
+	for {
+		select {
+		case v := <-n.queue:
+			v.feed.Send(v.value)
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+func (n *FeedNotifier) Start() {
+	n.wg.Add(1)
+	go n.loop()
+}
+
+func (n *FeedNotifier) Stop() {
+	close(n.quit)
+	n.wg.Wait()
+}
@@ -0,0 +1,55 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-sesa/rlp"
+
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestService_NewEventsNotify(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+
+	events := make(chan *native.EventPayload, feedNotifierQueueSize)
+	sub := env.EthAPI.SubscribeNewEventsNotify(events)
+	defer sub.Unsubscribe()
+	// the subscriber which never reads doesn't stall the events processing
+	stuck := make(chan *native.EventPayload)
+	stuckSub := env.EthAPI.SubscribeNewEventsNotify(stuck)
+
+	for i := 0; i < 3; i++ {
+		_, err := env.ApplyTxs(sameEpoch, env.Transfer(1, 2, utils.Tosesa(1)))
+		require.NoError(err)
+	}
+	stuckSub.Unsubscribe()
+
+	// every connected event is notified once the subscribers catch up
+	connected := make(map[hash.Event]bool)
+	env.store.ForEachEventRLP(nil, func(id hash.Event, _ rlp.RawValue) bool {
+		connected[id] = true
+		return true
+	})
+	require.NotEmpty(connected)
+	received := make(map[hash.Event]bool)
+	timeout := time.After(5 * time.Second)
+	for len(received) < len(connected) {
+		select {
+		case e := <-events:
+			require.True(connected[e.ID()], e.ID().String())
+			received[e.ID()] = true
+		case <-timeout:
+			t.Fatalf("%d of %d events are notified", len(received), len(connected))
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	notify "github.com/sesanetwork/go-sesa/event"
+	"github.com/sesanetwork/go-sesa/rpc"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+)
+
+type (
+	// EpochNotify is sent when an epoch is sealed, the states are of the new epoch start.
+	EpochNotify struct {
+		BlockState *iblockproc.BlockState
+		EpochState *iblockproc.EpochState
+	}
+
+	// LlrBlockResultNotify is sent when the LLR block votes decide a block record hash.
+	LlrBlockResultNotify struct {
+		Block idx.Block
+		Hash  hash.Hash
+	}
+
+	// LlrEpochResultNotify is sent when the LLR epoch votes decide an epoch record hash.
+	LlrEpochResultNotify struct {
+		Epoch idx.Epoch
+		Hash  hash.Hash
+	}
+)
+
+// DAGBackend provides the DAG, epochs and LLR votes notifications.
+type DAGBackend interface {
+	SubscribeNewEventsNotify(ch chan<- *native.EventPayload) notify.Subscription
+	SubscribeNewEpochNotify(ch chan<- EpochNotify) notify.Subscription
+	SubscribeLlrBlockResultNotify(ch chan<- LlrBlockResultNotify) notify.Subscription
+	SubscribeLlrEpochResultNotify(ch chan<- LlrEpochResultNotify) notify.Subscription
+}
+
+// PublicDAGFilterAPI offers the subscriptions to the DAG events, sealed epochs and LLR votes results.
+type PublicDAGFilterAPI struct {
+	backend DAGBackend
+}
+
+// NewPublicDAGFilterAPI returns a new PublicDAGFilterAPI instance.
+func NewPublicDAGFilterAPI(backend DAGBackend) *PublicDAGFilterAPI {
+	return &PublicDAGFilterAPI{backend}
+}
+
+// NewEvents sends a notification each time a new event is connected to the DAG.
+func (api *PublicDAGFilterAPI) NewEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	events := make(chan *native.EventPayload, 128)
+	eventsSub := api.backend.SubscribeNewEventsNotify(events)
+
+	go func() {
+		defer eventsSub.Unsubscribe()
+		for {
+			select {
+			case e := <-events:
+				_ = notifier.Notify(rpcSub.ID, native.RPCMarshalEvent(e))
+			case <-eventsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// NewEpoch sends a notification each time an epoch is sealed, with the new epoch summary and validators.
+func (api *PublicDAGFilterAPI) NewEpoch(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	epochs := make(chan EpochNotify, 4)
+	epochsSub := api.backend.SubscribeNewEpochNotify(epochs)
+
+	go func() {
+		defer epochsSub.Unsubscribe()
+		for {
+			select {
+			case n := <-epochs:
+				_ = notifier.Notify(rpcSub.ID, RPCMarshalEpoch(n))
+			case <-epochsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// LlrBlockResult sends a notification each time a block record hash is decided by the LLR votes.
+func (api *PublicDAGFilterAPI) LlrBlockResult(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	results := make(chan LlrBlockResultNotify, 128)
+	resultsSub := api.backend.SubscribeLlrBlockResultNotify(results)
+
+	go func() {
+		defer resultsSub.Unsubscribe()
+		for {
+			select {
+			case n := <-results:
+				_ = notifier.Notify(rpcSub.ID, map[string]interface{}{
+					"block": hexutil.Uint64(n.Block),
+					"hash":  hexutil.Bytes(n.Hash.Bytes()),
+				})
+			case <-resultsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// LlrEpochResult sends a notification each time an epoch record hash is decided by the LLR votes.
+func (api *PublicDAGFilterAPI) LlrEpochResult(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	results := make(chan LlrEpochResultNotify, 16)
+	resultsSub := api.backend.SubscribeLlrEpochResultNotify(results)
+
+	go func() {
+		defer resultsSub.Unsubscribe()
+		for {
+			select {
+			case n := <-results:
+				_ = notifier.Notify(rpcSub.ID, map[string]interface{}{
+					"epoch": hexutil.Uint64(n.Epoch),
+					"hash":  hexutil.Bytes(n.Hash.Bytes()),
+				})
+			case <-resultsSub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// RPCMarshalEpoch converts the sealed epoch notification to the RPC output.
+func RPCMarshalEpoch(n EpochNotify) map[string]interface{} {
+	es := n.EpochState
+	validators := make(map[hexutil.Uint64]interface{}, es.Validators.Len())
+	for _, vid := range es.Validators.IDs() {
+		profile := es.ValidatorProfiles[vid]
+		validators[hexutil.Uint64(vid)] = map[string]interface{}{
+			"weight": (*hexutil.Big)(profile.Weight),
+			"pubkey": profile.PubKey.String(),
+		}
+	}
+	return map[string]interface{}{
+		"epoch":          hexutil.Uint64(es.Epoch),
+		"sealedEpoch":    hexutil.Uint64(es.Epoch - 1),
+		"epochStart":     hexutil.Uint64(es.EpochStart),
+		"prevEpochStart": hexutil.Uint64(es.PrevEpochStart),
+		"lastBlock":      hexutil.Uint64(n.BlockState.LastBlock.Idx),
+		"stateRoot":      hexutil.Bytes(es.EpochStateRoot.Bytes()),
+		"validators":     validators,
+	}
+}
@@ -0,0 +1,145 @@
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/native/pos"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	notify "github.com/sesanetwork/go-sesa/event"
+	"github.com/sesanetwork/go-sesa/rpc"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/drivertype"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+)
+
+func TestRPCMarshalEpoch(t *testing.T) {
+	require := require.New(t)
+
+	pubkey := validatorpk.PubKey{
+		Type: validatorpk.Types.Secp256k1,
+		Raw:  []byte{1, 2, 3},
+	}
+	builder := pos.NewBuilder()
+	builder.Set(idx.ValidatorID(5), 10)
+	bs := iblockproc.BlockState{}
+	bs.LastBlock.Idx = 100
+	es := iblockproc.EpochState{
+		Epoch:      3,
+		EpochStart: 7,
+		Validators: builder.Build(),
+		ValidatorProfiles: iblockproc.ValidatorProfiles{
+			5: drivertype.Validator{Weight: big.NewInt(10), PubKey: pubkey},
+		},
+	}
+
+	res := RPCMarshalEpoch(EpochNotify{BlockState: &bs, EpochState: &es})
+	require.Equal(hexutil.Uint64(3), res["epoch"])
+	require.Equal(hexutil.Uint64(2), res["sealedEpoch"])
+	require.Equal(hexutil.Uint64(100), res["lastBlock"])
+	validators := res["validators"].(map[hexutil.Uint64]interface{})
+	require.Len(validators, 1)
+	require.Equal(pubkey.String(), validators[5].(map[string]interface{})["pubkey"])
+}
+
+type testDAGBackend struct {
+	events       notify.Feed
+	epochs       notify.Feed
+	blockResults notify.Feed
+	epochResults notify.Feed
+}
+
+func (b *testDAGBackend) SubscribeNewEventsNotify(ch chan<- *native.EventPayload) notify.Subscription {
+	return b.events.Subscribe(ch)
+}
+
+func (b *testDAGBackend) SubscribeNewEpochNotify(ch chan<- EpochNotify) notify.Subscription {
+	return b.epochs.Subscribe(ch)
+}
+
+func (b *testDAGBackend) SubscribeLlrBlockResultNotify(ch chan<- LlrBlockResultNotify) notify.Subscription {
+	return b.blockResults.Subscribe(ch)
+}
+
+func (b *testDAGBackend) SubscribeLlrEpochResultNotify(ch chan<- LlrEpochResultNotify) notify.Subscription {
+	return b.epochResults.Subscribe(ch)
+}
+
+func TestDAGFilterAPI_Subscriptions(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	backend := &testDAGBackend{}
+	server := rpc.NewServer()
+	defer server.Stop()
+	require.NoError(server.RegisterName("dag", NewPublicDAGFilterAPI(backend)))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	events := make(chan map[string]interface{})
+	eventsSub, err := client.Subscribe(ctx, "dag", events, "newEvents")
+	require.NoError(err)
+	defer eventsSub.Unsubscribe()
+	blockResults := make(chan map[string]interface{})
+	blockResultsSub, err := client.Subscribe(ctx, "dag", blockResults, "llrBlockResult")
+	require.NoError(err)
+	defer blockResultsSub.Unsubscribe()
+	epochResults := make(chan map[string]interface{})
+	epochResultsSub, err := client.Subscribe(ctx, "dag", epochResults, "llrEpochResult")
+	require.NoError(err)
+	defer epochResultsSub.Unsubscribe()
+
+	// the backend is subscribed once the subscription is created
+	me := &native.MutableEventPayload{}
+	me.SetEpoch(2)
+	me.SetSeq(3)
+	me.SetLamport(4)
+	me.SetCreator(5)
+	e := me.Build()
+	require.Equal(1, backend.events.Send(e))
+	select {
+	case res := <-events:
+		require.Equal(hexutil.Bytes(e.ID().Bytes()).String(), res["id"])
+		require.Equal("0x2", res["epoch"])
+		require.Equal("0x3", res["seq"])
+		require.Equal("0x5", res["creator"])
+	case err := <-eventsSub.Err():
+		require.NoError(err)
+	case <-ctx.Done():
+		t.Fatal("event notification timeout")
+	}
+
+	h := hash.Of([]byte("record"))
+	require.Equal(1, backend.blockResults.Send(LlrBlockResultNotify{Block: 10, Hash: h}))
+	select {
+	case res := <-blockResults:
+		require.Equal("0xa", res["block"])
+		require.Equal(hexutil.Bytes(h.Bytes()).String(), res["hash"])
+	case <-ctx.Done():
+		t.Fatal("LLR block result notification timeout")
+	}
+
+	require.Equal(1, backend.epochResults.Send(LlrEpochResultNotify{Epoch: 7, Hash: h}))
+	select {
+	case res := <-epochResults:
+		require.Equal("0x7", res["epoch"])
+		require.Equal(hexutil.Bytes(h.Bytes()).String(), res["hash"])
+	case <-ctx.Done():
+		t.Fatal("LLR epoch result notification timeout")
+	}
+
+	// the backend subscription is released along with the RPC subscription
+	eventsSub.Unsubscribe()
+	require.Eventually(func() bool {
+		return backend.events.Send(e) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
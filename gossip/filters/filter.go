@@ -50,6 +50,7 @@ type Backend interface {
 	SubscribeLogsNotify(ch chan<- []*types.Log) notify.Subscription
 
 	EvmLogIndex() topicsdb.Index
+	HistoryLowestBlock() idx.Block
 
 	CalcBlockExtApi() bool
 }
@@ -144,20 +145,14 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 
 // indexedLogs returns the logs matching the filter criteria based on topics index.
 func (f *Filter) indexedLogs(ctx context.Context, begin, end idx.Block) ([]*types.Log, error) {
+	if begin < f.backend.HistoryLowestBlock() {
+		return nil, evmcore.ErrPrunedHistory
+	}
 	if end-begin > f.config.IndexedLogsBlockRangeLimit {
 		return nil, fmt.Errorf("too wide blocks range, the limit is %d", f.config.IndexedLogsBlockRangeLimit)
 	}
 
-	addresses := make([]common.Hash, len(f.addresses))
-	for i, addr := range f.addresses {
-		addresses[i] = addr.Hash()
-	}
-
-	pattern := make([][]common.Hash, 1, len(f.topics)+1)
-	pattern[0] = addresses
-	pattern = append(pattern, f.topics...)
-
-	logs, err := f.backend.EvmLogIndex().FindInBlocks(ctx, begin, end, pattern)
+	logs, err := f.backend.EvmLogIndex().FindInBlocksByAddresses(ctx, begin, end, f.addresses, f.topics)
 	if err != nil {
 		return nil, err
 	}
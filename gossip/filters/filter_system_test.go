@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sesanetwork/go-vassalo/native/idx"
 	"github.com/sesanetwork/go-vassalo/sesadb/memorydb"
 	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/core/rawdb"
@@ -44,6 +45,7 @@ type testBackend struct {
 	blocksFeed *notify.Feed
 	txsFeed    *notify.Feed
 	logsFeed   *notify.Feed
+	lowest     idx.Block
 }
 
 func newTestBackend() *testBackend {
@@ -137,6 +139,10 @@ func (b *testBackend) EvmLogIndex() topicsdb.Index {
 	return b.logIndex
 }
 
+func (b *testBackend) HistoryLowestBlock() idx.Block {
+	return b.lowest
+}
+
 func (b *testBackend) MustPushLogs(recs ...*types.Log) {
 	err := b.logIndex.Push(recs...)
 	if err != nil {
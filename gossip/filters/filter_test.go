@@ -274,4 +274,18 @@ func TestFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 
+	backend.lowest = 900
+	filter = NewRangeFilter(backend, testConfig(), 1, 10, nil, [][]common.Hash{{hash1, hash2}})
+	_, err = filter.Logs(context.Background())
+	if err != evmcore.ErrPrunedHistory {
+		t.Error("expected pruned history error, got", err)
+	}
+	filter = NewRangeFilter(backend, testConfig(), 900, 999, []common.Address{addr}, [][]common.Hash{{hash3}})
+	logs, err = filter.Logs(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if len(logs) != 1 {
+		t.Error("expected 1 log, got", len(logs))
+	}
 }
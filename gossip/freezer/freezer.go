@@ -0,0 +1,309 @@
+package freezer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/tsdb/fileutil"
+
+	"github.com/sesanetwork/go-sesa/core/rawdb"
+	"github.com/sesanetwork/go-sesa/log"
+)
+
+var (
+	// ErrOutOrderInsertion is returned if the appended block or epoch doesn't follow the last frozen one.
+	ErrOutOrderInsertion = errors.New("the append operation is out-order")
+	// ErrUnsortedEvents is returned if the appended events aren't sorted by their keys.
+	ErrUnsortedEvents = errors.New("events aren't sorted by keys")
+)
+
+// Event is an event which is frozen by its key.
+type Event struct {
+	Key   []byte
+	Value []byte
+}
+
+// Freezer is an append-only flat files storage of the historical blocks and events,
+// which is built on the freezer tables of the ancient chain data:
+//
+//   - blocks, their non-event transactions and receipts are stored by block number;
+//   - events are stored sorted by key within each epoch, and are looked up by
+//     a binary search over the epoch range.
+//
+// Frozen data never changes, so it doesn't need the key-value DB compactions and caches.
+type Freezer struct {
+	blocks *sequence // block txs, block receipts and blocks
+	epochs *sequence // end of the epoch events range
+	events table     // key-prefixed events
+
+	instanceLock fileutil.Releaser // File-system lock to prevent double opens
+}
+
+const (
+	blockTxsIdx = iota
+	blockReceiptsIdx
+	blockIdx
+)
+
+// Open opens the freezer in the directory, repairing the tables after an unclean shutdown.
+func Open(dir string) (*Freezer, error) {
+	if info, err := os.Lstat(dir); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return nil, errors.New("symbolic link freezer directory is not supported")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	// Leveldb uses LOCK as the filelock filename. To prevent the
+	// name collision, we use FLOCK as the lock name.
+	lock, _, err := fileutil.Flock(filepath.Join(dir, "FLOCK"))
+	if err != nil {
+		return nil, err
+	}
+	f := &Freezer{
+		instanceLock: lock,
+	}
+	// the last table of a sequence is written last, so it defines the number of the frozen items
+	f.blocks, err = openSequence(dir, "blocks", []string{"txs", "receipts", "blocks"}, false)
+	if err != nil {
+		_ = lock.Release()
+		return nil, err
+	}
+	f.epochs, err = openSequence(dir, "epochs", []string{"epochs"}, true)
+	if err != nil {
+		_ = f.blocks.close()
+		_ = lock.Release()
+		return nil, err
+	}
+	f.events, err = rawdb.NewFreezerTable(dir, "events", false)
+	if err != nil {
+		_ = f.epochs.close()
+		_ = f.blocks.close()
+		_ = lock.Release()
+		return nil, err
+	}
+	if err := f.repair(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	log.Info("Opened freezer", "dir", dir)
+	return f, nil
+}
+
+// repair truncates the events which aren't indexed by the epochs.
+func (f *Freezer) repair() error {
+	end, err := f.eventsEnd()
+	if err != nil {
+		return err
+	}
+	if f.events.Items() < end {
+		return fmt.Errorf("freezer events are missing, have %d, expected %d", f.events.Items(), end)
+	}
+	return f.events.Truncate(end)
+}
+
+// Close closes all the freezer tables.
+func (f *Freezer) Close() error {
+	var errs []error
+	for _, err := range []error{f.blocks.close(), f.epochs.close(), f.events.Close(), f.instanceLock.Release()} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// Sync flushes all the freezer tables to disk.
+func (f *Freezer) Sync() error {
+	if err := f.blocks.sync(); err != nil {
+		return err
+	}
+	if err := f.events.Sync(); err != nil {
+		return err
+	}
+	return f.epochs.sync()
+}
+
+// Blocks returns the range [first, next) of the frozen blocks, ok is false if there are no frozen blocks.
+func (f *Freezer) Blocks() (first, next uint64, ok bool) {
+	return f.blocks.bounds()
+}
+
+// HasBlock returns true if the block is frozen.
+func (f *Freezer) HasBlock(n uint64) bool {
+	return f.blocks.has(n)
+}
+
+// AppendBlock freezes the RLP-encoded block, its non-event txs and receipts.
+// Empty data is read back as nil.
+func (f *Freezer) AppendBlock(n uint64, block, txs, receipts []byte) error {
+	blobs := make([][]byte, 3)
+	blobs[blockTxsIdx] = txs
+	blobs[blockReceiptsIdx] = receipts
+	blobs[blockIdx] = block
+	return f.blocks.append(n, blobs)
+}
+
+// Block returns the frozen RLP-encoded block, or nil if it isn't frozen.
+func (f *Freezer) Block(n uint64) ([]byte, error) {
+	return f.blocks.retrieve(n, blockIdx)
+}
+
+// BlockTxs returns the frozen RLP-encoded non-event txs of the block, or nil if the block isn't frozen.
+func (f *Freezer) BlockTxs(n uint64) ([]byte, error) {
+	return f.blocks.retrieve(n, blockTxsIdx)
+}
+
+// BlockReceipts returns the frozen RLP-encoded receipts of the block, or nil if the block isn't frozen.
+func (f *Freezer) BlockReceipts(n uint64) ([]byte, error) {
+	return f.blocks.retrieve(n, blockReceiptsIdx)
+}
+
+// Epochs returns the range [first, next) of the epochs which events are frozen, ok is false if there are no frozen epochs.
+func (f *Freezer) Epochs() (first, next uint64, ok bool) {
+	return f.epochs.bounds()
+}
+
+// HasEpoch returns true if the epoch events are frozen.
+func (f *Freezer) HasEpoch(epoch uint64) bool {
+	return f.epochs.has(epoch)
+}
+
+// AppendEpoch freezes the events of the epoch, which must be sorted by keys.
+func (f *Freezer) AppendEpoch(epoch uint64, events []Event) error {
+	if _, next, ok := f.epochs.bounds(); ok && epoch != next {
+		return ErrOutOrderInsertion
+	}
+	for i := 1; i < len(events); i++ {
+		if bytes.Compare(events[i-1].Key, events[i].Key) >= 0 {
+			return ErrUnsortedEvents
+		}
+	}
+	end := f.events.Items()
+	for _, e := range events {
+		if err := f.events.Append(end, encodeEvent(e)); err != nil {
+			return err
+		}
+		end++
+	}
+	return f.epochs.append(epoch, [][]byte{encodeUint64(end)})
+}
+
+// Event returns the frozen event value by the key, or nil if it isn't frozen.
+func (f *Freezer) Event(epoch uint64, key []byte) ([]byte, error) {
+	if !f.epochs.has(epoch) {
+		return nil, nil
+	}
+	start, end, err := f.epochRange(epoch)
+	if err != nil {
+		return nil, err
+	}
+	// binary search of the key within the epoch events
+	for start < end {
+		mid := start + (end-start)/2
+		blob, err := f.events.Retrieve(mid)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeEvent(blob)
+		if err != nil {
+			return nil, err
+		}
+		switch cmp := bytes.Compare(e.Key, key); {
+		case cmp == 0:
+			return e.Value, nil
+		case cmp < 0:
+			start = mid + 1
+		default:
+			end = mid
+		}
+	}
+	return nil, nil
+}
+
+// ForEachEpochEvent iterates over the frozen events of the epoch, ordered by keys.
+func (f *Freezer) ForEachEpochEvent(epoch uint64, fn func(e Event) bool) error {
+	if !f.epochs.has(epoch) {
+		return nil
+	}
+	start, end, err := f.epochRange(epoch)
+	if err != nil {
+		return err
+	}
+	for i := start; i < end; i++ {
+		blob, err := f.events.Retrieve(i)
+		if err != nil {
+			return err
+		}
+		e, err := decodeEvent(blob)
+		if err != nil {
+			return err
+		}
+		if !fn(e) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// epochRange returns the range [start, end) of the epoch items in the events table.
+func (f *Freezer) epochRange(epoch uint64) (start, end uint64, err error) {
+	end, err = f.epochEnd(epoch)
+	if err != nil {
+		return
+	}
+	if first, _, _ := f.epochs.bounds(); epoch != first {
+		start, err = f.epochEnd(epoch - 1)
+	}
+	return
+}
+
+func (f *Freezer) epochEnd(epoch uint64) (uint64, error) {
+	blob, err := f.epochs.retrieve(epoch, 0)
+	if err != nil {
+		return 0, err
+	}
+	if len(blob) != 8 {
+		return 0, fmt.Errorf("malformed freezer epoch %d", epoch)
+	}
+	return binary.BigEndian.Uint64(blob), nil
+}
+
+// eventsEnd returns the number of the events of the frozen epochs.
+func (f *Freezer) eventsEnd() (uint64, error) {
+	_, next, ok := f.epochs.bounds()
+	if !ok {
+		return 0, nil
+	}
+	return f.epochEnd(next - 1)
+}
+
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
+
+func encodeEvent(e Event) []byte {
+	b := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(e.Key)+len(e.Value))
+	b = b[:binary.PutUvarint(b, uint64(len(e.Key)))]
+	b = append(b, e.Key...)
+	return append(b, e.Value...)
+}
+
+func decodeEvent(b []byte) (Event, error) {
+	keySize, n := binary.Uvarint(b)
+	if n <= 0 || uint64(len(b)-n) < keySize {
+		return Event{}, errors.New("malformed freezer event")
+	}
+	return Event{
+		Key:   b[n : n+int(keySize)],
+		Value: b[n+int(keySize):],
+	}, nil
+}
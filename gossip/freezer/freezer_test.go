@@ -0,0 +1,165 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func eventKey(epoch, i uint32) []byte {
+	key := make([]byte, 32)
+	binary.BigEndian.PutUint32(key[0:4], epoch)
+	binary.BigEndian.PutUint32(key[4:8], i)
+	return key
+}
+
+func TestFreezer_Blocks(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	f, err := Open(dir)
+	require.NoError(err)
+	_, _, ok := f.Blocks()
+	require.False(ok)
+
+	for n := uint64(100); n < 200; n++ {
+		var receipts []byte
+		if n%2 == 0 {
+			receipts = []byte(fmt.Sprintf("receipts-%d", n))
+		}
+		require.NoError(f.AppendBlock(n, []byte(fmt.Sprintf("block-%d", n)), []byte(fmt.Sprintf("txs-%d", n)), receipts))
+	}
+	require.ErrorIs(f.AppendBlock(300, []byte("block"), nil, nil), ErrOutOrderInsertion)
+	require.NoError(f.Sync())
+
+	check := func(f *Freezer, next uint64) {
+		first, last, ok := f.Blocks()
+		require.True(ok)
+		require.Equal(uint64(100), first)
+		require.Equal(next, last)
+		require.False(f.HasBlock(99))
+		require.False(f.HasBlock(next))
+		for n := first; n < next; n++ {
+			require.True(f.HasBlock(n))
+			block, err := f.Block(n)
+			require.NoError(err)
+			require.Equal(fmt.Sprintf("block-%d", n), string(block))
+			txs, err := f.BlockTxs(n)
+			require.NoError(err)
+			require.Equal(fmt.Sprintf("txs-%d", n), string(txs))
+			receipts, err := f.BlockReceipts(n)
+			require.NoError(err)
+			if n%2 == 0 {
+				require.Equal(fmt.Sprintf("receipts-%d", n), string(receipts))
+			} else {
+				require.Nil(receipts)
+			}
+		}
+		block, err := f.Block(next)
+		require.NoError(err)
+		require.Nil(block)
+	}
+	check(f, 200)
+	require.NoError(f.Close())
+
+	// simulate an interrupted append, when not all the tables are written
+	f, err = Open(dir)
+	require.NoError(err)
+	require.NoError(f.blocks.tables[blockTxsIdx].Append(100, []byte("txs-200")))
+	require.NoError(f.blocks.tables[blockReceiptsIdx].Append(100, []byte("receipts-200")))
+	require.NoError(f.Close())
+
+	f, err = Open(dir)
+	require.NoError(err)
+	check(f, 200)
+	require.NoError(f.AppendBlock(200, []byte("block-200"), []byte("txs-200"), []byte("receipts-200")))
+	check(f, 201)
+	require.NoError(f.Close())
+}
+
+func TestFreezer_Events(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	f, err := Open(dir)
+	require.NoError(err)
+	_, _, ok := f.Epochs()
+	require.False(ok)
+
+	// epoch 3 has no events
+	eventsNum := map[uint32]uint32{1: 1, 2: 100, 3: 0, 4: 33}
+	for epoch := uint32(1); epoch <= 4; epoch++ {
+		events := make([]Event, 0, eventsNum[epoch])
+		for i := uint32(0); i < eventsNum[epoch]; i++ {
+			events = append(events, Event{
+				Key:   eventKey(epoch, i),
+				Value: []byte(fmt.Sprintf("event-%d-%d", epoch, i)),
+			})
+		}
+		require.NoError(f.AppendEpoch(uint64(epoch), events))
+	}
+	require.ErrorIs(f.AppendEpoch(6, nil), ErrOutOrderInsertion)
+	require.ErrorIs(f.AppendEpoch(5, []Event{{Key: eventKey(5, 1)}, {Key: eventKey(5, 0)}}), ErrUnsortedEvents)
+	require.NoError(f.Sync())
+
+	check := func(f *Freezer) {
+		first, next, ok := f.Epochs()
+		require.True(ok)
+		require.Equal(uint64(1), first)
+		require.Equal(uint64(5), next)
+		for epoch := uint32(1); epoch <= 4; epoch++ {
+			require.True(f.HasEpoch(uint64(epoch)))
+			for i := uint32(0); i < eventsNum[epoch]; i++ {
+				v, err := f.Event(uint64(epoch), eventKey(epoch, i))
+				require.NoError(err)
+				require.Equal(fmt.Sprintf("event-%d-%d", epoch, i), string(v))
+			}
+			v, err := f.Event(uint64(epoch), eventKey(epoch, eventsNum[epoch]))
+			require.NoError(err)
+			require.Nil(v)
+
+			var iterated uint32
+			require.NoError(f.ForEachEpochEvent(uint64(epoch), func(e Event) bool {
+				require.Equal(eventKey(epoch, iterated), e.Key)
+				iterated++
+				return true
+			}))
+			require.Equal(eventsNum[epoch], iterated)
+		}
+		v, err := f.Event(5, eventKey(5, 0))
+		require.NoError(err)
+		require.Nil(v)
+	}
+	check(f)
+
+	// simulate an interrupted epoch append, when only the events are written
+	require.NoError(f.events.Append(f.events.Items(), encodeEvent(Event{Key: eventKey(5, 0)})))
+	require.NoError(f.Close())
+
+	f, err = Open(dir)
+	require.NoError(err)
+	check(f)
+	require.Equal(uint64(1+100+33), f.events.Items())
+	require.NoError(f.Close())
+}
+
+func TestFreezer_Lock(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	f, err := Open(dir)
+	require.NoError(err)
+	_, err = Open(dir)
+	require.Error(err)
+	require.NoError(f.Close())
+
+	// symbolic links aren't supported
+	link := filepath.Join(t.TempDir(), "link")
+	require.NoError(os.Symlink(dir, link))
+	_, err = Open(link)
+	require.Error(err)
+}
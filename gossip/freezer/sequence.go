@@ -0,0 +1,163 @@
+package freezer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/sesanetwork/go-sesa/core/rawdb"
+)
+
+// table is a freezer table of the ancient chain data.
+type table interface {
+	Append(item uint64, blob []byte) error
+	Retrieve(item uint64) ([]byte, error)
+	Items() uint64
+	Truncate(items uint64) error
+	Sync() error
+	Close() error
+}
+
+// sequence is a set of the freezer tables, which items are appended together and are
+// numbered starting from the first number. The first number is written into a separate
+// table on the first append.
+type sequence struct {
+	name   string
+	first  table
+	tables []table
+
+	firstNum uint64 // first number of the sequence, valid only if the first table isn't empty
+}
+
+func openSequence(dir, name string, names []string, noCompression bool) (*sequence, error) {
+	q := &sequence{
+		name: name,
+	}
+	var err error
+	q.first, err = rawdb.NewFreezerTable(dir, name+".first", true)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range names {
+		t, err := rawdb.NewFreezerTable(dir, n, noCompression)
+		if err != nil {
+			_ = q.close()
+			return nil, err
+		}
+		q.tables = append(q.tables, t)
+	}
+	if err := q.repair(); err != nil {
+		_ = q.close()
+		return nil, err
+	}
+	return q, nil
+}
+
+// repair truncates the tables to the same number of items.
+func (q *sequence) repair() error {
+	if q.first.Items() != 0 {
+		blob, err := q.first.Retrieve(0)
+		if err != nil {
+			return err
+		}
+		if len(blob) != 8 {
+			return fmt.Errorf("malformed freezer %s first number", q.name)
+		}
+		atomic.StoreUint64(&q.firstNum, binary.BigEndian.Uint64(blob))
+	}
+	items := q.tables[0].Items()
+	for _, t := range q.tables[1:] {
+		if t.Items() < items {
+			items = t.Items()
+		}
+	}
+	if q.first.Items() == 0 {
+		items = 0
+	}
+	for _, t := range q.tables {
+		if err := t.Truncate(items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bounds returns the range [first, next) of the sequence numbers, ok is false if the sequence is empty.
+func (q *sequence) bounds() (first, next uint64, ok bool) {
+	// the last table is appended last
+	items := q.tables[len(q.tables)-1].Items()
+	if items == 0 {
+		return 0, 0, false
+	}
+	first = atomic.LoadUint64(&q.firstNum)
+	return first, first + items, true
+}
+
+func (q *sequence) has(n uint64) bool {
+	first, next, ok := q.bounds()
+	return ok && n >= first && n < next
+}
+
+// append appends the item of each table with the number n, which must follow the last appended one.
+func (q *sequence) append(n uint64, blobs [][]byte) error {
+	if len(blobs) != len(q.tables) {
+		return fmt.Errorf("freezer %s expects %d items, got %d", q.name, len(q.tables), len(blobs))
+	}
+	first, next, ok := q.bounds()
+	if !ok {
+		if q.first.Items() == 0 {
+			if err := q.first.Append(0, encodeUint64(n)); err != nil {
+				return err
+			}
+			if err := q.first.Sync(); err != nil {
+				return err
+			}
+			atomic.StoreUint64(&q.firstNum, n)
+		}
+		first = atomic.LoadUint64(&q.firstNum)
+		next = first
+	}
+	if n != next {
+		return ErrOutOrderInsertion
+	}
+	for i, t := range q.tables {
+		if err := t.Append(n-first, blobs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// retrieve returns the item of the i-th table with the number n, or nil if the number isn't in the sequence.
+func (q *sequence) retrieve(n uint64, i int) ([]byte, error) {
+	if !q.has(n) {
+		return nil, nil
+	}
+	blob, err := q.tables[i].Retrieve(n - atomic.LoadUint64(&q.firstNum))
+	if err != nil || len(blob) == 0 {
+		return nil, err
+	}
+	return blob, nil
+}
+
+func (q *sequence) sync() error {
+	for _, t := range q.tables {
+		if err := t.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *sequence) close() error {
+	var err error
+	for _, t := range append([]table{q.first}, q.tables...) {
+		if t == nil {
+			continue
+		}
+		if e := t.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
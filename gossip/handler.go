@@ -51,6 +51,7 @@ import (
 	"github.com/sesanetwork/go-sesa/native"
 	"github.com/sesanetwork/go-sesa/native/ibr"
 	"github.com/sesanetwork/go-sesa/native/ier"
+	"github.com/sesanetwork/go-sesa/tracing"
 	"github.com/sesanetwork/go-sesa/utils/txtime"
 )
 
@@ -1067,7 +1068,7 @@ func (h *handler) handleMsg(p *peer) error {
 		txs := make(types.Transactions, 0, len(requests))
 		for _, txid := range requests {
 			tx := h.txpool.Get(txid)
-			if tx == nil {
+			if tx == nil || h.txpool.IsPrivate(txid) {
 				continue
 			}
 			txs = append(txs, tx)
@@ -1410,6 +1411,7 @@ func (h *handler) BroadcastTxs(txs types.Transactions) {
 		}
 		totalSize += tx.Size()
 		log.Trace("Broadcast transaction", "hash", tx.Hash(), "recipients", len(peers))
+		tracing.TxStage(tx.Hash(), "Handler.BroadcastTxs()", tracing.PeersKey.Int(len(peers)))
 	}
 	fullRecipients := h.decideBroadcastAggressiveness(int(totalSize), time.Second, len(txset))
 	i := 0
@@ -0,0 +1,47 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoryPruner periodically deletes the EVM logs and transaction traces below the retention horizon,
+// or moves the old history into the freezer
+type HistoryPruner struct {
+	period time.Duration
+	prune  func() (more bool)
+
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+func (p *HistoryPruner) loop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// prune step by step to not hold the engine lock for long
+			for p.prune() {
+				select {
+				case <-p.quit:
+					return
+				default:
+				}
+			}
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+func (p *HistoryPruner) Start() {
+	p.wg.Add(1)
+	go p.loop()
+}
+
+func (p *HistoryPruner) Stop() {
+	close(p.quit)
+	p.wg.Wait()
+}
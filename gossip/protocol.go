@@ -1,6 +1,8 @@
 package gossip
 
 import (
+	"time"
+
 	"github.com/sesanetwork/go-vassalo/hash"
 	"github.com/sesanetwork/go-vassalo/native/idx"
 	"github.com/sesanetwork/go-sesa/common"
@@ -108,6 +110,7 @@ type TxPool interface {
 	AddRemotes([]*types.Transaction) []error
 	AddLocals(txs []*types.Transaction) []error
 	AddLocal(tx *types.Transaction) error
+	AddPrivate(tx *types.Transaction) error
 
 	Get(common.Hash) *types.Transaction
 
@@ -118,6 +121,10 @@ type TxPool interface {
 	Stats() (int, int)
 	Content() (map[common.Address]types.Transactions, map[common.Address]types.Transactions)
 	ContentFrom(addr common.Address) (types.Transactions, types.Transactions)
+
+	TxArrival(hash common.Hash) time.Time
+	RemoveTx(hash common.Hash) bool
+	RemoveSender(addr common.Address) int
 }
 
 // handshakeData is the network packet for the initial handshake message
@@ -39,6 +39,7 @@ import (
 	"github.com/sesanetwork/go-sesa/gossip/blockproc"
 	"github.com/sesanetwork/go-sesa/gossip/blockproc/drivermodule"
 	"github.com/sesanetwork/go-sesa/gossip/blockproc/eventmodule"
+	"github.com/sesanetwork/go-sesa/gossip/blockproc/hookmodule"
 	"github.com/sesanetwork/go-sesa/gossip/blockproc/evmmodule"
 	"github.com/sesanetwork/go-sesa/gossip/blockproc/sealmodule"
 	"github.com/sesanetwork/go-sesa/gossip/blockproc/verwatcher"
@@ -63,6 +64,10 @@ type ServiceFeed struct {
 	newEmittedEvent notify.Feed
 	newBlock        notify.Feed
 	newLogs         notify.Feed
+	newEvent        notify.Feed
+	newEpochState   notify.Feed
+	llrBlockResult  notify.Feed
+	llrEpochResult  notify.Feed
 }
 
 func (f *ServiceFeed) SubscribeNewEpoch(ch chan<- idx.Epoch) notify.Subscription {
@@ -81,6 +86,22 @@ func (f *ServiceFeed) SubscribeNewLogs(ch chan<- []*types.Log) notify.Subscripti
 	return f.scope.Track(f.newLogs.Subscribe(ch))
 }
 
+func (f *ServiceFeed) SubscribeNewEvent(ch chan<- *native.EventPayload) notify.Subscription {
+	return f.scope.Track(f.newEvent.Subscribe(ch))
+}
+
+func (f *ServiceFeed) SubscribeNewEpochState(ch chan<- filters.EpochNotify) notify.Subscription {
+	return f.scope.Track(f.newEpochState.Subscribe(ch))
+}
+
+func (f *ServiceFeed) SubscribeLlrBlockResult(ch chan<- filters.LlrBlockResultNotify) notify.Subscription {
+	return f.scope.Track(f.llrBlockResult.Subscribe(ch))
+}
+
+func (f *ServiceFeed) SubscribeLlrEpochResult(ch chan<- filters.LlrEpochResultNotify) notify.Subscription {
+	return f.scope.Track(f.llrEpochResult.Subscribe(ch))
+}
+
 type BlockProc struct {
 	SealerModule     blockproc.SealerModule
 	TxListenerModule blockproc.TxListenerModule
@@ -88,6 +109,18 @@ type BlockProc struct {
 	PostTxTransactor blockproc.TxTransactor
 	EventsModule     blockproc.ConfirmedEventsModule
 	EVMModule        blockproc.EVM
+
+	// ExtraTxListenerModules are notified about the new logs and receipts after TxListenerModule
+	ExtraTxListenerModules []blockproc.TxListenerModule
+	// ExtraEventsModules are notified about the confirmed events after EventsModule
+	ExtraEventsModules []blockproc.ConfirmedEventsModule
+}
+
+// withHooks returns the modules, which also run the extra modules
+func (p BlockProc) withHooks() BlockProc {
+	p.TxListenerModule = hookmodule.NewTxListenerModule(p.TxListenerModule, p.ExtraTxListenerModules...)
+	p.EventsModule = hookmodule.NewConfirmedEventsModule(p.EventsModule, p.ExtraEventsModules...)
+	return p
 }
 
 func DefaultBlockProc() BlockProc {
@@ -154,6 +187,10 @@ type Service struct {
 	haltCheck func(oldEpoch, newEpoch idx.Epoch, time time.Time) bool
 
 	tflusher PeriodicFlusher
+	hpruner  *HistoryPruner
+	hfreezer *HistoryPruner
+	notifier *FeedNotifier
+	backuper backuper
 
 	bootstrapping bool
 
@@ -282,6 +319,13 @@ func newService(config Config, store *Store, blockProc BlockProc, engine utypes.
 
 	svc.verWatcher = verwatcher.New(netVerStore)
 	svc.tflusher = svc.makePeriodicFlusher()
+	svc.notifier = newFeedNotifier()
+	if store.cfg.HistoryRetention.Enabled() {
+		svc.hpruner = svc.makeHistoryPruner(store.cfg.HistoryRetention)
+	}
+	if store.cfg.Freezer.Enabled() {
+		svc.hfreezer = svc.makeHistoryFreezer(store.cfg.Freezer)
+	}
 
 	return svc, nil
 }
@@ -334,6 +378,55 @@ func (s *Service) makePeriodicFlusher() PeriodicFlusher {
 	}
 }
 
+// makeHistoryPruner makes HistoryPruner
+func (s *Service) makeHistoryPruner(cfg HistoryRetentionConfig) *HistoryPruner {
+	return &HistoryPruner{
+		period: cfg.PrunePeriod,
+		prune: func() bool {
+			s.engineMu.Lock()
+			defer s.engineMu.Unlock()
+			if s.stopped {
+				return false
+			}
+			more, err := s.store.PruneHistory(cfg)
+			if err != nil {
+				s.Log.Error("Failed to prune history", "err", err)
+				return false
+			}
+			return more
+		},
+		quit: make(chan struct{}),
+	}
+}
+
+// makeHistoryFreezer makes HistoryPruner which moves the old history into the freezer
+func (s *Service) makeHistoryFreezer(cfg FreezerConfig) *HistoryPruner {
+	return &HistoryPruner{
+		period: cfg.FreezePeriod,
+		prune: func() bool {
+			// the freezer files are copied by the backups, so nothing is frozen until a backup is written
+			if !s.backuper.mu.TryLock() {
+				return false
+			}
+			defer s.backuper.mu.Unlock()
+			s.engineMu.Lock()
+			defer s.engineMu.Unlock()
+			if s.stopped {
+				return false
+			}
+			// the sealed epochs are flushed, so only the flushed data gets frozen
+			s.blockProcWg.Wait()
+			more, err := s.store.FreezeHistory(cfg)
+			if err != nil {
+				s.Log.Error("Failed to freeze history", "err", err)
+				return false
+			}
+			return more
+		},
+		quit: make(chan struct{}),
+	}
+}
+
 func (s *Service) EmitterWorld(signer valkeystore.SignerI) emitter.World {
 	return emitter.World{
 		External: &emitterWorld{
@@ -347,12 +440,45 @@ func (s *Service) EmitterWorld(signer valkeystore.SignerI) emitter.World {
 	}
 }
 
+// isValidatorNode returns true if any of the emitters is a validator of the current epoch.
+func (s *Service) isValidatorNode() bool {
+	validators := s.store.GetValidators()
+	for _, em := range s.emitters {
+		if id := em.ValidatorID(); id != 0 && validators.Exists(id) {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisterEmitter must be called before service is started
 func (s *Service) RegisterEmitter(em *emitter.Emitter) {
 	txtime.Enabled = true // enable tracking of tx times
 	s.emitters = append(s.emitters, em)
 }
 
+// RegisterTxListenerModule adds a named module, which listens to the new logs and receipts of every block.
+// The listeners are called synchronously by the block processing, and must not modify the state DB.
+// Must be called before GetConsensusCallbacks. Modules disabled in the config are skipped.
+func (s *Service) RegisterTxListenerModule(name string, m blockproc.TxListenerModule) {
+	if s.config.BlockProcHooks.isDisabled(name) {
+		log.Info("Block processing hook is disabled", "name", name)
+		return
+	}
+	s.blockProcModules.ExtraTxListenerModules = append(s.blockProcModules.ExtraTxListenerModules, m)
+}
+
+// RegisterConfirmedEventsModule adds a named module, which observes the confirmed events of every block.
+// The processors are called synchronously by the block processing, Finalize is called once a block is decided.
+// Must be called before GetConsensusCallbacks. Modules disabled in the config are skipped.
+func (s *Service) RegisterConfirmedEventsModule(name string, m blockproc.ConfirmedEventsModule) {
+	if s.config.BlockProcHooks.isDisabled(name) {
+		log.Info("Block processing hook is disabled", "name", name)
+		return
+	}
+	s.blockProcModules.ExtraEventsModules = append(s.blockProcModules.ExtraEventsModules, m)
+}
+
 // MakeProtocols constructs the P2P protocol definitions for `sesa`.
 func MakeProtocols(svc *Service, backend *handler, disc enode.Iterator) []p2p.Protocol {
 	protocols := make([]p2p.Protocol, len(ProtocolVersions))
@@ -387,7 +513,7 @@ func MakeProtocols(svc *Service, backend *handler, disc enode.Iterator) []p2p.Pr
 				}
 				return nil
 			},
-			Attributes:     []enr.Entry{currentENREntry(svc)},
+			Attributes:     []enr.Entry{svc.currentEnr()},
 			DialCandidates: disc,
 		}
 	}
@@ -417,6 +543,11 @@ func (s *Service) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   filters.NewPublicFilterAPI(s.EthAPI, s.config.FilterAPI),
 			Public:    true,
+		}, {
+			Namespace: "dag",
+			Version:   "1.0",
+			Service:   filters.NewPublicDAGFilterAPI(s.EthAPI),
+			Public:    true,
 		}, {
 			Namespace: "eth",
 			Version:   "1.0",
@@ -427,6 +558,11 @@ func (s *Service) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   s.netRPCService,
 			Public:    true,
+		}, {
+			Namespace: "admin",
+			Version:   "1.0",
+			Service:   NewPrivateAdminAPI(s),
+			Public:    false,
 		},
 	}...)
 
@@ -438,6 +574,7 @@ func (s *Service) Start() error {
 	s.gpo.Start(&GPOBackend{s.store, s.txpool})
 	// start tflusher before starting snapshots generation
 	s.tflusher.Start()
+	s.notifier.Start()
 	// start snapshots generation
 	if s.store.evm.IsEvmSnapshotPaused() && !s.config.AllowSnapsync {
 		return errors.New("cannot halt snapsync and start fullsync")
@@ -466,6 +603,13 @@ func (s *Service) Start() error {
 
 	s.verWatcher.Start()
 
+	if s.hpruner != nil {
+		s.hpruner.Start()
+	}
+	if s.hfreezer != nil {
+		s.hfreezer.Start()
+	}
+
 	if s.haltCheck != nil && s.haltCheck(s.store.GetEpoch(), s.store.GetEpoch(), s.store.GetBlockState().LastBlock.Time.Time()) {
 		// halt syncing
 		s.stopped = true
@@ -493,10 +637,18 @@ func (s *Service) Stop() error {
 
 	s.handler.Stop()
 	s.feed.scope.Close()
+	// the subscriptions are closed, so the notifier isn't blocked by the subscribers
+	s.notifier.Stop()
 	s.eventMux.Stop()
 	s.gpo.Stop()
-	// it's safe to stop tflusher only before locking engineMu
+	// it's safe to stop tflusher, hpruner and hfreezer only before locking engineMu
 	s.tflusher.Stop()
+	if s.hpruner != nil {
+		s.hpruner.Stop()
+	}
+	if s.hfreezer != nil {
+		s.hfreezer.Stop()
+	}
 
 	// flush the state at exit, after all the routines stopped
 	s.engineMu.Lock()
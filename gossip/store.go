@@ -15,6 +15,7 @@ import (
 	"github.com/sesanetwork/go-vassalo/sesadb/table"
 	"github.com/sesanetwork/go-vassalo/utils/wlru"
 	"github.com/sesanetwork/go-sesa/gossip/evmstore"
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
 	txtracer "github.com/sesanetwork/go-sesa/gossip/txtracer"
 	"github.com/sesanetwork/go-sesa/logger"
 	"github.com/sesanetwork/go-sesa/utils/adapters/snap2udb"
@@ -32,6 +33,7 @@ type Store struct {
 	snapshotedEVMDB *switchable.Snapshot
 	evm             *evmstore.Store
 	txtracer        *txtracer.Store
+	freezer         *freezer.Freezer
 	table           struct {
 		Version sesadb.Store `table:"_"`
 
@@ -54,7 +56,8 @@ type Store struct {
 		NetworkVersion sesadb.Store `table:"V"`
 
 		// API-only
-		BlockHashes sesadb.Store `table:"B"`
+		BlockHashes        sesadb.Store `table:"B"`
+		HistoryLowestBlock sesadb.Store `table:"H"`
 
 		LlrState           sesadb.Store `table:"S"`
 		LlrBlockResults    sesadb.Store `table:"R"`
@@ -85,6 +88,7 @@ type Store struct {
 		LastBVs                atomic.Value // store by pointer
 		LastEV                 atomic.Value // store by pointer
 		LlrState               atomic.Value // store by value
+		HistoryLowestBlock     atomic.Value // store by value
 		KvdbEvmSnap            atomic.Value // store by pointer
 		UpgradeHeights         atomic.Value // store by pointer
 		Genesis                atomic.Value // store by value
@@ -180,6 +184,9 @@ func (s *Store) Close() {
 	}
 	_ = s.closeEpochStore()
 	s.evm.Close()
+	if s.freezer != nil {
+		_ = s.freezer.Close()
+	}
 }
 
 func (s *Store) IsCommitNeeded() bool {
@@ -69,6 +69,9 @@ func (s *Store) GetBlock(n idx.Block) *native.Block {
 	}
 
 	block, _ := s.rlp.Get(s.table.Blocks, n.Bytes(), &native.Block{}).(*native.Block)
+	if block == nil {
+		block = s.getFrozenBlock(n)
+	}
 
 	// Add to LRU cache.
 	if block != nil {
@@ -80,6 +83,9 @@ func (s *Store) GetBlock(n idx.Block) *native.Block {
 
 func (s *Store) HasBlock(n idx.Block) bool {
 	has, _ := s.table.Blocks.Has(n.Bytes())
+	if !has && s.freezer != nil {
+		has = s.freezer.HasBlock(uint64(n))
+	}
 	return has
 }
 
@@ -192,21 +198,25 @@ func (s *Store) GetBlockTxs(n idx.Block, block *native.Block) types.Transactions
 	}
 
 	transactions := make(types.Transactions, 0, len(block.Txs)+len(block.InternalTxs)+len(block.Events)*10)
-	for _, txid := range block.InternalTxs {
-		tx := s.evm.GetTx(txid)
-		if tx == nil {
-			log.Crit("Internal tx not found", "tx", txid.String())
-			continue
+	if frozen, ok := s.evm.GetFrozenBlockTxs(n); ok {
+		transactions = append(transactions, frozen...)
+	} else {
+		for _, txid := range block.InternalTxs {
+			tx := s.evm.GetTx(txid)
+			if tx == nil {
+				log.Crit("Internal tx not found", "tx", txid.String())
+				continue
+			}
+			transactions = append(transactions, tx)
 		}
-		transactions = append(transactions, tx)
-	}
-	for _, txid := range block.Txs {
-		tx := s.evm.GetTx(txid)
-		if tx == nil {
-			log.Crit("Tx not found", "tx", txid.String())
-			continue
+		for _, txid := range block.Txs {
+			tx := s.evm.GetTx(txid)
+			if tx == nil {
+				log.Crit("Tx not found", "tx", txid.String())
+				continue
+			}
+			transactions = append(transactions, tx)
 		}
-		transactions = append(transactions, tx)
 	}
 	for _, id := range block.Events {
 		e := s.GetEventPayload(id)
@@ -52,6 +52,9 @@ func (s *Store) GetEventPayload(id hash.Event) *native.EventPayload {
 
 	key := id.Bytes()
 	w, _ := s.rlp.Get(s.table.Events, key, &native.EventPayload{}).(*native.EventPayload)
+	if w == nil {
+		w = s.getFrozenEvent(id)
+	}
 
 	if w != nil {
 		fixEventTxHashes(w)
@@ -76,6 +79,9 @@ func (s *Store) GetEvent(id hash.Event) *native.Event {
 
 	key := id.Bytes()
 	w, _ := s.rlp.Get(s.table.Events, key, &native.EventPayload{}).(*native.EventPayload)
+	if w == nil {
+		w = s.getFrozenEvent(id)
+	}
 	if w == nil {
 		return nil
 	}
@@ -92,31 +98,47 @@ func (s *Store) GetEvent(id hash.Event) *native.Event {
 
 func (s *Store) forEachEvent(it ethdb.Iterator, onEvent func(event *native.EventPayload) bool) {
 	for it.Next() {
-		event := &native.EventPayload{}
-		err := rlp.DecodeBytes(it.Value(), event)
-		if err != nil {
-			s.Log.Crit("Failed to decode event", "err", err)
-		}
-
-		if !onEvent(event) {
+		if !onEvent(s.decodeEvent(it.Value())) {
 			return
 		}
 	}
 }
 
+func (s *Store) decodeEvent(eventB rlp.RawValue) *native.EventPayload {
+	event := &native.EventPayload{}
+	err := rlp.DecodeBytes(eventB, event)
+	if err != nil {
+		s.Log.Crit("Failed to decode event", "err", err)
+	}
+	return event
+}
+
+// ForEachEpochEvent iterates over the events of the epoch, including the frozen ones.
 func (s *Store) ForEachEpochEvent(epoch idx.Epoch, onEvent func(event *native.EventPayload) bool) {
+	if s.isFrozenEpoch(epoch) {
+		s.forEachFrozenEpochEvent(epoch, func(_ hash.Event, eventB rlp.RawValue) bool {
+			return onEvent(s.decodeEvent(eventB))
+		})
+		return
+	}
 	it := s.table.Events.NewIterator(epoch.Bytes(), nil)
 	defer it.Release()
 	s.forEachEvent(it, onEvent)
 }
 
+// ForEachEvent iterates over the events starting from the epoch, including the frozen ones.
 func (s *Store) ForEachEvent(start idx.Epoch, onEvent func(event *native.EventPayload) bool) {
-	it := s.table.Events.NewIterator(nil, start.Bytes())
-	defer it.Release()
-	s.forEachEvent(it, onEvent)
+	s.ForEachEventRLP(start.Bytes(), func(_ hash.Event, eventB rlp.RawValue) bool {
+		return onEvent(s.decodeEvent(eventB))
+	})
 }
 
+// ForEachEventRLP iterates over the serialized events starting from the key, including the frozen ones.
 func (s *Store) ForEachEventRLP(start []byte, onEvent func(key hash.Event, event rlp.RawValue) bool) {
+	start, ok := s.forEachFrozenEventRLP(start, onEvent)
+	if !ok {
+		return
+	}
 	it := s.table.Events.NewIterator(nil, start)
 	defer it.Release()
 	for it.Next() {
@@ -126,12 +148,27 @@ func (s *Store) ForEachEventRLP(start []byte, onEvent func(key hash.Event, event
 	}
 }
 
+// FindEventHashes returns the IDs of the events with the epoch, lamport and ID prefix, including the frozen ones.
 func (s *Store) FindEventHashes(epoch idx.Epoch, lamport idx.Lamport, hashPrefix []byte) hash.Events {
 	prefix := bytes.NewBuffer(epoch.Bytes())
 	prefix.Write(lamport.Bytes())
 	prefix.Write(hashPrefix)
 	res := make(hash.Events, 0, 10)
 
+	if s.isFrozenEpoch(epoch) {
+		// frozen events are sorted by ID within the epoch
+		s.forEachFrozenEpochEvent(epoch, func(id hash.Event, _ rlp.RawValue) bool {
+			switch cmp := bytes.Compare(id.Bytes(), prefix.Bytes()); {
+			case bytes.HasPrefix(id.Bytes(), prefix.Bytes()):
+				res = append(res, id)
+			case cmp > 0:
+				return false
+			}
+			return true
+		})
+		return res
+	}
+
 	it := s.table.Events.NewIterator(prefix.Bytes(), nil)
 	defer it.Release()
 	for it.Next() {
@@ -149,6 +186,9 @@ func (s *Store) GetEventPayloadRLP(id hash.Event) rlp.RawValue {
 	if err != nil {
 		s.Log.Crit("Failed to get key-value", "err", err)
 	}
+	if data == nil {
+		data = s.getFrozenEventRLP(id)
+	}
 	return data
 }
 
@@ -158,6 +198,9 @@ func (s *Store) HasEvent(h hash.Event) bool {
 		return has
 	}
 	has, _ := s.table.Events.Has(h.Bytes())
+	if !has {
+		has = s.getFrozenEventRLP(h) != nil
+	}
 	return has
 }
 
@@ -0,0 +1,341 @@
+package gossip
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/rlp"
+
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
+	"github.com/sesanetwork/go-sesa/native"
+)
+
+type (
+	// FreezerConfig is a policy of moving the old events, blocks, txs and receipts
+	// from the key-value DB into the append-only freezer.
+	FreezerConfig struct {
+		// Epochs is a number of the latest epochs to keep in the key-value DB, 0 disables the freezing.
+		// Events of the frozen epochs are still served to the peers and the API, from the freezer.
+		Epochs idx.Epoch
+		// FreezePeriod is a period of the background freezing.
+		FreezePeriod time.Duration
+		// FreezeStep is a max number of blocks frozen at once.
+		FreezeStep idx.Block
+	}
+)
+
+// DefaultFreezerConfig keeps all the data in the key-value DB.
+func DefaultFreezerConfig() FreezerConfig {
+	return FreezerConfig{
+		FreezePeriod: time.Minute,
+		FreezeStep:   1000,
+	}
+}
+
+// Enabled is true if the old data is frozen.
+func (c FreezerConfig) Enabled() bool {
+	return c.Epochs != 0
+}
+
+// OpenFreezer opens the freezer of the old events, blocks, txs and receipts, which are
+// looked up in the freezer if they are missing in the key-value DB.
+func (s *Store) OpenFreezer(dir string) error {
+	f, err := freezer.Open(dir)
+	if err != nil {
+		return err
+	}
+	s.freezer = f
+	s.evm.SetFreezer(f)
+	return nil
+}
+
+// freezerHorizon returns the lowest epoch and the lowest block to keep in the key-value DB.
+func (s *Store) freezerHorizon(cfg FreezerConfig) (idx.Epoch, idx.Block) {
+	epoch := s.GetEpoch()
+	if epoch <= cfg.Epochs {
+		return 0, 0
+	}
+	horizon := epoch - cfg.Epochs + 1
+	// the history state of an epoch is the state at the epoch start
+	bs, _ := s.GetHistoryBlockEpochState(horizon)
+	if bs == nil {
+		return horizon, 0
+	}
+	return horizon, bs.LastBlock.Idx + 1
+}
+
+// FreezeHistory moves the events and blocks below the freezer horizon from the key-value DB
+// into the freezer, at most one epoch and cfg.FreezeStep blocks at once. The data is deleted
+// from the key-value DB only after it's written into the freezer. It returns true if there is
+// more history to freeze.
+func (s *Store) FreezeHistory(cfg FreezerConfig) (more bool, err error) {
+	if s.freezer == nil {
+		return false, nil
+	}
+	epochHorizon, blockHorizon := s.freezerHorizon(cfg)
+
+	moreBlocks, err := s.freezeBlocks(blockHorizon, cfg.FreezeStep)
+	if err != nil {
+		return false, err
+	}
+	moreEpochs, err := s.freezeEpochs(epochHorizon)
+	if err != nil {
+		return false, err
+	}
+	if err := s.freezer.Sync(); err != nil {
+		return false, err
+	}
+	moreDeleted := s.deleteFrozen(cfg.FreezeStep)
+	return moreBlocks || moreEpochs || moreDeleted, nil
+}
+
+// nextFreezerBlock returns the next block to freeze, ok is false if there are no blocks.
+func (s *Store) nextFreezerBlock() (idx.Block, bool) {
+	if _, next, ok := s.freezer.Blocks(); ok {
+		return idx.Block(next), true
+	}
+	it := s.table.Blocks.NewIterator(nil, nil)
+	defer it.Release()
+	if !it.Next() {
+		return 0, false
+	}
+	return idx.BytesToBlock(it.Key()), true
+}
+
+func (s *Store) freezeBlocks(horizon idx.Block, step idx.Block) (more bool, err error) {
+	next, ok := s.nextFreezerBlock()
+	if !ok || next >= horizon {
+		return false, nil
+	}
+	until := horizon
+	if step != 0 && until-next > step {
+		until = next + step
+		more = true
+	}
+	for n := next; n < until; n++ {
+		blockRLP, err := s.table.Blocks.Get(n.Bytes())
+		if err != nil {
+			return false, err
+		}
+		if blockRLP == nil {
+			s.Log.Warn("Block is missing, freezing of blocks is paused", "block", n)
+			return false, nil
+		}
+		if err := s.freezeBlock(n, blockRLP); err != nil {
+			return false, err
+		}
+	}
+	s.Log.Debug("Frozen blocks", "from", next, "until", until)
+	return more, nil
+}
+
+func (s *Store) freezeBlock(n idx.Block, blockRLP []byte) error {
+	var block native.Block
+	if err := rlp.DecodeBytes(blockRLP, &block); err != nil {
+		return err
+	}
+	txs := make(types.Transactions, 0, len(block.InternalTxs)+len(block.Txs))
+	for _, txids := range [][]common.Hash{block.InternalTxs, block.Txs} {
+		for _, txid := range txids {
+			tx := s.evm.GetTx(txid)
+			if tx == nil {
+				return fmt.Errorf("tx %s of block %d is not found", txid.String(), n)
+			}
+			txs = append(txs, tx)
+		}
+	}
+	txsRLP, err := rlp.EncodeToBytes(txs)
+	if err != nil {
+		return err
+	}
+	return s.freezer.AppendBlock(uint64(n), blockRLP, txsRLP, s.evm.GetRawReceiptsRLP(n))
+}
+
+// nextFreezerEpoch returns the next epoch to freeze, ok is false if there are no events.
+func (s *Store) nextFreezerEpoch() (idx.Epoch, bool) {
+	if _, next, ok := s.freezer.Epochs(); ok {
+		return idx.Epoch(next), true
+	}
+	it := s.table.Events.NewIterator(nil, nil)
+	defer it.Release()
+	if !it.Next() {
+		return 0, false
+	}
+	return hash.BytesToEvent(it.Key()).Epoch(), true
+}
+
+func (s *Store) freezeEpochs(horizon idx.Epoch) (more bool, err error) {
+	epoch, ok := s.nextFreezerEpoch()
+	if !ok || epoch >= horizon {
+		return false, nil
+	}
+	// events are sorted by ID within the epoch
+	events := make([]freezer.Event, 0, 1000)
+	it := s.table.Events.NewIterator(epoch.Bytes(), nil)
+	for it.Next() {
+		events = append(events, freezer.Event{
+			Key:   common.CopyBytes(it.Key()),
+			Value: common.CopyBytes(it.Value()),
+		})
+	}
+	it.Release()
+	if err := s.freezer.AppendEpoch(uint64(epoch), events); err != nil {
+		return false, err
+	}
+	s.Log.Debug("Frozen epoch", "epoch", epoch, "events", len(events))
+	return epoch+1 < horizon, nil
+}
+
+// deleteFrozen deletes the frozen blocks and events from the key-value DB, at most limit blocks at once.
+// It returns true if there are more frozen blocks to delete.
+func (s *Store) deleteFrozen(limit idx.Block) (more bool) {
+	if _, next, ok := s.freezer.Blocks(); ok {
+		blocks := make([]idx.Block, 0, limit)
+		it := s.table.Blocks.NewIterator(nil, nil)
+		for it.Next() {
+			n := idx.BytesToBlock(it.Key())
+			if uint64(n) >= next {
+				break
+			}
+			if limit != 0 && idx.Block(len(blocks)) >= limit {
+				more = true
+				break
+			}
+			blocks = append(blocks, n)
+		}
+		it.Release()
+		for _, n := range blocks {
+			s.delFrozenBlock(n)
+		}
+	}
+	if _, next, ok := s.freezer.Epochs(); ok {
+		events := make(hash.Events, 0, 1000)
+		it := s.table.Events.NewIterator(nil, nil)
+		for it.Next() {
+			id := hash.BytesToEvent(it.Key())
+			if uint64(id.Epoch()) >= next {
+				break
+			}
+			events = append(events, id)
+		}
+		it.Release()
+		for _, id := range events {
+			s.DelEvent(id)
+		}
+	}
+	return more
+}
+
+// delFrozenBlock deletes the block with its non-event txs and receipts from the key-value DB.
+func (s *Store) delFrozenBlock(n idx.Block) {
+	block, _ := s.rlp.Get(s.table.Blocks, n.Bytes(), &native.Block{}).(*native.Block)
+	if block != nil {
+		for _, txid := range block.InternalTxs {
+			s.evm.DelTx(txid)
+		}
+		for _, txid := range block.Txs {
+			s.evm.DelTx(txid)
+		}
+	}
+	s.evm.DelReceipts(n)
+	if err := s.table.Blocks.Delete(n.Bytes()); err != nil {
+		s.Log.Crit("Failed to delete key", "err", err)
+	}
+	s.cache.Blocks.Remove(n)
+}
+
+func (s *Store) getFrozenBlock(n idx.Block) *native.Block {
+	if s.freezer == nil {
+		return nil
+	}
+	buf, err := s.freezer.Block(uint64(n))
+	if err != nil {
+		s.Log.Crit("Failed to read freezer", "err", err)
+	}
+	if buf == nil {
+		return nil
+	}
+	block := &native.Block{}
+	if err := rlp.DecodeBytes(buf, block); err != nil {
+		s.Log.Crit("Failed to decode rlp", "err", err, "size", len(buf))
+	}
+	return block
+}
+
+func (s *Store) getFrozenEventRLP(id hash.Event) rlp.RawValue {
+	if s.freezer == nil {
+		return nil
+	}
+	buf, err := s.freezer.Event(uint64(id.Epoch()), id.Bytes())
+	if err != nil {
+		s.Log.Crit("Failed to read freezer", "err", err)
+	}
+	return buf
+}
+
+func (s *Store) getFrozenEvent(id hash.Event) *native.EventPayload {
+	buf := s.getFrozenEventRLP(id)
+	if buf == nil {
+		return nil
+	}
+	e := &native.EventPayload{}
+	if err := rlp.DecodeBytes(buf, e); err != nil {
+		s.Log.Crit("Failed to decode rlp", "err", err, "size", len(buf))
+	}
+	return e
+}
+
+// isFrozenEpoch returns true if the epoch events are read from the freezer.
+func (s *Store) isFrozenEpoch(epoch idx.Epoch) bool {
+	return s.freezer != nil && s.freezer.HasEpoch(uint64(epoch))
+}
+
+func (s *Store) forEachFrozenEpochEvent(epoch idx.Epoch, onEvent func(key hash.Event, event rlp.RawValue) bool) bool {
+	stopped := false
+	err := s.freezer.ForEachEpochEvent(uint64(epoch), func(e freezer.Event) bool {
+		stopped = !onEvent(hash.BytesToEvent(e.Key), e.Value)
+		return !stopped
+	})
+	if err != nil {
+		s.Log.Crit("Failed to read freezer", "err", err)
+	}
+	return !stopped
+}
+
+// forEachFrozenEventRLP iterates over the frozen events starting from the key. It returns the key
+// to continue the iteration from in the key-value DB, or false if the iteration is stopped.
+// The frozen events, which aren't deleted from the key-value DB yet, are skipped there.
+func (s *Store) forEachFrozenEventRLP(start []byte, onEvent func(key hash.Event, event rlp.RawValue) bool) ([]byte, bool) {
+	if s.freezer == nil {
+		return start, true
+	}
+	first, next, ok := s.freezer.Epochs()
+	if !ok {
+		return start, true
+	}
+	// the start key may be shorter than the epoch
+	startEpoch := uint64(idx.BytesToEpoch(append(common.CopyBytes(start), make([]byte, 4)...)[:4]))
+	if startEpoch < first {
+		startEpoch = first
+	}
+	for epoch := startEpoch; epoch < next; epoch++ {
+		ok := s.forEachFrozenEpochEvent(idx.Epoch(epoch), func(key hash.Event, event rlp.RawValue) bool {
+			if bytes.Compare(key.Bytes(), start) < 0 {
+				return true
+			}
+			return onEvent(key, event)
+		})
+		if !ok {
+			return nil, false
+		}
+	}
+	if unfrozen := idx.Epoch(next).Bytes(); bytes.Compare(unfrozen, start) > 0 {
+		return unfrozen, true
+	}
+	return start, true
+}
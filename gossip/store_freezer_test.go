@@ -0,0 +1,139 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/rlp"
+
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+func TestStore_FreezeHistory(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	env := newTestEnv(2, 3)
+	defer env.Close()
+	store := env.store
+	require.NoError(store.OpenFreezer(t.TempDir()))
+
+	for i := 0; i < 4; i++ {
+		_, err := env.ApplyTxs(nextEpoch, env.Transfer(1, 2, utils.Tosesa(1)))
+		require.NoError(err)
+	}
+
+	// remember the history before freezing
+	type frozenBlock struct {
+		block    *native.Block
+		txs      types.Transactions
+		receipts rlp.RawValue
+	}
+	blocks := make(map[idx.Block]frozenBlock)
+	store.ForEachBlock(func(n idx.Block, block *native.Block) {
+		blocks[n] = frozenBlock{
+			block:    block,
+			txs:      store.GetBlockTxs(n, block),
+			receipts: store.evm.GetRawReceiptsRLP(n),
+		}
+	})
+	events := make(map[hash.Event]rlp.RawValue)
+	var ids hash.Events
+	store.ForEachEventRLP(nil, func(id hash.Event, e rlp.RawValue) bool {
+		events[id] = e
+		ids = append(ids, id)
+		return true
+	})
+
+	cfg := DefaultFreezerConfig()
+	cfg.Epochs = 1
+	cfg.FreezeStep = 2
+	for {
+		more, err := store.FreezeHistory(cfg)
+		require.NoError(err)
+		if !more {
+			break
+		}
+	}
+
+	// all the sealed epochs are frozen and deleted from the key-value DB
+	_, nextEpoch, ok := store.freezer.Epochs()
+	require.True(ok)
+	require.Equal(uint64(store.GetEpoch()), nextEpoch)
+	_, nextBlock, ok := store.freezer.Blocks()
+	require.True(ok)
+	bs, _ := store.GetHistoryBlockEpochState(store.GetEpoch())
+	require.Equal(uint64(bs.LastBlock.Idx+1), nextBlock)
+	store.ForEachBlock(func(n idx.Block, _ *native.Block) {
+		require.GreaterOrEqual(uint64(n), nextBlock)
+	})
+	it := store.table.Events.NewIterator(nil, nil)
+	for it.Next() {
+		require.Equal(store.GetEpoch(), hash.BytesToEvent(it.Key()).Epoch())
+	}
+	it.Release()
+
+	// the history is read transparently
+	for n, expected := range blocks {
+		require.True(store.HasBlock(n))
+		block := store.GetBlock(n)
+		require.Equal(expected.block, block)
+		require.Equal(len(expected.txs), len(store.GetBlockTxs(n, block)))
+		for i, tx := range store.GetBlockTxs(n, block) {
+			require.Equal(expected.txs[i].Hash(), tx.Hash())
+		}
+		require.Equal(expected.receipts, store.evm.GetRawReceiptsRLP(n))
+	}
+	for id, expected := range events {
+		require.True(store.HasEvent(id))
+		require.Equal(expected, store.GetEventPayloadRLP(id))
+		require.Equal(id, store.GetEventPayload(id).ID())
+		require.Equal(id, store.GetEvent(id).ID())
+	}
+	require.False(store.HasBlock(store.GetLatestBlockIndex() + 1))
+
+	// the events are streamed from the freezer in the same order
+	var streamed hash.Events
+	store.ForEachEventRLP(nil, func(id hash.Event, e rlp.RawValue) bool {
+		require.Equal(events[id], e)
+		streamed = append(streamed, id)
+		return true
+	})
+	require.Equal(ids, streamed)
+
+	// a frozen epoch is streamed from the middle, as by the DAG stream seeder
+	frozenEpoch := idx.Epoch(nextEpoch - 1)
+	var epochIDs hash.Events
+	for _, id := range ids {
+		if id.Epoch() == frozenEpoch {
+			epochIDs = append(epochIDs, id)
+		}
+	}
+	require.Greater(len(epochIDs), 1)
+	streamed = streamed[:0]
+	store.ForEachEventRLP(epochIDs[1].Bytes(), func(id hash.Event, _ rlp.RawValue) bool {
+		if id.Epoch() != frozenEpoch {
+			return false
+		}
+		streamed = append(streamed, id)
+		return true
+	})
+	require.Equal(epochIDs[1:], streamed)
+
+	// the frozen epoch events are iterated and found by the ID prefix
+	streamed = streamed[:0]
+	store.ForEachEpochEvent(frozenEpoch, func(e *native.EventPayload) bool {
+		streamed = append(streamed, e.ID())
+		return true
+	})
+	require.Equal(epochIDs, streamed)
+	for _, id := range epochIDs {
+		require.Contains(store.FindEventHashes(id.Epoch(), id.Lamport(), id.Bytes()[8:10]), id)
+	}
+}
@@ -0,0 +1,117 @@
+package gossip
+
+import (
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+)
+
+type (
+	// HistoryRetentionConfig is a retention policy of the EVM logs and transaction traces.
+	HistoryRetentionConfig struct {
+		// Blocks is a number of the latest blocks to keep the history of, 0 means to keep all.
+		Blocks idx.Block
+		// Epochs is a number of the latest epochs to keep the history of, 0 means to keep all.
+		Epochs idx.Epoch
+		// PrunePeriod is a period of the background pruning.
+		PrunePeriod time.Duration
+		// PruneStep is a max number of blocks pruned at once.
+		PruneStep idx.Block
+	}
+)
+
+// DefaultHistoryRetentionConfig keeps the whole history.
+func DefaultHistoryRetentionConfig() HistoryRetentionConfig {
+	return HistoryRetentionConfig{
+		PrunePeriod: time.Minute,
+		PruneStep:   1000,
+	}
+}
+
+// Enabled is true if the history is pruned.
+func (c HistoryRetentionConfig) Enabled() bool {
+	return c.Blocks != 0 || c.Epochs != 0
+}
+
+// SetHistoryLowestBlock stores the lowest block which EVM logs and transaction traces are available for.
+func (s *Store) SetHistoryLowestBlock(n idx.Block) {
+	if err := s.table.HistoryLowestBlock.Put([]byte{}, n.Bytes()); err != nil {
+		s.Log.Crit("Failed to put key-value", "err", err)
+	}
+	s.cache.HistoryLowestBlock.Store(n)
+}
+
+// GetHistoryLowestBlock returns the lowest block which EVM logs and transaction traces are available for.
+func (s *Store) GetHistoryLowestBlock() idx.Block {
+	if v := s.cache.HistoryLowestBlock.Load(); v != nil {
+		return v.(idx.Block)
+	}
+	buf, err := s.table.HistoryLowestBlock.Get([]byte{})
+	if err != nil {
+		s.Log.Crit("Failed to get key-value", "err", err)
+	}
+	var n idx.Block
+	if buf != nil {
+		n = idx.BytesToBlock(buf)
+	}
+	s.cache.HistoryLowestBlock.Store(n)
+	return n
+}
+
+// historyHorizon returns the lowest block to keep the history of according to the retention policy.
+func (s *Store) historyHorizon(cfg HistoryRetentionConfig) idx.Block {
+	var horizon idx.Block
+	if cfg.Blocks != 0 {
+		latest := s.GetLatestBlockIndex()
+		if latest > cfg.Blocks {
+			horizon = latest - cfg.Blocks + 1
+		}
+	}
+	if cfg.Epochs != 0 {
+		epoch := s.GetEpoch()
+		if epoch > cfg.Epochs {
+			// the history state of an epoch is the state at the epoch start
+			bs, _ := s.GetHistoryBlockEpochState(epoch - cfg.Epochs + 1)
+			if bs != nil && (horizon == 0 || bs.LastBlock.Idx+1 < horizon) {
+				horizon = bs.LastBlock.Idx + 1
+			}
+		}
+	}
+	return horizon
+}
+
+// PruneHistory deletes EVM logs and transaction traces of the blocks below the retention horizon,
+// at most cfg.PruneStep blocks at once. It returns true if there is more history to prune.
+func (s *Store) PruneHistory(cfg HistoryRetentionConfig) (more bool, err error) {
+	horizon := s.historyHorizon(cfg)
+	lowest := s.GetHistoryLowestBlock()
+	if horizon <= lowest {
+		return false, nil
+	}
+	until := horizon
+	if cfg.PruneStep != 0 && until-lowest > cfg.PruneStep {
+		until = lowest + cfg.PruneStep
+		more = true
+	}
+
+	if s.txtracer != nil {
+		for n := lowest; n < until; n++ {
+			block := s.GetBlock(n)
+			if block == nil {
+				continue
+			}
+			for _, tx := range s.GetBlockTxs(n, block) {
+				if err = s.txtracer.RemoveTxTrace(tx.Hash()); err != nil {
+					return
+				}
+			}
+		}
+	}
+	pruned, err := s.evm.EvmLogs.Prune(until)
+	if err != nil {
+		return
+	}
+	s.SetHistoryLowestBlock(until)
+	s.Log.Debug("Pruned history", "from", lowest, "until", until, "logs", pruned)
+	return
+}
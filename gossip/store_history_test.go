@@ -0,0 +1,110 @@
+package gossip
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/sesadb/flushable"
+	"github.com/sesanetwork/go-vassalo/sesadb/memorydb"
+	"github.com/sesanetwork/go-vassalo/utils/cachescale"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/ethapi"
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/logger"
+	"github.com/sesanetwork/go-sesa/rpc"
+)
+
+// logEmitterCode is an init code which emits a log with the logTopic topic
+const logEmitterCode = "0x60aa60006000a1"
+
+var logTopic = common.BigToHash(big.NewInt(0xaa))
+
+func TestStore_PruneHistory(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	storeCfg := LiteStoreConfig()
+	storeCfg.TraceTransactions = true
+	store := NewStore(flushable.NewSyncedPool(memorydb.NewProducer(""), []byte{0}), storeCfg)
+	env := newTestEnvWithStore(2, 3, DefaultConfig(cachescale.Identity), store, nil)
+	defer env.Close()
+	api := ethapi.NewPublicBlockChainAPI(env.EthAPI)
+	traceAPI := ethapi.NewPublicTxTraceAPI(env.EthAPI)
+
+	var receipts types.Receipts
+	for i := 0; i < 6; i++ {
+		rr, err := env.ApplyTxs(sameEpoch, env.Contract(1, common.Big0, logEmitterCode))
+		require.NoError(err)
+		require.Len(rr[0].Logs, 1)
+		receipts = append(receipts, rr...)
+	}
+	findLogs := func() []*types.Log {
+		logs, err := store.EvmStore().EvmLogs.FindInBlocks(ctx, 0, store.GetLatestBlockIndex(), [][]common.Hash{{}, {logTopic}})
+		require.NoError(err)
+		return logs
+	}
+	hasTrace := func(r *types.Receipt) bool {
+		ok, err := store.TxTraceStore().HasTxTrace(r.TxHash)
+		require.NoError(err)
+		return ok
+	}
+	// the whole history is available before pruning
+	require.Zero(api.LowestAvailableBlock())
+	require.Len(findLogs(), len(receipts))
+	for _, r := range receipts {
+		require.True(hasTrace(r))
+	}
+
+	cfg := DefaultHistoryRetentionConfig()
+	cfg.Blocks = 2
+	cfg.PruneStep = 1
+	steps := 0
+	for {
+		more, err := store.PruneHistory(cfg)
+		require.NoError(err)
+		steps++
+		if !more {
+			break
+		}
+	}
+	latest := store.GetLatestBlockIndex()
+	horizon := latest - 1
+	require.Equal(int(horizon), steps)
+	require.Equal(horizon, store.GetHistoryLowestBlock())
+	require.Equal(hexutil.Uint64(horizon), api.LowestAvailableBlock())
+
+	// the logs and traces below the horizon are deleted
+	logTxs := make(map[common.Hash]bool)
+	for _, l := range findLogs() {
+		require.GreaterOrEqual(l.BlockNumber, uint64(horizon))
+		logTxs[l.TxHash] = true
+	}
+	for _, r := range receipts {
+		kept := idx.Block(r.BlockNumber.Uint64()) >= horizon
+		require.Equal(kept, hasTrace(r))
+		require.Equal(kept, logTxs[r.TxHash])
+	}
+
+	// trace_filter refuses the pruned blocks
+	fromBlock := func(n idx.Block) *rpc.BlockNumberOrHash {
+		sel := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n))
+		return &sel
+	}
+	_, err := traceAPI.Filter(ctx, ethapi.FilterArgs{FromBlock: fromBlock(horizon - 1)})
+	require.ErrorIs(err, evmcore.ErrPrunedHistory)
+	_, err = traceAPI.Filter(ctx, ethapi.FilterArgs{FromBlock: fromBlock(horizon)})
+	require.NoError(err)
+
+	// nothing more to prune until new blocks are decided
+	more, err := store.PruneHistory(cfg)
+	require.NoError(err)
+	require.False(more)
+	require.Equal(hexutil.Uint64(horizon), api.LowestAvailableBlock())
+}
@@ -44,7 +44,8 @@ func (s *Store) migrations() *migration.Migration {
 		Next("erase gossip-async db", s.eraseGossipAsyncDB).
 		Next("erase SFC API table", s.eraseSfcApiTable).
 		Next("erase legacy genesis DB", s.eraseGenesisDB).
-		Next("calculate upgrade heights", s.calculateUpgradeHeights)
+		Next("calculate upgrade heights", s.calculateUpgradeHeights).
+		Next("index EVM logs addresses", s.reindexEvmLogsAddresses)
 }
 
 func unsupportedMigration() error {
@@ -142,3 +143,7 @@ func (s *Store) calculateUpgradeHeights() error {
 	}
 	return nil
 }
+
+func (s *Store) reindexEvmLogsAddresses() error {
+	return s.evm.EvmLogs.ReindexAddresses()
+}
@@ -0,0 +1,1509 @@
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package graphql provides a GraphQL interface to Sesa node data.
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/common/math"
+	"github.com/sesanetwork/go-sesa/core/state"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/ethapi"
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
+	"github.com/sesanetwork/go-sesa/gossip/gasprice"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+	"github.com/sesanetwork/go-sesa/rpc"
+	"github.com/sesanetwork/go-sesa/trie"
+)
+
+var errBlockInvariant = errors.New("block objects must be instantiated with at least one of num or hash")
+
+// Account represents an Ethereum account at a particular block.
+type Account struct {
+	r             *Resolver
+	address       common.Address
+	blockNrOrHash rpc.BlockNumberOrHash
+}
+
+// getState fetches the StateDB object for an account.
+func (a *Account) getState(ctx context.Context) (*state.StateDB, error) {
+	state, _, err := a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	return state, err
+}
+
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	state, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*state.GetBalance(a.address)), nil
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	// Ask transaction pool for the nonce which includes pending transactions
+	if blockNr, ok := a.blockNrOrHash.Number(); ok && blockNr == rpc.PendingBlockNumber {
+		nonce, err := a.r.backend.GetPoolNonce(ctx, a.address)
+		if err != nil {
+			return 0, err
+		}
+		return hexutil.Uint64(nonce), nil
+	}
+	state, err := a.getState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(state.GetNonce(a.address)), nil
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	state, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return state.GetCode(a.address), nil
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	state, err := a.getState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return state.GetState(a.address, args.Slot), nil
+}
+
+// Log represents an individual log message. All arguments are mandatory.
+type Log struct {
+	r           *Resolver
+	transaction *Transaction
+	log         *types.Log
+}
+
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		r:             l.r,
+		address:       l.log.Address,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (l *Log) Index(ctx context.Context) int32 {
+	return int32(l.log.Index)
+}
+
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// AccessTuple represents EIP-2930
+type AccessTuple struct {
+	address     common.Address
+	storageKeys []common.Hash
+}
+
+func (at *AccessTuple) Address(ctx context.Context) common.Address {
+	return at.address
+}
+
+func (at *AccessTuple) StorageKeys(ctx context.Context) []common.Hash {
+	return at.storageKeys
+}
+
+// Transaction represents an Ethereum transaction.
+// backend and hash are mandatory; all others will be fetched when required.
+type Transaction struct {
+	r     *Resolver
+	hash  common.Hash
+	tx    *types.Transaction
+	block *Block
+	index uint64
+}
+
+// resolve returns the internal transaction object, fetching it if needed.
+func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, error) {
+	if t.tx != nil {
+		return t.tx, nil
+	}
+	// Try to return an already finalized transaction
+	tx, blockNumber, index, err := t.r.backend.GetTransaction(ctx, t.hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		t.tx = tx
+		blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNumber))
+		t.block = &Block{
+			r:            t.r,
+			numberOrHash: &blockNrOrHash,
+		}
+		t.index = index
+		return t.tx, nil
+	}
+	// No finalized transaction, try to retrieve it from the pool
+	t.tx = t.r.backend.GetPoolTransaction(t.hash)
+	return t.tx, nil
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.hash
+}
+
+func (t *Transaction) InputData(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.Data(), nil
+}
+
+func (t *Transaction) Gas(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Gas()), nil
+}
+
+func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	switch tx.Type() {
+	case types.AccessListTxType, types.LegacyTxType:
+		return hexutil.Big(*tx.GasPrice()), nil
+	default:
+		if t.block != nil {
+			if baseFee, _ := t.block.BaseFeePerGas(ctx); baseFee != nil {
+				// price = min(tip, gasFeeCap - baseFee) + baseFee
+				return (hexutil.Big)(*math.BigMin(new(big.Int).Add(tx.GasTipCap(), baseFee.ToInt()), tx.GasFeeCap())), nil
+			}
+		}
+		return hexutil.Big(*tx.GasPrice()), nil
+	}
+}
+
+func (t *Transaction) EffectiveGasPrice(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	// Pending tx
+	if t.block == nil {
+		return nil, nil
+	}
+	baseFee, err := t.block.BaseFeePerGas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee == nil {
+		return (*hexutil.Big)(tx.GasPrice()), nil
+	}
+	return (*hexutil.Big)(math.BigMin(new(big.Int).Add(tx.GasTipCap(), baseFee.ToInt()), tx.GasFeeCap())), nil
+}
+
+func (t *Transaction) MaxFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	switch tx.Type() {
+	case types.AccessListTxType, types.LegacyTxType:
+		return nil, nil
+	default:
+		return (*hexutil.Big)(tx.GasFeeCap()), nil
+	}
+}
+
+func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	switch tx.Type() {
+	case types.AccessListTxType, types.LegacyTxType:
+		return nil, nil
+	default:
+		return (*hexutil.Big)(tx.GasTipCap()), nil
+	}
+}
+
+func (t *Transaction) EffectiveTip(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	// Pending tx
+	if t.block == nil {
+		return nil, nil
+	}
+	baseFee, err := t.block.BaseFeePerGas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee == nil {
+		return (*hexutil.Big)(tx.GasPrice()), nil
+	}
+	tip, err := tx.EffectiveGasTip(baseFee.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tip), nil
+}
+
+func (t *Transaction) Value(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	if tx.Value() == nil {
+		return hexutil.Big{}, fmt.Errorf("invalid transaction value %x", t.hash)
+	}
+	return hexutil.Big(*tx.Value()), nil
+}
+
+func (t *Transaction) Nonce(ctx context.Context) (hexutil.Uint64, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(tx.Nonce()), nil
+}
+
+func (t *Transaction) To(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	to := tx.To()
+	if to == nil {
+		return nil, nil
+	}
+	return &Account{
+		r:             t.r,
+		address:       *to,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) From(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	from, err := types.Sender(t.r.signer(), tx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             t.r,
+		address:       from,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) Block(ctx context.Context) (*Block, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	return t.block, nil
+}
+
+// Event returns the DAG event which has included the transaction.
+func (t *Transaction) Event(ctx context.Context) (*Event, error) {
+	if _, err := t.resolve(ctx); err != nil || t.block == nil || t.r.filterBackend == nil {
+		return nil, err
+	}
+	pos := t.r.filterBackend.GetTxPosition(t.hash)
+	if pos == nil || pos.Event == (hash.Event{}) {
+		return nil, nil
+	}
+	return t.r.getEvent(ctx, pos.Event)
+}
+
+func (t *Transaction) Index(ctx context.Context) (*int32, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	index := int32(t.index)
+	return &index, nil
+}
+
+// getReceipt returns the receipt associated with this transaction, if any.
+func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if _, err := t.resolve(ctx); err != nil {
+		return nil, err
+	}
+	if t.block == nil {
+		return nil, nil
+	}
+	receipts, err := t.block.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.index >= uint64(len(receipts)) {
+		return nil, nil
+	}
+	return receipts[t.index], nil
+}
+
+func (t *Transaction) Status(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.Status)
+	return &ret, nil
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.GasUsed)
+	return &ret, nil
+}
+
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*hexutil.Uint64, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := hexutil.Uint64(receipt.CumulativeGasUsed)
+	return &ret, nil
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil || receipt.ContractAddress == (common.Address{}) {
+		return nil, err
+	}
+	return &Account{
+		r:             t.r,
+		address:       receipt.ContractAddress,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		ret = append(ret, &Log{
+			r:           t.r,
+			transaction: t,
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) Type(ctx context.Context) (*int32, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	txType := int32(tx.Type())
+	return &txType, nil
+}
+
+func (t *Transaction) AccessList(ctx context.Context) (*[]*AccessTuple, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	accessList := tx.AccessList()
+	ret := make([]*AccessTuple, 0, len(accessList))
+	for _, al := range accessList {
+		ret = append(ret, &AccessTuple{
+			address:     al.Address,
+			storageKeys: al.StorageKeys,
+		})
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) R(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	_, r, _ := tx.RawSignatureValues()
+	return hexutil.Big(*r), nil
+}
+
+func (t *Transaction) S(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	_, _, s := tx.RawSignatureValues()
+	return hexutil.Big(*s), nil
+}
+
+func (t *Transaction) V(ctx context.Context) (hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Big{}, err
+	}
+	v, _, _ := tx.RawSignatureValues()
+	return hexutil.Big(*v), nil
+}
+
+func (t *Transaction) Raw(ctx context.Context) (hexutil.Bytes, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return hexutil.Bytes{}, err
+	}
+	return tx.MarshalBinary()
+}
+
+func (t *Transaction) RawReceipt(ctx context.Context) (hexutil.Bytes, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil || receipt == nil {
+		return hexutil.Bytes{}, err
+	}
+	var buf bytes.Buffer
+	types.Receipts{receipt}.EncodeIndex(0, &buf)
+	return buf.Bytes(), nil
+}
+
+// Block represents a Sesa block.
+// r and either num or hash are mandatory. All other fields are lazily fetched
+// when required.
+type Block struct {
+	r            *Resolver
+	numberOrHash *rpc.BlockNumberOrHash
+	hash         common.Hash
+	block        *evmcore.EvmBlock
+	receipts     []*types.Receipt
+}
+
+// resolve returns the internal EvmBlock object representing this block, fetching
+// it if necessary.
+func (b *Block) resolve(ctx context.Context) (*evmcore.EvmBlock, error) {
+	if b.block != nil {
+		return b.block, nil
+	}
+	if b.numberOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		b.numberOrHash = &latest
+	}
+	var err error
+	if hash, ok := b.numberOrHash.Hash(); ok {
+		b.block, err = b.r.backend.BlockByHash(ctx, hash)
+	} else if number, ok := b.numberOrHash.Number(); ok {
+		b.block, err = b.r.backend.BlockByNumber(ctx, number)
+	} else {
+		return nil, errBlockInvariant
+	}
+	if b.block != nil && b.hash == (common.Hash{}) {
+		b.hash = b.block.Hash
+	}
+	return b.block, err
+}
+
+// resolveReceipts returns the list of receipts for this block, fetching them
+// if necessary.
+func (b *Block) resolveReceipts(ctx context.Context) ([]*types.Receipt, error) {
+	if b.receipts != nil {
+		return b.receipts, nil
+	}
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	receipts, err := b.r.backend.GetReceiptsByNumber(ctx, rpc.BlockNumber(block.Number.Uint64()))
+	if err != nil {
+		return nil, err
+	}
+	b.receipts = receipts
+	return receipts, nil
+}
+
+// numberOrHashAt returns the block as the state reference of the block-scoped queries.
+func (b *Block) numberOrHashAt(ctx context.Context) (rpc.BlockNumberOrHash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil {
+		return rpc.BlockNumberOrHash{}, err
+	}
+	if block == nil {
+		return rpc.BlockNumberOrHash{}, errors.New("block not found")
+	}
+	return rpc.BlockNumberOrHashWithHash(block.Hash, false), nil
+}
+
+func (b *Block) Number(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Number.Uint64()), nil
+}
+
+func (b *Block) Hash(ctx context.Context) (common.Hash, error) {
+	if b.hash == (common.Hash{}) {
+		if _, err := b.resolve(ctx); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	return b.hash, nil
+}
+
+func (b *Block) GasLimit(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasLimit), nil
+}
+
+func (b *Block) GasUsed(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.GasUsed), nil
+}
+
+func (b *Block) BaseFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if block.BaseFee == nil {
+		return nil, nil
+	}
+	return (*hexutil.Big)(block.BaseFee), nil
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil || block.Number.Sign() == 0 {
+		return nil, err
+	}
+	parent := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.Number.Int64() - 1))
+	return &Block{
+		r:            b.r,
+		numberOrHash: &parent,
+		hash:         block.ParentHash,
+	}, nil
+}
+
+func (b *Block) Timestamp(ctx context.Context) (hexutil.Uint64, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return 0, err
+	}
+	return hexutil.Uint64(block.Time.Unix()), nil
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.TxHash, nil
+}
+
+func (b *Block) StateRoot(ctx context.Context) (common.Hash, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return common.Hash{}, err
+	}
+	return block.Root, nil
+}
+
+func (b *Block) ReceiptsRoot(ctx context.Context) (common.Hash, error) {
+	receipts, err := b.resolveReceipts(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(receipts) == 0 {
+		return types.EmptyRootHash, nil
+	}
+	return types.DeriveSha(types.Receipts(receipts), trie.NewStackTrie(nil)), nil
+}
+
+func (b *Block) LogsBloom(ctx context.Context) (hexutil.Bytes, error) {
+	receipts, err := b.resolveReceipts(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return types.CreateBloom(receipts).Bytes(), nil
+}
+
+func (b *Block) Miner(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	block, err := b.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	return &Account{
+		r:             b.r,
+		address:       block.Coinbase,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (b *Block) TransactionCount(ctx context.Context) (*int32, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	count := int32(len(block.Transactions))
+	return &count, err
+}
+
+func (b *Block) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		ret = append(ret, &Transaction{
+			r:     b.r,
+			hash:  tx.Hash(),
+			tx:    tx,
+			block: b,
+			index: uint64(i),
+		})
+	}
+	return &ret, nil
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) (*Transaction, error) {
+	block, err := b.resolve(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	if args.Index < 0 || int(args.Index) >= len(block.Transactions) {
+		return nil, nil
+	}
+	tx := block.Transactions[args.Index]
+	return &Transaction{
+		r:     b.r,
+		hash:  tx.Hash(),
+		tx:    tx,
+		block: b,
+		index: uint64(args.Index),
+	}, nil
+}
+
+// BlockFilterCriteria encapsulates criteria passed to a `logs` accessor inside
+// a block.
+type BlockFilterCriteria struct {
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics. Each event has a list
+	// of topics. Topics matches a prefix of that list. An empty element slice matches any
+	// topic. Non-empty elements represent an alternative that matches any of the
+	// contained topics.
+	//
+	// Examples:
+	// {} or nil          matches any topic list
+	// {{A}}              matches topic A in first position
+	// {{}, {B}}          matches any topic in first position, B in second position
+	// {{A}, {B}}         matches topic A in first position, B in second position
+	// {{A, C}, {B, D}}   matches topic (A OR C) in first position, (B OR D) in second position
+	Topics *[][]common.Hash
+}
+
+// runFilter accepts a filter and executes it, returning all its results as
+// `Log` objects.
+func runFilter(ctx context.Context, r *Resolver, filter *filters.Filter) ([]*Log, error) {
+	logs, err := filter.Logs(ctx)
+	if err != nil || logs == nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{
+			r:           r,
+			transaction: &Transaction{r: r, hash: log.TxHash},
+			log:         log,
+		})
+	}
+	return ret, nil
+}
+
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	if b.r.filterBackend == nil {
+		return nil, errors.New("logs search is not supported")
+	}
+	var addresses []common.Address
+	if args.Filter.Addresses != nil {
+		addresses = *args.Filter.Addresses
+	}
+	var topics [][]common.Hash
+	if args.Filter.Topics != nil {
+		topics = *args.Filter.Topics
+	}
+	hash, err := b.Hash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Construct the block filter and run it
+	filter := filters.NewBlockFilter(b.r.filterBackend, b.r.filterConfig, hash, addresses, topics)
+	return runFilter(ctx, b.r, filter)
+}
+
+func (b *Block) Account(ctx context.Context, args struct {
+	Address common.Address
+}) (*Account, error) {
+	blockNrOrHash, err := b.numberOrHashAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		r:             b.r,
+		address:       args.Address,
+		blockNrOrHash: blockNrOrHash,
+	}, nil
+}
+
+// CallData encapsulates arguments to `call` or `estimateGas`.
+// All arguments are optional.
+type CallData struct {
+	From                 *common.Address // The Ethereum address the call is from.
+	To                   *common.Address // The Ethereum address the call is to.
+	Gas                  *hexutil.Uint64 // The amount of gas provided for the call.
+	GasPrice             *hexutil.Big    // The price of each unit of gas, in wei.
+	MaxFeePerGas         *hexutil.Big    // The max price of each unit of gas, in wei (1559).
+	MaxPriorityFeePerGas *hexutil.Big    // The max tip of each unit of gas, in wei (1559).
+	Value                *hexutil.Big    // The value sent along with the call.
+	Data                 *hexutil.Bytes  // Any data sent with the call.
+}
+
+// CallResult encapsulates the result of an invocation of the `call` accessor.
+type CallResult struct {
+	data    hexutil.Bytes  // The return data from the call
+	gasUsed hexutil.Uint64 // The amount of gas used
+	status  hexutil.Uint64 // The return status of the call - 0 for failure or 1 for success.
+}
+
+func (c *CallResult) Data() hexutil.Bytes {
+	return c.data
+}
+
+func (c *CallResult) GasUsed() hexutil.Uint64 {
+	return c.gasUsed
+}
+
+func (c *CallResult) Status() hexutil.Uint64 {
+	return c.status
+}
+
+func (c CallData) toTransactionArgs() ethapi.TransactionArgs {
+	return ethapi.TransactionArgs{
+		From:                 c.From,
+		To:                   c.To,
+		Gas:                  c.Gas,
+		GasPrice:             c.GasPrice,
+		MaxFeePerGas:         c.MaxFeePerGas,
+		MaxPriorityFeePerGas: c.MaxPriorityFeePerGas,
+		Value:                c.Value,
+		Data:                 c.Data,
+	}
+}
+
+func (r *Resolver) call(ctx context.Context, data CallData, blockNrOrHash rpc.BlockNumberOrHash) (*CallResult, error) {
+	result, err := ethapi.DoCall(ctx, r.backend, data.toTransactionArgs(), blockNrOrHash, nil, r.backend.RPCTimeout(), r.backend.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	status := hexutil.Uint64(1)
+	if result.Failed() {
+		status = 0
+	}
+	return &CallResult{
+		data:    result.ReturnData,
+		gasUsed: hexutil.Uint64(result.UsedGas),
+		status:  status,
+	}, nil
+}
+
+func (b *Block) Call(ctx context.Context, args struct {
+	Data CallData
+}) (*CallResult, error) {
+	blockNrOrHash, err := b.numberOrHashAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.r.call(ctx, args.Data, blockNrOrHash)
+}
+
+func (b *Block) EstimateGas(ctx context.Context, args struct {
+	Data CallData
+}) (hexutil.Uint64, error) {
+	blockNrOrHash, err := b.numberOrHashAt(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return ethapi.DoEstimateGas(ctx, b.r.backend, args.Data.toTransactionArgs(), blockNrOrHash, b.r.backend.RPCGasCap())
+}
+
+// Atropos returns the DAG event which has decided the block.
+func (b *Block) Atropos(ctx context.Context) (*Event, error) {
+	hash, err := b.Hash(ctx)
+	if err != nil || hash == (common.Hash{}) {
+		return nil, err
+	}
+	return b.r.getEvent(ctx, hash)
+}
+
+// Epoch returns the epoch of the block Atropos event.
+func (b *Block) Epoch(ctx context.Context) (*Epoch, error) {
+	atropos, err := b.Atropos(ctx)
+	if err != nil || atropos == nil {
+		return nil, err
+	}
+	return b.r.getEpoch(ctx, rpc.BlockNumber(atropos.event.Epoch()))
+}
+
+// Pending represents the current pending state.
+type Pending struct {
+	r *Resolver
+}
+
+func (p *Pending) TransactionCount(ctx context.Context) (int32, error) {
+	txs, err := p.r.backend.GetPoolTransactions()
+	return int32(len(txs)), err
+}
+
+func (p *Pending) Transactions(ctx context.Context) (*[]*Transaction, error) {
+	txs, err := p.r.backend.GetPoolTransactions()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Transaction, 0, len(txs))
+	for _, tx := range txs {
+		ret = append(ret, &Transaction{
+			r:    p.r,
+			hash: tx.Hash(),
+			tx:   tx,
+		})
+	}
+	return &ret, nil
+}
+
+func (p *Pending) Account(ctx context.Context, args struct {
+	Address common.Address
+}) *Account {
+	return &Account{
+		r:             p.r,
+		address:       args.Address,
+		blockNrOrHash: rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber),
+	}
+}
+
+func (p *Pending) Call(ctx context.Context, args struct {
+	Data CallData
+}) (*CallResult, error) {
+	return p.r.call(ctx, args.Data, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+}
+
+func (p *Pending) EstimateGas(ctx context.Context, args struct {
+	Data CallData
+}) (hexutil.Uint64, error) {
+	pendingBlockNr := rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber)
+	return ethapi.DoEstimateGas(ctx, p.r.backend, args.Data.toTransactionArgs(), pendingBlockNr, p.r.backend.RPCGasCap())
+}
+
+// GasPowerLeft represents the gas power of a validator left after an event.
+type GasPowerLeft struct {
+	gas native.GasPowerLeft
+}
+
+func (g *GasPowerLeft) ShortTerm() hexutil.Uint64 {
+	return hexutil.Uint64(g.gas.Gas[native.ShortTermGas])
+}
+
+func (g *GasPowerLeft) LongTerm() hexutil.Uint64 {
+	return hexutil.Uint64(g.gas.Gas[native.LongTermGas])
+}
+
+// Event represents a Hashgraph DAG event.
+type Event struct {
+	r     *Resolver
+	event *native.Event
+}
+
+func (e *Event) ID() common.Hash {
+	return common.Hash(e.event.ID())
+}
+
+func (e *Event) Version() int32 {
+	return int32(e.event.Version())
+}
+
+func (e *Event) Epoch() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.Epoch())
+}
+
+func (e *Event) Seq() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.Seq())
+}
+
+func (e *Event) Frame() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.Frame())
+}
+
+func (e *Event) Lamport() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.Lamport())
+}
+
+func (e *Event) Creator() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.Creator())
+}
+
+func (e *Event) CreationTime() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.CreationTime())
+}
+
+func (e *Event) MedianTime() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.MedianTime())
+}
+
+func (e *Event) PayloadHash() common.Hash {
+	return common.Hash(e.event.PayloadHash())
+}
+
+func (e *Event) ExtraData() hexutil.Bytes {
+	return e.event.Extra()
+}
+
+func (e *Event) GasPowerUsed() hexutil.Uint64 {
+	return hexutil.Uint64(e.event.GasPowerUsed())
+}
+
+func (e *Event) GasPowerLeft() *GasPowerLeft {
+	return &GasPowerLeft{e.event.GasPowerLeft()}
+}
+
+func (e *Event) ParentIDs() []common.Hash {
+	parents := e.event.Parents()
+	ret := make([]common.Hash, 0, len(parents))
+	for _, id := range parents {
+		ret = append(ret, common.Hash(id))
+	}
+	return ret
+}
+
+// Parents returns the parent events of the event, following the DAG links.
+func (e *Event) Parents(ctx context.Context) ([]*Event, error) {
+	parents := e.event.Parents()
+	ret := make([]*Event, 0, len(parents))
+	for _, id := range parents {
+		parent, err := e.r.getEvent(ctx, common.Hash(id))
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			ret = append(ret, parent)
+		}
+	}
+	return ret, nil
+}
+
+func (e *Event) Transactions(ctx context.Context) ([]*Transaction, error) {
+	if !e.event.AnyTxs() {
+		return []*Transaction{}, nil
+	}
+	payload, err := e.r.backend.GetEventPayload(ctx, e.ID().Hex())
+	if err != nil || payload == nil {
+		return nil, err
+	}
+	txs := payload.Txs()
+	ret := make([]*Transaction, 0, len(txs))
+	for _, tx := range txs {
+		// the block position is resolved lazily, if the transaction is confirmed
+		ret = append(ret, &Transaction{
+			r:    e.r,
+			hash: tx.Hash(),
+		})
+	}
+	return ret, nil
+}
+
+// Rules represents the network rules of an epoch.
+type Rules struct {
+	rules *iblockproc.EpochState
+}
+
+func (r *Rules) Name() string {
+	return r.rules.Rules.Name
+}
+
+func (r *Rules) NetworkID() hexutil.Uint64 {
+	return hexutil.Uint64(r.rules.Rules.NetworkID)
+}
+
+func (r *Rules) MinGasPrice() hexutil.Big {
+	if r.rules.Rules.Economy.MinGasPrice == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*r.rules.Rules.Economy.MinGasPrice)
+}
+
+func (r *Rules) MaxEpochGas() hexutil.Uint64 {
+	return hexutil.Uint64(r.rules.Rules.Epochs.MaxEpochGas)
+}
+
+func (r *Rules) MaxEpochDuration() hexutil.Uint64 {
+	return hexutil.Uint64(r.rules.Rules.Epochs.MaxEpochDuration)
+}
+
+func (r *Rules) MaxBlockGas() hexutil.Uint64 {
+	return hexutil.Uint64(r.rules.Rules.Blocks.MaxBlockGas)
+}
+
+func (r *Rules) MaxParents() hexutil.Uint64 {
+	return hexutil.Uint64(r.rules.Rules.Dag.MaxParents)
+}
+
+func (r *Rules) Raw() string {
+	return r.rules.Rules.String()
+}
+
+// Validator represents a validator of an epoch.
+type Validator struct {
+	epoch *Epoch
+	id    idx.ValidatorID
+}
+
+func (v *Validator) ID() hexutil.Uint64 {
+	return hexutil.Uint64(v.id)
+}
+
+func (v *Validator) Weight() hexutil.Big {
+	profile, ok := v.epoch.es.ValidatorProfiles[v.id]
+	if !ok || profile.Weight == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*profile.Weight)
+}
+
+func (v *Validator) PubKey() hexutil.Bytes {
+	return v.epoch.es.ValidatorProfiles[v.id].PubKey.Bytes()
+}
+
+// state returns the block state of the validator, if it's known.
+func (v *Validator) state() *iblockproc.ValidatorBlockState {
+	i := int(v.epoch.es.Validators.GetIdx(v.id))
+	if i >= len(v.epoch.bs.ValidatorStates) {
+		return nil
+	}
+	return &v.epoch.bs.ValidatorStates[i]
+}
+
+func (v *Validator) LastEvent(ctx context.Context) (*Event, error) {
+	st := v.state()
+	if st == nil || st.LastEvent.ID == (hash.Event{}) {
+		return nil, nil
+	}
+	return v.epoch.r.getEvent(ctx, common.Hash(st.LastEvent.ID))
+}
+
+func (v *Validator) Uptime() hexutil.Uint64 {
+	st := v.state()
+	if st == nil {
+		return 0
+	}
+	return hexutil.Uint64(st.Uptime)
+}
+
+func (v *Validator) OriginatedFee() hexutil.Big {
+	st := v.state()
+	if st == nil || st.Originated == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*st.Originated)
+}
+
+// Epoch represents a Hashgraph epoch.
+type Epoch struct {
+	r  *Resolver
+	bs *iblockproc.BlockState
+	es *iblockproc.EpochState
+}
+
+func (e *Epoch) Number() hexutil.Uint64 {
+	return hexutil.Uint64(e.es.Epoch)
+}
+
+func (e *Epoch) Start() hexutil.Uint64 {
+	return hexutil.Uint64(e.es.EpochStart)
+}
+
+// End returns the start time of the next epoch, if the epoch is sealed.
+func (e *Epoch) End(ctx context.Context) (*hexutil.Uint64, error) {
+	if e.es.Epoch >= e.r.backend.CurrentEpoch(ctx) {
+		return nil, nil
+	}
+	_, next, err := e.r.backend.GetEpochBlockState(ctx, rpc.BlockNumber(e.es.Epoch+1))
+	if err != nil || next == nil {
+		return nil, err
+	}
+	end := hexutil.Uint64(next.EpochStart)
+	return &end, nil
+}
+
+func (e *Epoch) StateRoot() common.Hash {
+	return common.Hash(e.es.EpochStateRoot)
+}
+
+func (e *Epoch) TotalWeight() hexutil.Big {
+	total := new(big.Int)
+	for _, profile := range e.es.ValidatorProfiles {
+		if profile.Weight != nil {
+			total.Add(total, profile.Weight)
+		}
+	}
+	return hexutil.Big(*total)
+}
+
+func (e *Epoch) Validators() []*Validator {
+	ids := e.es.Validators.SortedIDs()
+	ret := make([]*Validator, 0, len(ids))
+	for _, id := range ids {
+		ret = append(ret, &Validator{
+			epoch: e,
+			id:    id,
+		})
+	}
+	return ret
+}
+
+func (e *Epoch) Rules() *Rules {
+	return &Rules{e.es}
+}
+
+// Heads returns the events with no descendants, only the current epoch heads are available.
+func (e *Epoch) Heads(ctx context.Context) (*[]*Event, error) {
+	if e.es.Epoch != e.r.backend.CurrentEpoch(ctx) {
+		return nil, nil
+	}
+	heads, err := e.r.backend.GetHeads(ctx, rpc.BlockNumber(e.es.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Event, 0, len(heads))
+	for _, id := range heads {
+		head, err := e.r.getEvent(ctx, common.Hash(id))
+		if err != nil {
+			return nil, err
+		}
+		if head != nil {
+			ret = append(ret, head)
+		}
+	}
+	return &ret, nil
+}
+
+// SyncState represents the synchronisation status returned from the `syncing` accessor.
+type SyncState struct {
+	progress ethapi.PeerProgress
+}
+
+func (s *SyncState) CurrentBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.progress.CurrentBlock)
+}
+
+func (s *SyncState) HighestBlock() hexutil.Uint64 {
+	return hexutil.Uint64(s.progress.HighestBlock)
+}
+
+func (s *SyncState) CurrentEpoch() hexutil.Uint64 {
+	return hexutil.Uint64(s.progress.CurrentEpoch)
+}
+
+func (s *SyncState) HighestEpoch() hexutil.Uint64 {
+	return hexutil.Uint64(s.progress.HighestEpoch)
+}
+
+// BlockNumberArgs encapsulates arguments to accessors that specify a block number.
+type BlockNumberArgs struct {
+	// TODO: Ideally we could use input unions to allow the query to specify the
+	// block parameter by hash, block number, or tag but input unions aren't part of the
+	// standard GraphQL schema SDL yet, see: https://github.com/graphql/graphql-spec/issues/488
+	Block *hexutil.Uint64
+}
+
+// NumberOr returns the provided block number argument, or the "current" block number or hash if none
+// was provided.
+func (a BlockNumberArgs) NumberOr(current rpc.BlockNumberOrHash) rpc.BlockNumberOrHash {
+	if a.Block != nil {
+		blockNr := rpc.BlockNumber(*a.Block)
+		return rpc.BlockNumberOrHashWithNumber(blockNr)
+	}
+	return current
+}
+
+// NumberOrLatest returns the provided block number argument, or the "latest" block number if none
+// was provided.
+func (a BlockNumberArgs) NumberOrLatest() rpc.BlockNumberOrHash {
+	return a.NumberOr(rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+}
+
+// Resolver is the root resolver of the GraphQL schema.
+type Resolver struct {
+	backend       ethapi.Backend
+	filterBackend filters.Backend
+	filterConfig  filters.Config
+}
+
+func (r *Resolver) signer() types.Signer {
+	return types.LatestSignerForChainID(r.backend.ChainConfig().ChainID)
+}
+
+// getEvent returns the DAG event by its ID, or nil if the event is unknown.
+func (r *Resolver) getEvent(ctx context.Context, id common.Hash) (*Event, error) {
+	event, err := r.backend.GetEvent(ctx, id.Hex())
+	if err != nil || event == nil {
+		return nil, err
+	}
+	return &Event{r: r, event: event}, nil
+}
+
+// getEpoch returns the epoch by its number, or nil if the epoch is unknown.
+func (r *Resolver) getEpoch(ctx context.Context, number rpc.BlockNumber) (*Epoch, error) {
+	bs, es, err := r.backend.GetEpochBlockState(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if bs == nil || es == nil {
+		return nil, nil
+	}
+	return &Epoch{r: r, bs: bs, es: es}, nil
+}
+
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *hexutil.Uint64
+	Hash   *common.Hash
+}) (*Block, error) {
+	var block *Block
+	if args.Number != nil {
+		number := rpc.BlockNumber(uint64(*args.Number))
+		numberOrHash := rpc.BlockNumberOrHashWithNumber(number)
+		block = &Block{
+			r:            r,
+			numberOrHash: &numberOrHash,
+		}
+	} else if args.Hash != nil {
+		numberOrHash := rpc.BlockNumberOrHashWithHash(*args.Hash, false)
+		block = &Block{
+			r:            r,
+			numberOrHash: &numberOrHash,
+		}
+	} else {
+		numberOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		block = &Block{
+			r:            r,
+			numberOrHash: &numberOrHash,
+		}
+	}
+	// Resolve the block, return nil if unsuccessful.
+	b, err := block.resolve(ctx)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*Block, error) {
+	var from rpc.BlockNumber
+	if args.From != nil {
+		from = rpc.BlockNumber(*args.From)
+	}
+	var to rpc.BlockNumber
+	if args.To != nil {
+		to = rpc.BlockNumber(*args.To)
+	} else {
+		to = rpc.BlockNumber(r.backend.CurrentBlock().Number.Int64())
+	}
+	if to < from {
+		return []*Block{}, nil
+	}
+	ret := []*Block{}
+	for i := from; i <= to; i++ {
+		numberOrHash := rpc.BlockNumberOrHashWithNumber(i)
+		block := &Block{
+			r:            r,
+			numberOrHash: &numberOrHash,
+		}
+		// Resolve the block, stop at the first unknown one.
+		b, err := block.resolve(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			break
+		}
+		ret = append(ret, block)
+	}
+	return ret, nil
+}
+
+func (r *Resolver) Pending(ctx context.Context) *Pending {
+	return &Pending{r}
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx := &Transaction{
+		r:    r,
+		hash: args.Hash,
+	}
+	// Resolve the transaction; if it doesn't exist, return nil.
+	t, err := tx.resolve(ctx)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (r *Resolver) SendRawTransaction(ctx context.Context, args struct{ Data hexutil.Bytes }) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(args.Data); err != nil {
+		return common.Hash{}, err
+	}
+	hash, err := ethapi.SubmitTransaction(ctx, r.backend, tx)
+	return hash, err
+}
+
+// FilterCriteria encapsulates the arguments to `logs` on the root resolver object.
+type FilterCriteria struct {
+	FromBlock *hexutil.Uint64   // beginning of the queried range, nil means genesis block
+	ToBlock   *hexutil.Uint64   // end of the range, nil means latest block
+	Addresses *[]common.Address // restricts matches to events created by specific contracts
+
+	// The Topic list restricts matches to particular event topics. Each event has a list
+	// of topics. Topics matches a prefix of that list. An empty element slice matches any
+	// topic. Non-empty elements represent an alternative that matches any of the
+	// contained topics.
+	Topics *[][]common.Hash
+}
+
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	if r.filterBackend == nil {
+		return nil, errors.New("logs search is not supported")
+	}
+	// Convert the RPC block numbers into internal representations
+	begin := rpc.LatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	var addresses []common.Address
+	if args.Filter.Addresses != nil {
+		addresses = *args.Filter.Addresses
+	}
+	var topics [][]common.Hash
+	if args.Filter.Topics != nil {
+		topics = *args.Filter.Topics
+	}
+	// Construct the range filter
+	filter := filters.NewRangeFilter(r.filterBackend, r.filterConfig, begin, end, addresses, topics)
+	return runFilter(ctx, r, filter)
+}
+
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	tipcap := r.backend.SuggestGasTipCap(ctx, gasprice.AsDefaultCertainty)
+	tipcap.Add(tipcap, r.backend.MinGasPrice())
+	return (hexutil.Big)(*tipcap), nil
+}
+
+func (r *Resolver) MaxPriorityFeePerGas(ctx context.Context) (hexutil.Big, error) {
+	tipcap := r.backend.SuggestGasTipCap(ctx, gasprice.AsDefaultCertainty)
+	return (hexutil.Big)(*tipcap), nil
+}
+
+func (r *Resolver) ChainID(ctx context.Context) (hexutil.Big, error) {
+	return hexutil.Big(*r.backend.ChainConfig().ChainID), nil
+}
+
+// Syncing returns false in case the node is in sync with the network. If it is syncing, it will return the syncing status.
+func (r *Resolver) Syncing(ctx context.Context) (*SyncState, error) {
+	progress := r.backend.Progress()
+
+	// Return not syncing if the synchronisation already completed
+	if progress.CurrentEpoch >= progress.HighestEpoch && progress.CurrentBlock >= progress.HighestBlock {
+		return nil, nil
+	}
+	// Otherwise gather the block sync stats
+	return &SyncState{progress}, nil
+}
+
+// Event returns the DAG event by its full hash or short ID.
+func (r *Resolver) Event(ctx context.Context, args struct{ ID string }) (*Event, error) {
+	event, err := r.backend.GetEvent(ctx, args.ID)
+	if err != nil || event == nil {
+		return nil, err
+	}
+	return &Event{r: r, event: event}, nil
+}
+
+// Epoch returns the epoch by its number, or the current epoch if the number isn't specified.
+func (r *Resolver) Epoch(ctx context.Context, args struct{ Number *hexutil.Uint64 }) (*Epoch, error) {
+	number := rpc.LatestBlockNumber
+	if args.Number != nil {
+		if uint64(*args.Number) > uint64(r.backend.CurrentEpoch(ctx)) {
+			return nil, nil
+		}
+		number = rpc.BlockNumber(*args.Number)
+	}
+	return r.getEpoch(ctx, number)
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/native/pos"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+	"github.com/sesanetwork/go-sesa/ethapi"
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/drivertype"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/node"
+	"github.com/sesanetwork/go-sesa/rpc"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+// testBackend serves a single block, the current epoch and the sealed one,
+// the rest of the ethapi.Backend methods aren't expected to be called.
+type testBackend struct {
+	ethapi.Backend
+
+	block  *evmcore.EvmBlock
+	bs     *iblockproc.BlockState
+	es     *iblockproc.EpochState
+	sealed *iblockproc.EpochState
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*evmcore.EvmBlock, error) {
+	if number == rpc.LatestBlockNumber || number == rpc.BlockNumber(b.block.Number.Int64()) {
+		return b.block, nil
+	}
+	return nil, nil
+}
+
+func (b *testBackend) BlockByHash(ctx context.Context, h common.Hash) (*evmcore.EvmBlock, error) {
+	if h == b.block.Hash {
+		return b.block, nil
+	}
+	return nil, nil
+}
+
+func (b *testBackend) GetReceiptsByNumber(ctx context.Context, number rpc.BlockNumber) (types.Receipts, error) {
+	return types.Receipts{}, nil
+}
+
+func (b *testBackend) GetEvent(ctx context.Context, shortEventID string) (*native.Event, error) {
+	return nil, nil
+}
+
+func (b *testBackend) GetHeads(ctx context.Context, epoch rpc.BlockNumber) (hash.Events, error) {
+	return hash.Events{}, nil
+}
+
+func (b *testBackend) CurrentEpoch(ctx context.Context) idx.Epoch {
+	return b.es.Epoch
+}
+
+func (b *testBackend) GetEpochBlockState(ctx context.Context, epoch rpc.BlockNumber) (*iblockproc.BlockState, *iblockproc.EpochState, error) {
+	if epoch == rpc.LatestBlockNumber || epoch == rpc.BlockNumber(b.es.Epoch) {
+		return b.bs, b.es, nil
+	}
+	if epoch == rpc.BlockNumber(b.sealed.Epoch) {
+		return b.bs, b.sealed, nil
+	}
+	return nil, nil, nil
+}
+
+func (b *testBackend) RPCTimeout() time.Duration {
+	return time.Second
+}
+
+func newTestBackend() *testBackend {
+	builder := pos.NewBuilder()
+	builder.Set(idx.ValidatorID(5), 10)
+	rules := sesa.FakeNetRules()
+	rules.Name = "test"
+
+	return &testBackend{
+		block: evmcore.NewEvmBlock(&evmcore.EvmHeader{
+			Number:  big.NewInt(10),
+			Hash:    common.HexToHash("0x0a"),
+			Time:    native.FromUnix(100),
+			GasUsed: 21000,
+		}, nil),
+		bs: &iblockproc.BlockState{
+			ValidatorStates: []iblockproc.ValidatorBlockState{{
+				Uptime:     7,
+				Originated: big.NewInt(3),
+			}},
+		},
+		es: &iblockproc.EpochState{
+			Epoch:      3,
+			EpochStart: 7,
+			Validators: builder.Build(),
+			ValidatorProfiles: iblockproc.ValidatorProfiles{
+				5: drivertype.Validator{
+					Weight: big.NewInt(10),
+					PubKey: validatorpk.PubKey{Type: validatorpk.Types.Secp256k1, Raw: []byte{1, 2, 3}},
+				},
+			},
+			Rules: rules,
+		},
+		sealed: &iblockproc.EpochState{
+			Epoch:      2,
+			EpochStart: 4,
+			Validators: builder.Build(),
+			Rules:      rules,
+		},
+	}
+}
+
+func newTestService(t *testing.T, backend ethapi.Backend) *node.Node {
+	stack, err := node.New(&node.Config{
+		DataDir:      t.TempDir(),
+		HTTPHost:     "127.0.0.1",
+		HTTPPort:     0,
+		HTTPTimeouts: node.DefaultConfig.HTTPTimeouts,
+	})
+	require.NoError(t, err)
+	require.NoError(t, newHandler(stack, backend, nil, filters.DefaultConfig(), []string{}, []string{}))
+	require.NoError(t, stack.Start())
+	t.Cleanup(func() { stack.Close() })
+	return stack
+}
+
+func TestBuildSchema(t *testing.T) {
+	stack, err := node.New(&node.Config{DataDir: t.TempDir()})
+	require.NoError(t, err)
+	defer stack.Close()
+
+	// Make sure the schema can be parsed and matched up to the object model.
+	require.NoError(t, newHandler(stack, newTestBackend(), nil, filters.DefaultConfig(), []string{}, []string{}))
+}
+
+func TestGraphQLQueries(t *testing.T) {
+	stack := newTestService(t, newTestBackend())
+
+	for i, tt := range []struct {
+		body string
+		want string
+		code int
+	}{
+		{
+			body: `{"query": "{block{number hash timestamp gasUsed transactionCount}}"}`,
+			want: `{"data":{"block":{"number":"0xa","hash":"0x000000000000000000000000000000000000000000000000000000000000000a","timestamp":"0x64","gasUsed":"0x5208","transactionCount":0}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{block(number:11){number}}"}`,
+			want: `{"data":{"block":null}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{epoch{number start end totalWeight validators{id weight uptime originatedFee} rules{name}}}"}`,
+			want: `{"data":{"epoch":{"number":"0x3","start":"0x7","end":null,"totalWeight":"0xa","validators":[{"id":"0x5","weight":"0xa","uptime":"0x7","originatedFee":"0x3"}],"rules":{"name":"test"}}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{epoch(number:2){number start end}}"}`,
+			want: `{"data":{"epoch":{"number":"0x2","start":"0x4","end":"0x7"}}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{epoch(number:4){number}}"}`,
+			want: `{"data":{"epoch":null}}`,
+			code: 200,
+		},
+		{
+			body: `{"query": "{bleh{number}}"}`,
+			want: `{"errors":[{"message":"Cannot query field \"bleh\" on type \"Query\".","locations":[{"line":1,"column":2}]}]}`,
+			code: 400,
+		},
+	} {
+		resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", strings.NewReader(tt.body))
+		require.NoError(t, err, "test %d", i)
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err, "test %d", i)
+		require.Equal(t, tt.code, resp.StatusCode, "test %d", i)
+		require.Equal(t, tt.want, string(bodyBytes), "test %d", i)
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+const schema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Ethereum address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'. Byte strings must have an even number of hexadecimal nybbles.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer.
+    scalar Long
+
+    schema {
+        query: Query
+        mutation: Mutation
+    }
+
+    # Account is an Ethereum account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in wei.
+        balance: BigInt!
+        # TransactionCount is the number of transactions sent from this account,
+        # or in the case of a contract, the number of contracts created. Otherwise
+        # known as the nonce.
+        transactionCount: Long!
+        # Code contains the smart contract code for this account, if the account
+        # is a (non-self-destructed) contract.
+        code: Bytes!
+        # Storage provides access to the storage of a contract account, indexed
+        # by its 32 byte slot identifier.
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is an Ethereum event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Int!
+        # Account is the account which generated this log - this will always
+        # be a contract account.
+        account(block: Long): Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+        # Transaction is the transaction that generated this log entry.
+        transaction: Transaction!
+    }
+
+    # EIP-2718
+    type AccessTuple {
+        address: Address!
+        storageKeys: [Bytes32!]!
+    }
+
+    # Transaction is an Ethereum transaction.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction in the parent block. This will
+        # be null if the transaction has not yet been mined.
+        index: Int
+        # From is the account that sent this transaction - this will always be
+        # an externally owned account.
+        from(block: Long): Account!
+        # To is the account the transaction was sent to. This is null for
+        # contract-creating transactions.
+        to(block: Long): Account
+        # Value is the value, in wei, sent along with this transaction.
+        value: BigInt!
+        # GasPrice is the price offered to miners for gas, in wei per unit.
+        gasPrice: BigInt!
+        # MaxFeePerGas is the maximum fee per gas offered to include a transaction, in wei.
+        maxFeePerGas: BigInt
+        # MaxPriorityFeePerGas is the maximum miner tip per gas offered to include a transaction, in wei.
+        maxPriorityFeePerGas: BigInt
+        # EffectiveTip is the actual amount of reward going to the validators after paying the base fee.
+        effectiveTip: BigInt
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the block this transaction was included in. This will be null
+        # if the transaction has not yet been included into a block.
+        block: Block
+        # Event is the DAG event which has included this transaction. This will be
+        # null if the transaction has not yet been included into a block.
+        event: Event
+        # Status is the return status of the transaction. This will be 1 if the
+        # transaction succeeded, or 0 if it failed (due to a revert, or due to
+        # running out of gas). If the transaction has not yet been included into
+        # a block, this field will be null.
+        status: Long
+        # GasUsed is the amount of gas that was used processing this transaction.
+        # If the transaction has not yet been included into a block, this field will be null.
+        gasUsed: Long
+        # CumulativeGasUsed is the total gas used in the block up to and including
+        # this transaction. If the transaction has not yet been included into a block,
+        # this field will be null.
+        cumulativeGasUsed: Long
+        # EffectiveGasPrice is actual value per gas deducted from the sender's
+        # account. If the transaction has not yet been included into a block,
+        # this field will be null.
+        effectiveGasPrice: BigInt
+        # CreatedContract is the account that was created by a contract creation
+        # transaction. If the transaction was not a contract creation transaction,
+        # or it has not yet been included into a block, this will be null.
+        createdContract(block: Long): Account
+        # Logs is a list of log entries emitted by this transaction. If the
+        # transaction has not yet been included into a block, this will be null.
+        logs: [Log!]
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+        # Envelope transaction support
+        type: Int
+        accessList: [AccessTuple!]
+        # Raw is the canonical encoding of the transaction.
+        raw: Bytes!
+        # RawReceipt is the canonical encoding of the receipt. For post EIP-2718 typed transactions
+        # this is equivalent to TxType || ReceiptEncoding.
+        rawReceipt: Bytes!
+    }
+
+    # BlockFilterCriteria encapsulates log filter criteria for a filter applied
+    # to a single block.
+    input BlockFilterCriteria {
+        # Addresses is list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        #
+        # Examples:
+        #  - [] or nil          matches any topic list
+        #  - [[A]]              matches topic A in first position
+        #  - [[], [B]]          matches any topic in first position, B in second position
+        #  - [[A], [B]]         matches topic A in first position, B in second position
+        #  - [[A, C], [B, D]]   matches topic (A OR C) in first position, (B OR D) in second position
+        topics: [[Bytes32!]!]
+    }
+
+    # Block is a Sesa block. Blocks are produced by the Atropos events of the DAG,
+    # the hash of the block is the ID of its Atropos event.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # TransactionsRoot is the keccak256 hash of the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # TransactionCount is the number of transactions in this block. if
+        # transactions are not available for this block, this field will be null.
+        transactionCount: Int
+        # StateRoot is the keccak256 hash of the state trie after this block was processed.
+        stateRoot: Bytes32!
+        # ReceiptsRoot is the keccak256 hash of the trie of transaction receipts in this block.
+        receiptsRoot: Bytes32!
+        # Miner is the account that mined this block.
+        miner(block: Long): Account!
+        # GasLimit is the maximum amount of gas that was available to transactions in this block.
+        gasLimit: Long!
+        # GasUsed is the amount of gas that was used executing transactions in this block.
+        gasUsed: Long!
+        # BaseFeePerGas is the fee per unit of gas burned by the protocol in this block.
+        baseFeePerGas: BigInt
+        # Timestamp is the unix timestamp at which this block was mined.
+        timestamp: Long!
+        # LogsBloom is a bloom filter that can be used to check if a block may
+        # contain log entries matching a filter.
+        logsBloom: Bytes!
+        # Transactions is a list of transactions associated with this block. If
+        # transactions are unavailable for this block, this field will be null.
+        transactions: [Transaction!]
+        # TransactionAt returns the transaction at the specified index. If
+        # transactions are unavailable for this block, or if the index is out of
+        # bounds, this field will be null.
+        transactionAt(index: Int!): Transaction
+        # Logs returns a filtered set of logs from this block.
+        logs(filter: BlockFilterCriteria!): [Log!]!
+        # Account fetches an Ethereum account at the current block's state.
+        account(address: Address!): Account!
+        # Call executes a local call operation at the current block's state.
+        call(data: CallData!): CallResult
+        # EstimateGas estimates the amount of gas that will be required for
+        # successful execution of a transaction at the current block's state.
+        estimateGas(data: CallData!): Long!
+        # Atropos is the DAG event which has decided this block.
+        atropos: Event
+        # Epoch is the epoch the block belongs to.
+        epoch: Epoch
+    }
+
+    # CallData represents the data associated with a local contract call.
+    # All fields are optional.
+    input CallData {
+        # From is the address making the call.
+        from: Address
+        # To is the address the call is sent to.
+        to: Address
+        # Gas is the amount of gas sent with the call.
+        gas: Long
+        # GasPrice is the price, in wei, offered for each unit of gas.
+        gasPrice: BigInt
+        # MaxFeePerGas is the maximum fee per gas offered, in wei.
+        maxFeePerGas: BigInt
+        # MaxPriorityFeePerGas is the maximum tip per gas offered, in wei.
+        maxPriorityFeePerGas: BigInt
+        # Value is the value, in wei, sent along with the call.
+        value: BigInt
+        # Data is the data sent to the callee.
+        data: Bytes
+    }
+
+    # CallResult is the result of a local call operation.
+    type CallResult {
+        # Data is the return data of the called contract.
+        data: Bytes!
+        # GasUsed is the amount of gas used by the call, after any refunds.
+        gasUsed: Long!
+        # Status is the result of the call - 1 for success or 0 for failure.
+        status: Long!
+    }
+
+    # FilterCriteria encapsulates log filter criteria for searching log entries.
+    input FilterCriteria {
+        # FromBlock is the block at which to start searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        fromBlock: Long
+        # ToBlock is the block at which to stop searching, inclusive. Defaults
+        # to the latest block if not supplied.
+        toBlock: Long
+        # Addresses is a list of addresses that are of interest. If this list is
+        # empty, results will not be filtered by address.
+        addresses: [Address!]
+        # Topics list restricts matches to particular event topics. Each event has a list
+        # of topics. Topics matches a prefix of that list. An empty element array matches any
+        # topic. Non-empty elements represent an alternative that matches any of the
+        # contained topics.
+        topics: [[Bytes32!]!]
+    }
+
+    # SyncState contains the current synchronisation state of the client.
+    type SyncState {
+        # CurrentBlock is the point at which synchronisation has presently reached.
+        currentBlock: Long!
+        # HighestBlock is the latest known block number.
+        highestBlock: Long!
+        # CurrentEpoch is the epoch of the current block.
+        currentEpoch: Long!
+        # HighestEpoch is the latest known epoch.
+        highestEpoch: Long!
+    }
+
+    # Pending represents the current pending state.
+    type Pending {
+        # TransactionCount is the number of transactions in the pending state.
+        transactionCount: Int!
+        # Transactions is a list of transactions in the current pending state.
+        transactions: [Transaction!]
+        # Account fetches an Ethereum account for the pending state.
+        account(address: Address!): Account!
+        # Call executes a local call operation for the pending state.
+        call(data: CallData!): CallResult
+        # EstimateGas estimates the amount of gas that will be required for
+        # successful execution of a transaction for the pending state.
+        estimateGas(data: CallData!): Long!
+    }
+
+    # GasPowerLeft is the gas power of the validator left after the event.
+    type GasPowerLeft {
+        shortTerm: Long!
+        longTerm: Long!
+    }
+
+    # Event is a Hashgraph DAG event.
+    type Event {
+        # ID is the hash of the event.
+        id: Bytes32!
+        # Version is the version of the event format.
+        version: Int!
+        # Epoch is the number of the epoch the event belongs to.
+        epoch: Long!
+        # Seq is the sequence number of the event in the creator's chain.
+        seq: Long!
+        # Frame is the frame of the event.
+        frame: Long!
+        # Lamport is the Lamport timestamp of the event.
+        lamport: Long!
+        # Creator is the ID of the validator which has created the event.
+        creator: Long!
+        # CreationTime is the creation time of the event, in nanoseconds.
+        creationTime: Long!
+        # MedianTime is the median time of the event, in nanoseconds.
+        medianTime: Long!
+        # PayloadHash is the hash of the event payload.
+        payloadHash: Bytes32!
+        # ExtraData is the extra data of the event.
+        extraData: Bytes!
+        # GasPowerUsed is the gas power consumed by the event.
+        gasPowerUsed: Long!
+        # GasPowerLeft is the gas power of the creator left after the event.
+        gasPowerLeft: GasPowerLeft!
+        # Parents are the parent events of the event in the DAG.
+        parents: [Event!]!
+        # ParentIDs are the IDs of the parent events of the event in the DAG.
+        parentIDs: [Bytes32!]!
+        # Transactions is a list of transactions included into the event.
+        transactions: [Transaction!]!
+    }
+
+    # Rules are the network rules of an epoch.
+    type Rules {
+        # Name is the name of the network.
+        name: String!
+        # NetworkID is the ID of the network.
+        networkID: Long!
+        # MinGasPrice is the minimum gas price of the epoch, in wei.
+        minGasPrice: BigInt!
+        # MaxEpochGas is the gas limit of the epoch.
+        maxEpochGas: Long!
+        # MaxEpochDuration is the duration limit of the epoch, in nanoseconds.
+        maxEpochDuration: Long!
+        # MaxBlockGas is the gas limit of a block.
+        maxBlockGas: Long!
+        # MaxParents is the limit of parents of an event.
+        maxParents: Long!
+        # Raw is the JSON encoding of all the rules.
+        raw: String!
+    }
+
+    # Validator is a validator of an epoch.
+    type Validator {
+        # ID is the ID of the validator.
+        id: Long!
+        # Weight is the stake of the validator, in wei.
+        weight: BigInt!
+        # PubKey is the public key the validator is signing the events with.
+        pubKey: Bytes!
+        # LastEvent is the last confirmed event of the validator.
+        lastEvent: Event
+        # Uptime is the time the validator was online during the epoch, in nanoseconds.
+        uptime: Long!
+        # OriginatedFee is the amount of fees originated by the validator, in wei.
+        originatedFee: BigInt!
+    }
+
+    # Epoch is a Hashgraph epoch. The fee and reward totals of the epochs
+    # aren't tracked by the node, they are kept by the SFC contract.
+    type Epoch {
+        # Number is the number of the epoch.
+        number: Long!
+        # Start is the time the epoch has started at, in nanoseconds.
+        start: Long!
+        # End is the time the epoch has been sealed at, in nanoseconds.
+        # This field will be null if the epoch is not sealed yet.
+        end: Long
+        # StateRoot is the hash of the EVM state the epoch has started with.
+        stateRoot: Bytes32!
+        # TotalWeight is the total stake of the epoch validators, in wei.
+        totalWeight: BigInt!
+        # Validators is the list of the epoch validators.
+        validators: [Validator!]!
+        # Rules are the network rules of the epoch.
+        rules: Rules!
+        # Heads are the events with no descendants. They are available only
+        # for the current epoch.
+        heads: [Event!]
+    }
+
+    type Query {
+        # Block fetches a block by number or by hash. If neither is
+        # supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns all the blocks between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known block.
+        blocks(from: Long, to: Long): [Block!]!
+        # Pending returns the current pending state.
+        pending: Pending!
+        # Transaction returns a transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # Logs returns log entries matching the provided filter.
+        logs(filter: FilterCriteria!): [Log!]!
+        # GasPrice returns the node's estimate of a gas price sufficient to
+        # ensure a transaction is mined in a timely fashion.
+        gasPrice: BigInt!
+        # MaxPriorityFeePerGas returns the node's estimate of a gas tip sufficient
+        # to ensure a transaction is mined in a timely fashion.
+        maxPriorityFeePerGas: BigInt!
+        # Syncing returns information on the current synchronisation state.
+        syncing: SyncState
+        # ChainID returns the current chain ID for transaction replay protection.
+        chainID: BigInt!
+        # Event fetches a DAG event by its full hash or short ID.
+        event(id: String!): Event
+        # Epoch fetches an epoch by its number. If the number is not supplied,
+        # the current epoch is returned.
+        epoch(number: Long): Epoch
+    }
+
+    type Mutation {
+        # SendRawTransaction sends an RLP-encoded transaction to the network.
+        sendRawTransaction(data: Bytes!): Bytes32!
+    }
+`
@@ -0,0 +1,95 @@
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sesanetwork/go-sesa/ethapi"
+	"github.com/sesanetwork/go-sesa/gossip/filters"
+	"github.com/sesanetwork/go-sesa/node"
+)
+
+type handler struct {
+	Schema  *graphql.Schema
+	backend ethapi.Backend
+}
+
+func (h handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if timeout := h.backend.RPCTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	response := h.Schema.Exec(ctx, params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if len(response.Errors) > 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	w.Write(responseJSON)
+}
+
+// New constructs a new GraphQL service instance.
+// The filter backend is optional, the logs search isn't available without it.
+func New(stack *node.Node, backend ethapi.Backend, filterBackend filters.Backend, filterConfig filters.Config, cors, vhosts []string) error {
+	if backend == nil {
+		panic("missing backend")
+	}
+	return newHandler(stack, backend, filterBackend, filterConfig, cors, vhosts)
+}
+
+// newHandler mounts the handler answering GraphQL queries on the node HTTP server.
+func newHandler(stack *node.Node, backend ethapi.Backend, filterBackend filters.Backend, filterConfig filters.Config, cors, vhosts []string) error {
+	q := Resolver{
+		backend:       backend,
+		filterBackend: filterBackend,
+		filterConfig:  filterConfig,
+	}
+
+	s, err := graphql.ParseSchema(schema, &q)
+	if err != nil {
+		return err
+	}
+	h := handler{Schema: s, backend: backend}
+	handler := node.NewHTTPHandlerStack(h, cors, vhosts, nil)
+
+	stack.RegisterHandler("GraphQL", "/graphql", handler)
+	stack.RegisterHandler("GraphQL", "/graphql/", handler)
+
+	return nil
+}
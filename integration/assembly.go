@@ -236,10 +236,14 @@ func makeEngine(chaindataDir string, g *genesis.Genesis, genesisProc bool, cfg C
 
 	runtimeProducers, runtimeScopedProducers := SupportedDBs(chaindataDir, cfg.DBs.RuntimeCache)
 	// open flushable DBs
-	dbs, err := MakeMultiProducer(runtimeProducers, runtimeScopedProducers, cfg.DBs.Routing)
+	multiDbs, err := MakeMultiProducer(runtimeProducers, runtimeScopedProducers, cfg.DBs.Routing)
 	if err != nil {
 		return nil, nil, nil, nil, gossip.BlockProc{}, nil, err
 	}
+	dbs := &snapshotableProducer{
+		FullDBProducer: multiDbs,
+		raw:            runtimeProducers,
+	}
 
 	gdb, cdb := getStores(dbs, cfg)
 	defer func() {
@@ -250,6 +254,16 @@ func makeEngine(chaindataDir string, g *genesis.Genesis, genesisProc bool, cfg C
 		}
 	}()
 
+	// the freezer is opened even if freezing is disabled, to read the already frozen data
+	freezerDir := path.Join(chaindataDir, FreezerDir)
+	if cfg.sesaStore.Freezer.Enabled() || !isEmpty(freezerDir) {
+		err = gdb.OpenFreezer(freezerDir)
+		if err != nil {
+			err = fmt.Errorf("failed to open freezer: %v", err)
+			return nil, nil, nil, nil, gossip.BlockProc{}, dbs.Close, err
+		}
+	}
+
 	// compare genesis with the input
 	genesisID := gdb.GetGenesisID()
 	if genesisID == nil {
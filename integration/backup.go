@@ -0,0 +1,383 @@
+package integration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/sesanetwork/go-vassalo/sesadb"
+	"github.com/sesanetwork/go-vassalo/sesadb/multidb"
+	"github.com/sesanetwork/go-sesa/common"
+
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
+	"github.com/sesanetwork/go-sesa/utils/dbutil/checkpoint"
+)
+
+// BackupManifestFile is the name of the backup manifest file, which is written
+// after all the DBs of the backup, so it exists only for complete backups
+const BackupManifestFile = "backup.json"
+
+// backupDBsCacheConfig is the DBs cache config for writing and verifying backups
+var backupDBsCacheConfig = DBsCacheConfig{
+	Table: map[string]DBCacheConfig{
+		"": {
+			Cache:   64 * opt.MiB,
+			Fdlimit: 128,
+		},
+	},
+}
+
+// snapshotableProducer is a multi-producer which is able to take consistent snapshots
+// of all the physical DBs.
+type snapshotableProducer struct {
+	sesadb.FullDBProducer
+	raw map[multidb.TypeName]sesadb.IterableDBProducer
+}
+
+func (p *snapshotableProducer) SnapshotDBs(dir string) (gossip.DBsSnapshots, error) {
+	return SnapshotDBs(p.raw, dir)
+}
+
+// SnapshotDBs takes the snapshots of all the physical DBs of the producers returned by SupportedDBs.
+// The DBs which are able to write their checkpoints, i.e. pebble DBs, are checkpointed into the
+// backup directory instead, which must be empty or non-existent. The snapshots are taken only
+// for the rest of the DBs. The caller has to pause writes into the DBs to get a consistent view of them.
+func SnapshotDBs(producers map[multidb.TypeName]sesadb.IterableDBProducer, dir string) (gossip.DBsSnapshots, error) {
+	if !isEmpty(dir) {
+		return nil, fmt.Errorf("backup directory %s isn't empty", dir)
+	}
+	snapshots := make(gossip.DBsSnapshots, len(producers))
+	for typ, producer := range producers {
+		p, ok := producer.(*checkpoint.Producer)
+		if !ok {
+			snapshots.Release()
+			return nil, fmt.Errorf("%s DBs snapshots aren't supported", typ)
+		}
+		_, err := p.Checkpoints(path.Join(dir, "chaindata", string(typ)))
+		if err == nil {
+			continue
+		}
+		if err != checkpoint.ErrNotSupported {
+			snapshots.Release()
+			return nil, err
+		}
+		ss, err := p.Snapshots()
+		if err != nil {
+			snapshots.Release()
+			return nil, err
+		}
+		snapshots[typ] = ss
+	}
+	return snapshots, nil
+}
+
+// NewBackupWriter returns the gossip.BackupWriter of the DBs snapshots and the freezer of the chaindata directory.
+func NewBackupWriter(chaindataDir string) gossip.BackupWriter {
+	return func(dir string, snapshots gossip.DBsSnapshots, manifest *gossip.BackupManifest) error {
+		return WriteBackup(dir, chaindataDir, snapshots, manifest)
+	}
+}
+
+// WriteBackup writes the DBs snapshots and the freezer of the chaindata directory into the backup directory,
+// which has the DBs checkpoints taken along with the snapshots by SnapshotDBs. The freezer must not be appended
+// until the backup is written.
+func WriteBackup(dir, chaindataDir string, snapshots gossip.DBsSnapshots, manifest *gossip.BackupManifest) error {
+	if _, err := os.Stat(path.Join(dir, BackupManifestFile)); !os.IsNotExist(err) {
+		return fmt.Errorf("backup directory %s already has a backup", dir)
+	}
+	backupChaindataDir := path.Join(dir, "chaindata")
+	producers, _ := SupportedDBs(backupChaindataDir, backupDBsCacheConfig)
+
+	for typ, ss := range snapshots {
+		if err := os.MkdirAll(path.Join(backupChaindataDir, string(typ)), 0700); err != nil {
+			return err
+		}
+		for name, snap := range ss {
+			if err := writeBackupDB(producers[typ], name, snap); err != nil {
+				return fmt.Errorf("failed to write %s/%s DB: %v", typ, name, err)
+			}
+		}
+	}
+	// digest both the written snapshots and the checkpoints
+	manifest.DBs = nil
+	for typ, producer := range producers {
+		for _, name := range producer.Names() {
+			records, digest, err := digestDB(producer, name)
+			if err != nil {
+				return fmt.Errorf("failed to read %s/%s DB: %v", typ, name, err)
+			}
+			manifest.DBs = append(manifest.DBs, gossip.BackupDB{
+				Type:    typ,
+				Name:    name,
+				Records: records,
+				Digest:  digest,
+			})
+		}
+	}
+	sort.Slice(manifest.DBs, func(i, j int) bool {
+		a, b := manifest.DBs[i], manifest.DBs[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+
+	err := writeBackupFreezer(path.Join(chaindataDir, FreezerDir), path.Join(backupChaindataDir, FreezerDir), manifest)
+	if err != nil {
+		return fmt.Errorf("failed to write freezer: %v", err)
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path.Join(dir, BackupManifestFile), b, 0600)
+}
+
+func writeBackupDB(producer sesadb.DBProducer, name string, snap sesadb.Snapshot) error {
+	db, err := producer.OpenDB(name)
+	if err != nil {
+		return err
+	}
+	_, err = checkpoint.Copy(db, snap)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	return db.Close()
+}
+
+// writeBackupFreezer copies the freezer files, and records the range of the frozen history into the manifest.
+func writeBackupFreezer(src, dst string, manifest *gossip.BackupManifest) error {
+	manifest.Freezer = nil
+	if isEmpty(src) {
+		return nil
+	}
+	if err := copyDir(src, dst); err != nil {
+		return err
+	}
+	frozen, err := readFreezerRange(dst)
+	if err != nil {
+		return err
+	}
+	manifest.Freezer = frozen
+	return nil
+}
+
+// readFreezerRange opens the freezer, repairing it if it's copied after an unclean shutdown, and returns the range of the frozen history.
+func readFreezerRange(dir string) (*gossip.BackupFreezer, error) {
+	f, err := freezer.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	frozen := &gossip.BackupFreezer{}
+	_, frozen.Blocks, _ = f.Blocks()
+	_, frozen.Epochs, _ = f.Epochs()
+	return frozen, nil
+}
+
+// ReadBackupManifest reads the manifest of the backup.
+func ReadBackupManifest(dir string) (*gossip.BackupManifest, error) {
+	b, err := os.ReadFile(path.Join(dir, BackupManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("backup manifest is missing, the backup is incomplete")
+		}
+		return nil, err
+	}
+	manifest := &gossip.BackupManifest{}
+	if err := json.Unmarshal(b, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse backup manifest: %v", err)
+	}
+	if manifest.Version != gossip.BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+// VerifyBackup checks that the backup contains all the DBs of the manifest with all their records,
+// and the frozen history of the manifest, that all the DBs are flushed at the same point,
+// and that the DBs state matches the manifest. Opening the DBs modifies their files,
+// so a copy of the backup is verified, and the backup files are left intact.
+func VerifyBackup(dir string, cfg DBsConfig) (*gossip.BackupManifest, error) {
+	manifest, err := ReadBackupManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	// the copy is made next to the backup, as the temporary directory may be too small for it
+	tmpDir, err := os.MkdirTemp(filepath.Dir(filepath.Clean(dir)), "sesa-backup-verify")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := copyDir(path.Join(dir, "chaindata"), tmpDir); err != nil {
+		return nil, err
+	}
+	if err := verifyBackupChaindata(tmpDir, manifest, cfg); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// verifyBackupChaindata checks the copy of the backup chaindata against the manifest.
+func verifyBackupChaindata(chaindataDir string, manifest *gossip.BackupManifest, cfg DBsConfig) error {
+	producers, _ := SupportedDBs(chaindataDir, backupDBsCacheConfig)
+
+	expected := make(map[string]bool, len(manifest.DBs))
+	for _, info := range manifest.DBs {
+		humanName := path.Join(string(info.Type), info.Name)
+		expected[humanName] = true
+		producer := producers[info.Type]
+		if producer == nil {
+			return fmt.Errorf("%s DB has unknown type", humanName)
+		}
+		if !hasDB(producer, info.Name) {
+			return fmt.Errorf("%s DB is missing", humanName)
+		}
+		records, digest, err := digestDB(producer, info.Name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s DB: %v", humanName, err)
+		}
+		if records != info.Records {
+			return fmt.Errorf("%s DB has %d records, expected %d", humanName, records, info.Records)
+		}
+		if digest != info.Digest {
+			return fmt.Errorf("%s DB content doesn't match the manifest digest", humanName)
+		}
+	}
+	for typ, producer := range producers {
+		for _, name := range producer.Names() {
+			if humanName := path.Join(string(typ), name); !expected[humanName] {
+				return fmt.Errorf("%s DB isn't listed in the manifest", humanName)
+			}
+		}
+	}
+
+	freezerDir := path.Join(chaindataDir, FreezerDir)
+	if manifest.Freezer == nil {
+		if !isEmpty(freezerDir) {
+			return errors.New("freezer isn't listed in the manifest")
+		}
+	} else {
+		if isEmpty(freezerDir) {
+			return errors.New("freezer is missing")
+		}
+		frozen, err := readFreezerRange(freezerDir)
+		if err != nil {
+			return fmt.Errorf("failed to read freezer: %v", err)
+		}
+		if *frozen != *manifest.Freezer {
+			return fmt.Errorf("freezer has blocks until %d and epochs until %d, expected %d and %d",
+				frozen.Blocks, frozen.Epochs, manifest.Freezer.Blocks, manifest.Freezer.Epochs)
+		}
+	}
+
+	// all the DBs must be flushed at the same point
+	if err := CheckStateInitialized(chaindataDir, cfg); err != nil {
+		return err
+	}
+
+	dbs, err := MakeDirectMultiProducer(producers, cfg.Routing)
+	if err != nil {
+		return err
+	}
+	defer dbs.Close()
+	gdb := gossip.NewStore(dbs, gossip.LiteStoreConfig())
+	defer gdb.Close()
+	genesisID := gdb.GetGenesisID()
+	if genesisID == nil || common.Hash(*genesisID) != manifest.GenesisID {
+		return errors.New("backup genesis ID doesn't match the manifest")
+	}
+	if block := gdb.GetBlockState().LastBlock.Idx; block != manifest.Block {
+		return fmt.Errorf("backup last block %d doesn't match the manifest block %d", block, manifest.Block)
+	}
+	return nil
+}
+
+func hasDB(producer sesadb.IterableDBProducer, name string) bool {
+	for _, n := range producer.Names() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func digestDB(producer sesadb.DBProducer, name string) (uint64, common.Hash, error) {
+	db, err := producer.OpenDB(name)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	defer db.Close()
+	return checkpoint.Digest(db)
+}
+
+// RestoreBackup verifies the backup and copies its DBs into the chaindata directory, which must be
+// empty or non-existent. An interrupted restoring is rolled back on the next node start.
+func RestoreBackup(dir, chaindataDir string, cfg DBsConfig) (*gossip.BackupManifest, error) {
+	if !isEmpty(chaindataDir) {
+		return nil, fmt.Errorf("chaindata directory %s isn't empty", chaindataDir)
+	}
+	manifest, err := VerifyBackup(dir, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("backup verification failed: %v", err)
+	}
+
+	if err := os.MkdirAll(chaindataDir, 0700); err != nil {
+		return nil, err
+	}
+	setGenesisProcessing(chaindataDir)
+	if err := copyDir(path.Join(dir, "chaindata"), chaindataDir); err != nil {
+		return nil, err
+	}
+	setGenesisComplete(chaindataDir)
+	return manifest, nil
+}
+
+// copyDir copies the files of the src directory into the dst directory recursively.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		return copyFile(p, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
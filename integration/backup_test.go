@@ -0,0 +1,183 @@
+package integration
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/consensus"
+	"github.com/sesanetwork/go-vassalo/utils/cachescale"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/gossip/freezer"
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/utils"
+	"github.com/sesanetwork/go-sesa/vecmt"
+)
+
+func backupTestConfigs() Configs {
+	return Configs{
+		sesa:           gossip.DefaultConfig(cachescale.Identity),
+		sesaStore:      gossip.DefaultStoreConfig(cachescale.Identity),
+		Hashgraph:      consensus.DefaultConfig(),
+		HashgraphStore: consensus.DefaultStoreConfig(cachescale.Identity),
+		VectorClock:    vecmt.DefaultConfig(cachescale.Identity),
+		DBs:            DefaultDBsConfig(cachescale.Identity.U64, 512),
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	genStore := makefakegenesis.FakeGenesisStore(1, utils.Tosesa(1), utils.Tosesa(1))
+	g := genStore.Genesis()
+	cfg := backupTestConfigs()
+	_, _, store, s2, _, closeDBs := MakeEngine(path.Join(dir, "chaindata"), &g, cfg)
+
+	require.NoError(store.Commit())
+	backupDir := path.Join(dir, "backup")
+	snapshots, err := store.SnapshotDBs(backupDir)
+	require.NoError(err)
+	manifest := store.BackupManifest()
+	require.NoError(WriteBackup(backupDir, path.Join(dir, "chaindata"), snapshots, manifest))
+	snapshots.Release()
+	require.Equal(common.Hash(g.GenesisID), manifest.GenesisID)
+	require.NotEmpty(manifest.DBs)
+	require.Nil(manifest.Freezer)
+	for _, info := range manifest.DBs {
+		require.NotEqual(common.Hash{}, info.Digest)
+	}
+
+	// the backup directory must be empty
+	_, err = store.SnapshotDBs(backupDir)
+	require.Error(err)
+
+	store.Close()
+	require.NoError(s2.Close())
+	require.NoError(closeDBs())
+
+	restored, err := RestoreBackup(backupDir, path.Join(dir, "restored"), cfg.DBs)
+	require.NoError(err)
+	require.Equal(manifest.Block, restored.Block)
+	require.NoError(CheckStateInitialized(path.Join(dir, "restored"), cfg.DBs))
+
+	// the verification doesn't modify the backup files
+	before := backupFiles(t, backupDir)
+	_, err = VerifyBackup(backupDir, cfg.DBs)
+	require.NoError(err)
+	require.Equal(before, backupFiles(t, backupDir))
+
+	// restoring into a non-empty chaindata isn't allowed
+	_, err = RestoreBackup(backupDir, path.Join(dir, "restored"), cfg.DBs)
+	require.Error(err)
+
+	// a backup which DB content differs from the manifest is rejected, even with the same records number
+	info := manifest.DBs[0]
+	producers, _ := SupportedDBs(path.Join(backupDir, "chaindata"), backupDBsCacheConfig)
+	db, err := producers[info.Type].OpenDB(info.Name)
+	require.NoError(err)
+	it := db.NewIterator(nil, nil)
+	require.True(it.Next())
+	key := common.CopyBytes(it.Key())
+	it.Release()
+	require.NoError(db.Put(key, []byte("corrupted")))
+	require.NoError(db.Close())
+	_, err = VerifyBackup(backupDir, cfg.DBs)
+	require.ErrorContains(err, "digest")
+
+	// an incomplete backup is rejected
+	require.NoError(os.Remove(path.Join(backupDir, BackupManifestFile)))
+	_, err = RestoreBackup(backupDir, path.Join(dir, "restored2"), cfg.DBs)
+	require.Error(err)
+}
+
+func TestBackupRestoreFreezer(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	chaindataDir := path.Join(dir, "chaindata")
+
+	// freeze the history before the node start
+	f, err := freezer.Open(path.Join(chaindataDir, FreezerDir))
+	require.NoError(err)
+	for n := uint64(0); n < 5; n++ {
+		require.NoError(f.AppendBlock(n, []byte(fmt.Sprintf("block-%d", n)), nil, nil))
+	}
+	require.NoError(f.AppendEpoch(1, []freezer.Event{{Key: []byte{1}, Value: []byte("event")}}))
+	require.NoError(f.Sync())
+	require.NoError(f.Close())
+
+	genStore := makefakegenesis.FakeGenesisStore(1, utils.Tosesa(1), utils.Tosesa(1))
+	g := genStore.Genesis()
+	cfg := backupTestConfigs()
+	cfg.sesaStore.Freezer.Epochs = 1
+	_, _, store, s2, _, closeDBs := MakeEngine(chaindataDir, &g, cfg)
+
+	require.NoError(store.Commit())
+	backupDir := path.Join(dir, "backup")
+	snapshots, err := store.SnapshotDBs(backupDir)
+	require.NoError(err)
+	manifest := store.BackupManifest()
+	require.NoError(NewBackupWriter(chaindataDir)(backupDir, snapshots, manifest))
+	snapshots.Release()
+	require.Equal(&gossip.BackupFreezer{Blocks: 5, Epochs: 2}, manifest.Freezer)
+
+	store.Close()
+	require.NoError(s2.Close())
+	require.NoError(closeDBs())
+
+	restoredDir := path.Join(dir, "restored")
+	restored, err := RestoreBackup(backupDir, restoredDir, cfg.DBs)
+	require.NoError(err)
+	require.Equal(manifest.Freezer, restored.Freezer)
+
+	f, err = freezer.Open(path.Join(restoredDir, FreezerDir))
+	require.NoError(err)
+	for n := uint64(0); n < 5; n++ {
+		block, err := f.Block(n)
+		require.NoError(err)
+		require.Equal(fmt.Sprintf("block-%d", n), string(block))
+	}
+	event, err := f.Event(1, []byte{1})
+	require.NoError(err)
+	require.Equal("event", string(event))
+	require.NoError(f.Close())
+
+	// a backup which frozen history differs from the manifest is rejected
+	f, err = freezer.Open(path.Join(backupDir, "chaindata", FreezerDir))
+	require.NoError(err)
+	require.NoError(f.AppendBlock(5, []byte("block-5"), nil, nil))
+	require.NoError(f.Close())
+	_, err = RestoreBackup(backupDir, path.Join(dir, "restored2"), cfg.DBs)
+	require.Error(err)
+
+	// a backup without the frozen history of the manifest is rejected
+	require.NoError(os.RemoveAll(path.Join(backupDir, "chaindata", FreezerDir)))
+	_, err = RestoreBackup(backupDir, path.Join(dir, "restored3"), cfg.DBs)
+	require.Error(err)
+}
+
+// backupFiles returns the hashes of all the backup files by their paths.
+func backupFiles(t *testing.T, dir string) map[string]common.Hash {
+	files := make(map[string]common.Hash)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[p] = crypto.Keccak256Hash(b)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
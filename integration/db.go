@@ -22,9 +22,13 @@ import (
 
 	"github.com/sesanetwork/go-sesa/gossip"
 	"github.com/sesanetwork/go-sesa/utils/dbutil/asyncflushproducer"
+	"github.com/sesanetwork/go-sesa/utils/dbutil/checkpoint"
 	"github.com/sesanetwork/go-sesa/utils/dbutil/dbcounter"
 )
 
+// FreezerDir is the chaindata subdirectory of the freezer of the old events and blocks
+const FreezerDir = "ancient"
+
 type DBsConfig struct {
 	Routing       RoutingConfig
 	RuntimeCache  DBsCacheConfig
@@ -65,6 +69,13 @@ func SupportedDBs(chaindataDir string, cfg DBsCacheConfig) (map[multidb.TypeName
 		pebbleFlg = WrapDatabaseWithMetrics(pebbleFlg)
 		pebbleDrc = WrapDatabaseWithMetrics(pebbleDrc)
 	}
+	// track the opened DBs to take their snapshots for backups
+	leveldbFsh = checkpoint.Wrap(leveldbFsh)
+	leveldbFlg = checkpoint.Wrap(leveldbFlg)
+	leveldbDrc = checkpoint.Wrap(leveldbDrc)
+	pebbleFsh = checkpoint.Wrap(pebbleFsh)
+	pebbleFlg = checkpoint.Wrap(pebbleFlg)
+	pebbleDrc = checkpoint.Wrap(pebbleDrc)
 
 	return map[multidb.TypeName]sesadb.IterableDBProducer{
 			"leveldb-fsh": leveldbFsh,
@@ -0,0 +1,119 @@
+package integration
+
+import (
+	"github.com/sesanetwork/go-vassalo/consensus"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/utils/cachescale"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/node"
+
+	"github.com/sesanetwork/go-sesa/evmcore"
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/gossip/emitter"
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/utils/adapters/vecmt2dagidx"
+	"github.com/sesanetwork/go-sesa/valkeystore"
+	"github.com/sesanetwork/go-sesa/vecmt"
+)
+
+// FakeValidatorConfig is a config of the in-memory fakenet validator.
+type FakeValidatorConfig struct {
+	ID idx.ValidatorID
+	// Silent validator doesn't emit events, so its node only syncs
+	Silent        bool
+	EmitIntervals emitter.EmitIntervals
+	Logger        log.Logger
+}
+
+// FakeValidator is an in-memory fakenet validator, i.e. the gossip service
+// with the fake key of the validator, which is assembled like a fakenet node.
+type FakeValidator struct {
+	Store   *gossip.Store
+	CStore  *consensus.Store
+	Service *gossip.Service
+	TxPool  *evmcore.TxPool
+	// Emitter is nil for a silent validator
+	Emitter *emitter.Emitter
+}
+
+// NewFakeValidator applies the genesis to the in-memory DBs, and creates the gossip service
+// of the validator on the node stack. The caller registers the service in the stack.
+func NewFakeValidator(stack *node.Node, g genesis.Genesis, cfg FakeValidatorConfig) (*FakeValidator, error) {
+	v := &FakeValidator{
+		Store:  gossip.NewMemStore(),
+		CStore: consensus.NewMemStore(),
+	}
+	_, err := v.Store.ApplyGenesis(g)
+	if err == nil {
+		err = v.CStore.ApplyGenesis(&consensus.Genesis{
+			Epoch:      v.Store.GetEpoch(),
+			Validators: v.Store.GetValidators(),
+		})
+	}
+	if err != nil {
+		v.Close()
+		return nil, err
+	}
+	vecClock := vecmt.NewIndex(logPanics(cfg.Logger, "Vector clock"), vecmt.LiteConfig())
+	engine := consensus.NewConsensus(v.CStore, &GossipStoreAdapter{Store: v.Store}, vecmt2dagidx.Wrap(vecClock), logPanics(cfg.Logger, "Hashgraph"), consensus.LiteConfig())
+
+	newTxPool := func(reader evmcore.StateReader) gossip.TxPool {
+		// no journal for the in-memory network
+		txpoolCfg := evmcore.DefaultTxPoolConfig
+		txpoolCfg.Journal = ""
+		v.TxPool = evmcore.NewTxPool(txpoolCfg, reader.Config(), reader)
+		return v.TxPool
+	}
+	v.Service, err = gossip.NewService(stack, gossip.DefaultConfig(cachescale.Identity), v.Store, gossip.DefaultBlockProc(), engine, vecClock, newTxPool, nil)
+	if err == nil {
+		err = engine.StartFrom(v.Service.GetConsensusCallbacks(), v.Store.GetEpoch(), v.Store.GetValidators())
+	}
+	if err != nil {
+		v.Close()
+		return nil, err
+	}
+
+	if !cfg.Silent {
+		v.Emitter = newFakeEmitter(v.Service, cfg)
+		v.Service.RegisterEmitter(v.Emitter)
+	}
+	return v, nil
+}
+
+// newFakeEmitter creates the emitter of the validator, which signs events with the fake key.
+func newFakeEmitter(svc *gossip.Service, cfg FakeValidatorConfig) *emitter.Emitter {
+	key := makefakegenesis.FakeKey(cfg.ID)
+	pubkey := validatorpk.PubKey{
+		Raw:  crypto.FromECDSAPub(&key.PublicKey),
+		Type: validatorpk.Types.Secp256k1,
+	}
+	valKeystore := valkeystore.NewDefaultMemKeystore()
+	_ = valKeystore.Add(pubkey, crypto.FromECDSA(key), validatorpk.FakePassword)
+	_ = valKeystore.Unlock(pubkey, validatorpk.FakePassword)
+
+	emitterCfg := emitter.DefaultConfig()
+	emitterCfg.Validator = emitter.ValidatorConfig{
+		ID:     cfg.ID,
+		PubKey: pubkey,
+	}
+	emitterCfg.EmitIntervals = cfg.EmitIntervals
+	return emitter.NewEmitter(emitterCfg, svc.EmitterWorld(valkeystore.NewSigner(valKeystore)))
+}
+
+// Close stops the txpool and closes the DBs. The service is stopped by the node stack.
+func (v *FakeValidator) Close() {
+	if v.TxPool != nil {
+		v.TxPool.Stop()
+	}
+	v.Store.Close()
+	_ = v.CStore.Close()
+}
+
+func logPanics(logger log.Logger, name string) func(error) {
+	return func(err error) {
+		logger.Crit(name+" error", "err", err)
+	}
+}
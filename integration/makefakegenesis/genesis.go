@@ -22,14 +22,7 @@ import (
 	"github.com/sesanetwork/go-sesa/native/ier"
 	"github.com/sesanetwork/go-sesa/native/validatorpk"
 	"github.com/sesanetwork/go-sesa/sesa"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/driver"
 	"github.com/sesanetwork/go-sesa/sesa/contracts/driver/drivercall"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/driverauth"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/evmwriter"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/netinit"
-	netinitcall "github.com/sesanetwork/go-sesa/sesa/contracts/netinit/netinitcalls"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/sfc"
-	"github.com/sesanetwork/go-sesa/sesa/contracts/sfclib"
 	"github.com/sesanetwork/go-sesa/sesa/genesis"
 	"github.com/sesanetwork/go-sesa/sesa/genesis/gpos"
 	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
@@ -75,18 +68,7 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 	}
 
 	// deploy essential contracts
-	// pre deploy NetworkInitializer
-	builder.SetCode(netinit.ContractAddress, netinit.GetContractBin())
-	// pre deploy NodeDriver
-	builder.SetCode(driver.ContractAddress, driver.GetContractBin())
-	// pre deploy NodeDriverAuth
-	builder.SetCode(driverauth.ContractAddress, driverauth.GetContractBin())
-	// pre deploy SFC
-	builder.SetCode(sfc.ContractAddress, sfc.GetContractBin())
-	// pre deploy SFCLib
-	builder.SetCode(sfclib.ContractAddress, sfclib.GetContractBin())
-	// set non-zero code for pre-compiled contracts
-	builder.SetCode(evmwriter.ContractAddress, []byte{0})
+	builder.SetSystemContracts()
 
 	builder.SetCurrentEpoch(ier.LlrIdxFullEpochRecord{
 		LlrFullEpochRecord: ier.LlrFullEpochRecord{
@@ -138,32 +120,8 @@ func FakeGenesisStoreWithRulesAndStart(num idx.Validator, balance, stake *big.In
 	})
 }
 
-func txBuilder() func(calldata []byte, addr common.Address) *types.Transaction {
-	nonce := uint64(0)
-	return func(calldata []byte, addr common.Address) *types.Transaction {
-		tx := types.NewTransaction(nonce, addr, common.Big0, 1e10, common.Big0, calldata)
-		nonce++
-		return tx
-	}
-}
-
 func GetGenesisTxs(sealedEpoch idx.Epoch, validators gpos.Validators, totalSupply *big.Int, delegations []drivercall.Delegation, driverOwner common.Address) types.Transactions {
-	buildTx := txBuilder()
-	internalTxs := make(types.Transactions, 0, 15)
-	// initialization
-	calldata := netinitcall.InitializeAll(sealedEpoch, totalSupply, sfc.ContractAddress, sfclib.ContractAddress, driverauth.ContractAddress, driver.ContractAddress, evmwriter.ContractAddress, driverOwner)
-	internalTxs = append(internalTxs, buildTx(calldata, netinit.ContractAddress))
-	// push genesis validators
-	for _, v := range validators {
-		calldata := drivercall.SetGenesisValidator(v)
-		internalTxs = append(internalTxs, buildTx(calldata, driver.ContractAddress))
-	}
-	// push genesis delegations
-	for _, delegation := range delegations {
-		calldata := drivercall.SetGenesisDelegation(delegation)
-		internalTxs = append(internalTxs, buildTx(calldata, driver.ContractAddress))
-	}
-	return internalTxs
+	return makegenesis.GetGenesisTxs(sealedEpoch, validators, totalSupply, delegations, driverOwner)
 }
 
 func GetFakeValidators(num idx.Validator) gpos.Validators {
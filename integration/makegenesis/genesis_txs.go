@@ -0,0 +1,75 @@
+package makegenesis
+
+import (
+	"math/big"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driver"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driver/drivercall"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driverauth"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/evmwriter"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/netinit"
+	netinitcall "github.com/sesanetwork/go-sesa/sesa/contracts/netinit/netinitcalls"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/sfc"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/sfclib"
+	"github.com/sesanetwork/go-sesa/sesa/genesis/gpos"
+)
+
+// SetSystemContracts pre deploys the essential contracts.
+func (b *GenesisBuilder) SetSystemContracts() {
+	// pre deploy NetworkInitializer
+	b.SetCode(netinit.ContractAddress, netinit.GetContractBin())
+	// pre deploy NodeDriver
+	b.SetCode(driver.ContractAddress, driver.GetContractBin())
+	// pre deploy NodeDriverAuth
+	b.SetCode(driverauth.ContractAddress, driverauth.GetContractBin())
+	// pre deploy SFC
+	b.SetCode(sfc.ContractAddress, sfc.GetContractBin())
+	// pre deploy SFCLib
+	b.SetCode(sfclib.ContractAddress, sfclib.GetContractBin())
+	// set non-zero code for pre-compiled contracts
+	b.SetCode(evmwriter.ContractAddress, []byte{0})
+}
+
+// IsSystemContract returns true if the address is reserved by the essential contracts.
+func IsSystemContract(addr common.Address) bool {
+	switch addr {
+	case netinit.ContractAddress, driver.ContractAddress, driverauth.ContractAddress,
+		sfc.ContractAddress, sfclib.ContractAddress, evmwriter.ContractAddress:
+		return true
+	}
+	return false
+}
+
+func txBuilder() func(calldata []byte, addr common.Address) *types.Transaction {
+	nonce := uint64(0)
+	return func(calldata []byte, addr common.Address) *types.Transaction {
+		tx := types.NewTransaction(nonce, addr, common.Big0, 1e10, common.Big0, calldata)
+		nonce++
+		return tx
+	}
+}
+
+// GetGenesisTxs returns the transactions which initialize the essential contracts
+// with the genesis validators and delegations.
+func GetGenesisTxs(sealedEpoch idx.Epoch, validators gpos.Validators, totalSupply *big.Int, delegations []drivercall.Delegation, driverOwner common.Address) types.Transactions {
+	buildTx := txBuilder()
+	internalTxs := make(types.Transactions, 0, 15)
+	// initialization
+	calldata := netinitcall.InitializeAll(sealedEpoch, totalSupply, sfc.ContractAddress, sfclib.ContractAddress, driverauth.ContractAddress, driver.ContractAddress, evmwriter.ContractAddress, driverOwner)
+	internalTxs = append(internalTxs, buildTx(calldata, netinit.ContractAddress))
+	// push genesis validators
+	for _, v := range validators {
+		calldata := drivercall.SetGenesisValidator(v)
+		internalTxs = append(internalTxs, buildTx(calldata, driver.ContractAddress))
+	}
+	// push genesis delegations
+	for _, delegation := range delegations {
+		calldata := drivercall.SetGenesisDelegation(delegation)
+		internalTxs = append(internalTxs, buildTx(calldata, driver.ContractAddress))
+	}
+	return internalTxs
+}
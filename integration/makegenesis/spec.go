@@ -0,0 +1,275 @@
+package makegenesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/common/math"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-vassalo/native/pos"
+	"github.com/sesanetwork/go-vassalo/sesadb/memorydb"
+	utypes "github.com/sesanetwork/go-vassalo/types"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/drivertype"
+	"github.com/sesanetwork/go-sesa/native/iblockproc"
+	"github.com/sesanetwork/go-sesa/native/ier"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/sesa"
+	"github.com/sesanetwork/go-sesa/sesa/contracts/driver/drivercall"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesis/gpos"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore"
+)
+
+const (
+	// specFirstEpoch is the first epoch of a network built from a spec
+	specFirstEpoch = idx.Epoch(2)
+	// specFirstBlock is the first block of a network built from a spec
+	specFirstBlock = idx.Block(1)
+)
+
+type (
+	// Spec is a declarative description of a custom network genesis.
+	Spec struct {
+		NetworkID   uint64 `json:"networkId"`
+		NetworkName string `json:"networkName"`
+		// Time is the genesis time in unix seconds
+		Time uint64 `json:"time"`
+		// Rules override the mainnet rules, NetworkID and Name are taken from the spec
+		Rules sesa.Rules `json:"rules"`
+		// DriverOwner is the owner of NodeDriverAuth contract, the first validator by default
+		DriverOwner *common.Address `json:"driverOwner,omitempty"`
+
+		Validators  []SpecValidator                `json:"validators"`
+		Delegations []SpecDelegation               `json:"delegations,omitempty"`
+		Accounts    map[common.Address]SpecAccount `json:"accounts,omitempty"`
+	}
+
+	// SpecValidator is an initial validator with its self-stake.
+	SpecValidator struct {
+		ID     idx.ValidatorID    `json:"id"`
+		PubKey validatorpk.PubKey `json:"pubkey"`
+		// Address is the validator's auth address, derived from the pubkey by default
+		Address *common.Address       `json:"address,omitempty"`
+		Stake   *math.HexOrDecimal256 `json:"stake"`
+	}
+
+	// SpecDelegation is an initial delegation to a validator.
+	SpecDelegation struct {
+		Address     common.Address        `json:"address"`
+		ValidatorID idx.ValidatorID       `json:"validatorId"`
+		Stake       *math.HexOrDecimal256 `json:"stake"`
+	}
+
+	// SpecAccount is a pre-funded account or a predeployed contract.
+	SpecAccount struct {
+		Balance *math.HexOrDecimal256       `json:"balance,omitempty"`
+		Nonce   uint64                      `json:"nonce,omitempty"`
+		Code    hexutil.Bytes               `json:"code,omitempty"`
+		Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	}
+)
+
+// LoadSpec reads the genesis spec from a JSON file. Rules which aren't
+// specified are taken from the mainnet rules.
+func LoadSpec(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spec := &Spec{
+		Rules: sesa.MainNetRules(),
+	}
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to parse genesis spec: %v", err)
+	}
+	return spec, nil
+}
+
+func specBig(v *math.HexOrDecimal256) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return (*big.Int)(v)
+}
+
+// validatorAddress returns the auth address of the validator.
+func (v *SpecValidator) validatorAddress() (common.Address, error) {
+	if v.Address != nil {
+		return *v.Address, nil
+	}
+	if v.PubKey.Type != validatorpk.Types.Secp256k1 {
+		return common.Address{}, fmt.Errorf("validator %d: address is required for pubkey type %#x", v.ID, v.PubKey.Type)
+	}
+	pub, err := crypto.UnmarshalPubkey(v.PubKey.Raw)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("validator %d: invalid pubkey: %v", v.ID, err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// Validate checks the spec consistency.
+func (s *Spec) Validate() error {
+	if s.NetworkID == 0 {
+		return errors.New("networkId is required")
+	}
+	if s.NetworkName == "" {
+		return errors.New("networkName is required")
+	}
+	if s.Time == 0 {
+		return errors.New("genesis time is required")
+	}
+	if len(s.Validators) == 0 {
+		return errors.New("at least one validator is required")
+	}
+	validators := make(map[idx.ValidatorID]bool, len(s.Validators))
+	for _, v := range s.Validators {
+		if v.ID == 0 {
+			return errors.New("validator ID must be positive")
+		}
+		if validators[v.ID] {
+			return fmt.Errorf("duplicate validator %d", v.ID)
+		}
+		validators[v.ID] = true
+		if v.PubKey.Empty() {
+			return fmt.Errorf("validator %d: pubkey is required", v.ID)
+		}
+		if _, err := v.validatorAddress(); err != nil {
+			return err
+		}
+		if specBig(v.Stake).Sign() <= 0 {
+			return fmt.Errorf("validator %d: stake must be positive", v.ID)
+		}
+	}
+	for i, d := range s.Delegations {
+		if !validators[d.ValidatorID] {
+			return fmt.Errorf("delegation %d: unknown validator %d", i, d.ValidatorID)
+		}
+		if specBig(d.Stake).Sign() <= 0 {
+			return fmt.Errorf("delegation %d: stake must be positive", i)
+		}
+	}
+	for addr, acc := range s.Accounts {
+		if IsSystemContract(addr) {
+			return fmt.Errorf("account %s: address is reserved by a system contract", addr.Hex())
+		}
+		if specBig(acc.Balance).Sign() < 0 {
+			return fmt.Errorf("account %s: negative balance", addr.Hex())
+		}
+	}
+	return nil
+}
+
+// BuildSpecGenesisStore builds the genesis of a custom network described by the spec.
+func BuildSpecGenesisStore(spec *Spec) (*genesisstore.Store, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	rules := spec.Rules.Copy()
+	rules.NetworkID = spec.NetworkID
+	rules.Name = spec.NetworkName
+	genesisTime := native.Timestamp(spec.Time * uint64(time.Second))
+
+	builder := NewGenesisBuilder(memorydb.NewProducer(""))
+
+	// pre-funded accounts and predeployed contracts
+	for addr, acc := range spec.Accounts {
+		builder.AddBalance(addr, specBig(acc.Balance))
+		if len(acc.Code) != 0 {
+			builder.SetCode(addr, acc.Code)
+		}
+		if acc.Nonce != 0 {
+			builder.SetNonce(addr, acc.Nonce)
+		}
+		for key, val := range acc.Storage {
+			builder.SetStorage(addr, key, val)
+		}
+	}
+
+	// validators with their self-stakes
+	validators := make(gpos.Validators, 0, len(spec.Validators))
+	delegations := make([]drivercall.Delegation, 0, len(spec.Validators)+len(spec.Delegations))
+	for _, v := range spec.Validators {
+		addr, _ := v.validatorAddress()
+		validators = append(validators, gpos.Validator{
+			ID:            v.ID,
+			Address:       addr,
+			PubKey:        v.PubKey.Copy(),
+			CreationTime:  genesisTime,
+			CreationEpoch: 0,
+		})
+		delegations = append(delegations, newSpecDelegation(addr, v.ID, specBig(v.Stake)))
+	}
+	for _, d := range spec.Delegations {
+		delegations = append(delegations, newSpecDelegation(d.Address, d.ValidatorID, specBig(d.Stake)))
+	}
+
+	builder.SetSystemContracts()
+
+	builder.SetCurrentEpoch(ier.LlrIdxFullEpochRecord{
+		LlrFullEpochRecord: ier.LlrFullEpochRecord{
+			BlockState: iblockproc.BlockState{
+				LastBlock: iblockproc.BlockCtx{
+					Idx:     specFirstBlock - 1,
+					Time:    genesisTime,
+					Atropos: hash.Event{},
+				},
+				FinalizedStateRoot:    hash.Hash{},
+				EpochCheaters:         utypes.Cheaters{},
+				ValidatorStates:       make([]iblockproc.ValidatorBlockState, 0),
+				NextValidatorProfiles: make(map[idx.ValidatorID]drivertype.Validator),
+			},
+			EpochState: iblockproc.EpochState{
+				Epoch:             specFirstEpoch - 1,
+				EpochStart:        genesisTime,
+				PrevEpochStart:    genesisTime - 1,
+				EpochStateRoot:    hash.Zero,
+				Validators:        pos.NewBuilder().Build(),
+				ValidatorStates:   make([]iblockproc.ValidatorEpochState, 0),
+				ValidatorProfiles: make(map[idx.ValidatorID]drivertype.Validator),
+				Rules:             rules,
+			},
+		},
+		Idx: specFirstEpoch - 1,
+	})
+
+	owner := validators[0].Address
+	if spec.DriverOwner != nil {
+		owner = *spec.DriverOwner
+	}
+
+	genesisTxs := GetGenesisTxs(specFirstEpoch-2, validators, builder.TotalSupply(), delegations, owner)
+	if err := builder.ExecuteGenesisTxs(DefaultBlockProc(), genesisTxs); err != nil {
+		return nil, err
+	}
+
+	return builder.Build(genesis.Header{
+		GenesisID:   builder.CurrentHash(),
+		NetworkID:   rules.NetworkID,
+		NetworkName: rules.Name,
+	}), nil
+}
+
+func newSpecDelegation(addr common.Address, validatorID idx.ValidatorID, stake *big.Int) drivercall.Delegation {
+	return drivercall.Delegation{
+		Address:            addr,
+		ValidatorID:        validatorID,
+		Stake:              stake,
+		LockedStake:        new(big.Int),
+		EarlyUnlockPenalty: new(big.Int),
+		Rewards:            new(big.Int),
+	}
+}
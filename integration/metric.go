@@ -10,6 +10,7 @@ import (
 	"github.com/sesanetwork/go-vassalo/sesadb"
 	"github.com/sesanetwork/go-sesa/log"
 	"github.com/sesanetwork/go-sesa/metrics"
+	"github.com/sesanetwork/go-sesa/utils/dbutil/checkpoint"
 )
 
 const (
@@ -63,6 +64,10 @@ func (ds *StoreWithMetrics) Close() error {
 	return ds.Store.Close()
 }
 
+func (ds *StoreWithMetrics) Checkpoint(dir string) error {
+	return checkpoint.WriteCheckpoint(ds.Store, dir)
+}
+
 func (ds *StoreWithMetrics) meter(refresh time.Duration) {
 	// Create storage for iostats.
 	var iostats [2]float64
@@ -0,0 +1,108 @@
+package simulation
+
+import (
+	"fmt"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+)
+
+// CheckFinality checks that the given nodes (all the nodes if none are given) have decided
+// the same blocks after the genesis, up to the latest block decided by all of them.
+func (n *Network) CheckFinality(nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = n.nodes
+	}
+	last := nodes[0].Store.GetLatestBlockIndex()
+	for _, node := range nodes[1:] {
+		if b := node.Store.GetLatestBlockIndex(); b < last {
+			last = b
+		}
+	}
+	for b := nodes[0].genesis.LowestBlockToDecide; b <= last; b++ {
+		var (
+			expect *hash.Hash
+			first  *Node
+		)
+		for _, node := range nodes {
+			record := node.Store.GetBlockRecordHash(b)
+			if record == nil {
+				return fmt.Errorf("block %d is missing on %s", b, node.Name)
+			}
+			if expect == nil {
+				expect, first = record, node
+				continue
+			}
+			if *record != *expect {
+				return fmt.Errorf("block %d mismatch: %s on %s, %s on %s", b, expect, first.Name, record, node.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLlrVotes checks that the LLR votes on the given nodes (all the nodes if none are given)
+// have decided the same block and epoch records, which match the records the nodes have processed.
+// Only the records after the genesis, which are decided by all the nodes, are checked.
+func (n *Network) CheckLlrVotes(nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = n.nodes
+	}
+	lowest := nodes[0].Store.GetLlrState()
+	for _, node := range nodes[1:] {
+		llrs := node.Store.GetLlrState()
+		if llrs.LowestBlockToDecide < lowest.LowestBlockToDecide {
+			lowest.LowestBlockToDecide = llrs.LowestBlockToDecide
+		}
+		if llrs.LowestEpochToDecide < lowest.LowestEpochToDecide {
+			lowest.LowestEpochToDecide = llrs.LowestEpochToDecide
+		}
+	}
+
+	for b := nodes[0].genesis.LowestBlockToDecide; b < lowest.LowestBlockToDecide; b++ {
+		err := checkLlrResults(nodes, fmt.Sprintf("block %d", b), func(node *Node) (*hash.Hash, *hash.Hash) {
+			return node.Store.GetLlrBlockResult(b), node.Store.GetBlockRecordHash(b)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for e := nodes[0].genesis.LowestEpochToDecide; e < lowest.LowestEpochToDecide; e++ {
+		err := checkLlrResults(nodes, fmt.Sprintf("epoch %d", e), func(node *Node) (*hash.Hash, *hash.Hash) {
+			var record *hash.Hash
+			if er := node.Store.GetFullEpochRecord(e); er != nil {
+				h := er.Hash()
+				record = &h
+			}
+			return node.Store.GetLlrEpochResult(e), record
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLlrResults checks that the nodes have the same LLR voting result, which matches the record of every node.
+func checkLlrResults(nodes []*Node, name string, get func(*Node) (result, record *hash.Hash)) error {
+	var (
+		expect *hash.Hash
+		first  *Node
+	)
+	for _, node := range nodes {
+		result, record := get(node)
+		if result == nil {
+			return fmt.Errorf("%s LLR result is missing on %s", name, node.Name)
+		}
+		if record == nil || *record != *result {
+			return fmt.Errorf("%s LLR result %s doesn't match the record %v on %s", name, result, record, node.Name)
+		}
+		if expect == nil {
+			expect, first = result, node
+			continue
+		}
+		if *result != *expect {
+			return fmt.Errorf("%s LLR result mismatch: %s on %s, %s on %s", name, expect, first.Name, result, node.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+// Package simulation runs a network of fake validators in-process, connected by the
+// in-memory pipes of p2p/simulations, to test the gossip protocol and the consensus
+// under the network partitions, latency and byzantine validators.
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/p2p/enode"
+	"github.com/sesanetwork/go-sesa/p2p/simulations"
+	"github.com/sesanetwork/go-sesa/p2p/simulations/adapters"
+	"github.com/sesanetwork/go-sesa/p2p/simulations/pipes"
+
+	"github.com/sesanetwork/go-sesa/gossip/emitter"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+const (
+	// serviceName is the name of the gossip service in the simulation nodes
+	serviceName = "sesa"
+	// forkTimeout is a max time to wait for the forking validators to create forks at startup
+	forkTimeout = 10 * time.Second
+	// pollInterval is an interval of checking the nodes state while waiting for a condition
+	pollInterval = 50 * time.Millisecond
+)
+
+// ErrTimeout is returned if the awaited condition isn't met in time.
+var ErrTimeout = errors.New("simulation timeout")
+
+// Config is the configuration of the simulated network.
+type Config struct {
+	// Validators is a number of the genesis validators, which have equal stakes
+	Validators idx.Validator
+	// Silent validators don't emit events, while their nodes are online
+	Silent []idx.ValidatorID
+	// Forking validators are run by two nodes each, which emit events independently,
+	// so the validators create forks
+	Forking []idx.ValidatorID
+	// Latency is the initial delay of every message between the nodes
+	Latency time.Duration
+
+	Rules         sesa.Rules
+	EmitIntervals emitter.EmitIntervals
+}
+
+// DefaultConfig returns the configuration of the fakenet with the given number of validators.
+func DefaultConfig(validators idx.Validator) Config {
+	rules := sesa.FakeNetRules()
+	// every decided frame creates a block
+	rules.Blocks.MaxEmptyBlockSkipPeriod = 0
+	return Config{
+		Validators: validators,
+		Rules:      rules,
+		EmitIntervals: emitter.EmitIntervals{
+			Min:        50 * time.Millisecond,
+			Max:        200 * time.Millisecond,
+			Confirming: 50 * time.Millisecond,
+			// no doublesign protections, as they pause the emitting of the forking validators
+			DoublesignProtection:       0,
+			ParallelInstanceProtection: 0,
+		},
+	}
+}
+
+// Network is a simulated network of the gossip services.
+// Nodes are fully connected, unless the network is partitioned.
+type Network struct {
+	cfg Config
+
+	sim   *simulations.Network
+	pipe  func() (net.Conn, net.Conn, error)
+	nodes []*Node
+	byID  map[enode.ID]*Node
+
+	latency int64 // time.Duration, accessed atomically
+
+	mu        sync.Mutex
+	partition map[*Node]int // partition group of the nodes, the nodes of the same group are connected
+}
+
+// New starts the simulated network, and connects the nodes together.
+func New(cfg Config) (*Network, error) {
+	for _, vid := range append(append([]idx.ValidatorID{}, cfg.Silent...), cfg.Forking...) {
+		if vid == 0 || uint64(vid) > uint64(cfg.Validators) {
+			return nil, fmt.Errorf("validator %d is not in the genesis", vid)
+		}
+	}
+
+	n := &Network{
+		cfg:       cfg,
+		byID:      make(map[enode.ID]*Node),
+		partition: make(map[*Node]int),
+	}
+	n.SetLatency(cfg.Latency)
+	n.pipe = pipes.LatencyPipe(n.Latency)
+	adapter := adapters.NewSimAdapterWithPipe(adapters.LifecycleConstructors{
+		serviceName: n.newService,
+	}, n.pipe)
+	n.sim = simulations.NewNetwork(adapter, &simulations.NetworkConfig{
+		ID:             "sesa-simulation",
+		DefaultService: serviceName,
+	})
+
+	for vid := idx.ValidatorID(1); uint64(vid) <= uint64(cfg.Validators); vid++ {
+		if err := n.addNode(vid, false); err != nil {
+			n.Close()
+			return nil, err
+		}
+	}
+	for _, vid := range cfg.Forking {
+		if err := n.addNode(vid, true); err != nil {
+			n.Close()
+			return nil, err
+		}
+	}
+	if err := n.sim.StartAll(); err != nil {
+		n.Close()
+		return nil, err
+	}
+
+	// the isolated instances of a forking validator emit their first events independently
+	if len(cfg.Forking) != 0 {
+		err := n.Wait(forkTimeout, func(node *Node) bool {
+			return !node.Forking() || node.Store.GetLastEvent(node.Store.GetEpoch(), node.Validator) != nil
+		})
+		if err != nil {
+			n.Close()
+			return nil, err
+		}
+	}
+	if err := n.Heal(); err != nil {
+		n.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *Network) addNode(vid idx.ValidatorID, twin bool) error {
+	conf := adapters.RandomNodeConfig()
+	conf.Name = fmt.Sprintf("validator-%d", vid)
+	if twin {
+		conf.Name += "-twin"
+	}
+	conf.Lifecycles = []string{serviceName}
+	conf.EnableMsgEvents = false
+
+	node := &Node{
+		Name:      conf.Name,
+		Validator: vid,
+		twin:      twin,
+		forking:   containsValidator(n.cfg.Forking, vid),
+		silent:    containsValidator(n.cfg.Silent, vid),
+		id:        conf.ID,
+	}
+	// register the node before its creation, as the service constructor looks it up
+	n.nodes = append(n.nodes, node)
+	n.byID[conf.ID] = node
+
+	simNode, err := n.sim.NewNodeWithConfig(conf)
+	if err != nil {
+		return err
+	}
+	node.sim = simNode.Node.(*adapters.SimNode)
+	return nil
+}
+
+// Close stops the nodes and releases the in-memory databases.
+func (n *Network) Close() {
+	n.sim.Shutdown()
+	for _, node := range n.nodes {
+		node.close()
+	}
+}
+
+// Nodes returns all the nodes, including the second instances of the forking validators.
+func (n *Network) Nodes() []*Node {
+	return append([]*Node{}, n.nodes...)
+}
+
+// Validator returns the node of the validator, or nil if the validator isn't in the genesis.
+// For a forking validator, it's the first of two instances.
+func (n *Network) Validator(vid idx.ValidatorID) *Node {
+	for _, node := range n.nodes {
+		if node.Validator == vid && !node.twin {
+			return node
+		}
+	}
+	return nil
+}
+
+// Honest returns the nodes of the validators which are neither silent nor forking.
+func (n *Network) Honest() []*Node {
+	nodes := make([]*Node, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		if !node.silent && !node.forking {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Latency returns the current delay of every message between the nodes.
+func (n *Network) Latency() time.Duration {
+	return time.Duration(atomic.LoadInt64(&n.latency))
+}
+
+// SetLatency changes the delay of every message between the nodes, including the existing connections.
+func (n *Network) SetLatency(latency time.Duration) {
+	atomic.StoreInt64(&n.latency, int64(latency))
+}
+
+// Partition splits the network into the isolated groups of nodes, which are connected only inside the group.
+// Nodes which aren't in the given groups form one more group. Previous partitioning is overridden.
+func (n *Network) Partition(groups ...[]*Node) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.partition = make(map[*Node]int)
+	for i, group := range groups {
+		for _, node := range group {
+			n.partition[node] = i + 1
+		}
+	}
+	return n.reconnect()
+}
+
+// Heal removes the network partitions, so all the nodes get connected.
+func (n *Network) Heal() error {
+	return n.Partition()
+}
+
+// reconnect connects the nodes of the same partition group, and disconnects the rest.
+func (n *Network) reconnect() error {
+	for i, a := range n.nodes {
+		for _, b := range n.nodes[i+1:] {
+			connected := a.connected(b)
+			if n.partition[a] != n.partition[b] {
+				if connected {
+					a.disconnect(b)
+				}
+				continue
+			}
+			if !connected {
+				if err := n.connect(a, b); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// connect sets up the connection between the nodes directly, bypassing the dialing,
+// so the nodes aren't redialed after they are disconnected by a partition.
+func (n *Network) connect(a, b *Node) error {
+	p1, p2, err := n.pipe()
+	if err != nil {
+		return err
+	}
+	// both sides are set up concurrently, as the handshakes wait for each other
+	errc := make(chan error, 2)
+	go func() {
+		if err := b.sim.Server().SetupConn(p1, 0, nil); err != nil {
+			errc <- fmt.Errorf("%s failed to accept %s: %v", b.Name, a.Name, err)
+			return
+		}
+		errc <- nil
+	}()
+	go func() {
+		if err := a.sim.Server().SetupConn(p2, 0, b.sim.Node()); err != nil {
+			errc <- fmt.Errorf("failed to connect %s to %s: %v", a.Name, b.Name, err)
+			return
+		}
+		errc <- nil
+	}()
+	return errors.Join(<-errc, <-errc)
+}
+
+// Wait waits until the condition is met by all the given nodes, or by all the nodes
+// of the network if none are given.
+func (n *Network) Wait(timeout time.Duration, cond func(*Node) bool, nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = n.nodes
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		met := true
+		for _, node := range nodes {
+			if !cond(node) {
+				met = false
+				break
+			}
+		}
+		if met {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// WaitBlock waits until the block is decided by the given nodes.
+func (n *Network) WaitBlock(block idx.Block, timeout time.Duration, nodes ...*Node) error {
+	return n.Wait(timeout, func(node *Node) bool {
+		return node.Store.GetLatestBlockIndex() >= block
+	}, nodes...)
+}
+
+// WaitLlrBlock waits until the block record is decided by the LLR votes on the given nodes.
+func (n *Network) WaitLlrBlock(block idx.Block, timeout time.Duration, nodes ...*Node) error {
+	return n.Wait(timeout, func(node *Node) bool {
+		return node.Store.GetLlrState().LowestBlockToDecide > block
+	}, nodes...)
+}
+
+func containsValidator(vids []idx.ValidatorID, vid idx.ValidatorID) bool {
+	for _, v := range vids {
+		if v == vid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/logger"
+)
+
+const timeout = time.Minute
+
+// firstBlock returns the first block decided after the genesis.
+func firstBlock(net *Network) idx.Block {
+	return net.nodes[0].genesis.LowestBlockToDecide
+}
+
+func latestBlock(nodes []*Node) idx.Block {
+	var latest idx.Block
+	for _, node := range nodes {
+		if b := node.Store.GetLatestBlockIndex(); b > latest {
+			latest = b
+		}
+	}
+	return latest
+}
+
+func TestNetwork_Finality(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := DefaultConfig(4)
+	cfg.Latency = 10 * time.Millisecond
+	net, err := New(cfg)
+	require.NoError(err)
+	defer net.Close()
+
+	require.NoError(net.WaitBlock(firstBlock(net)+5, timeout))
+	require.NoError(net.CheckFinality())
+
+	// the blocks are still decided with a higher latency
+	net.SetLatency(100 * time.Millisecond)
+	require.NoError(net.WaitBlock(latestBlock(net.Nodes())+5, timeout))
+	require.NoError(net.CheckFinality())
+
+	// the block votes are cast at least once an epoch is sealed
+	require.NoError(net.WaitLlrBlock(firstBlock(net)+5, timeout))
+	require.NoError(net.CheckLlrVotes())
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	net, err := New(DefaultConfig(4))
+	require.NoError(err)
+	defer net.Close()
+
+	require.NoError(net.WaitBlock(firstBlock(net)+3, timeout))
+
+	nodes := net.Nodes()
+	majority, minority := nodes[:3], nodes[3:]
+	require.NoError(net.Partition(majority, minority))
+	require.Equal(0, minority[0].Peers())
+	for _, node := range majority {
+		require.Equal(2, node.Peers())
+	}
+
+	// the majority keeps deciding blocks, while the minority stalls
+	require.NoError(net.WaitBlock(latestBlock(nodes)+5, timeout, majority...))
+	require.NoError(net.CheckFinality(majority...))
+	require.Less(uint64(latestBlock(minority)), uint64(latestBlock(majority)))
+
+	// the minority catches up once the partition is healed
+	require.NoError(net.Heal())
+	require.NoError(net.WaitBlock(latestBlock(majority)+3, timeout))
+	require.NoError(net.CheckFinality())
+	require.NoError(net.WaitLlrBlock(firstBlock(net)+5, timeout))
+	require.NoError(net.CheckLlrVotes())
+}
+
+func TestNetwork_SilentValidator(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := DefaultConfig(4)
+	cfg.Silent = []idx.ValidatorID{4}
+	net, err := New(cfg)
+	require.NoError(err)
+	defer net.Close()
+
+	// 3/4 of the validators are enough to decide blocks, and the silent validator keeps syncing
+	require.NoError(net.WaitBlock(firstBlock(net)+5, timeout))
+	require.NoError(net.CheckFinality())
+	require.NoError(net.WaitLlrBlock(firstBlock(net)+5, timeout))
+	require.NoError(net.CheckLlrVotes())
+
+	for _, node := range net.Nodes() {
+		require.Nil(node.Store.GetLastEvent(node.Store.GetEpoch(), 4), node.Name)
+	}
+
+	// one more offline validator halts the network
+	net.Validator(3).Silence()
+	require.Len(net.Honest(), 2)
+	stalled := latestBlock(net.Nodes()) + 5
+	require.ErrorIs(net.WaitBlock(stalled, 5*time.Second, net.Validator(1)), ErrTimeout)
+}
+
+func TestNetwork_ForkingValidator(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	cfg := DefaultConfig(4)
+	cfg.Forking = []idx.ValidatorID{4}
+	net, err := New(cfg)
+	require.NoError(err)
+	defer net.Close()
+
+	honest := net.Honest()
+	require.Len(honest, 3)
+	require.Len(net.Nodes(), 5)
+
+	// the forks are detected, and the cheater is deactivated since the next epoch
+	require.NoError(net.Wait(timeout, func(node *Node) bool {
+		return !node.Store.GetValidators().Exists(4)
+	}, honest...))
+
+	// the honest validators keep deciding blocks
+	require.NoError(net.WaitBlock(latestBlock(honest)+5, timeout, honest...))
+	require.NoError(net.CheckFinality(honest...))
+	require.NoError(net.WaitLlrBlock(firstBlock(net)+5, timeout, honest...))
+	require.NoError(net.CheckLlrVotes(honest...))
+}
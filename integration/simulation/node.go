@@ -0,0 +1,111 @@
+package simulation
+
+import (
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/node"
+	"github.com/sesanetwork/go-sesa/p2p/enode"
+	"github.com/sesanetwork/go-sesa/p2p/simulations/adapters"
+
+	"github.com/sesanetwork/go-sesa/gossip"
+	"github.com/sesanetwork/go-sesa/integration"
+	"github.com/sesanetwork/go-sesa/integration/makefakegenesis"
+	"github.com/sesanetwork/go-sesa/utils"
+)
+
+const (
+	genesisBalance = 1e18
+	genesisStake   = 2 * 4e6
+)
+
+// Node is a simulation node, which runs the gossip service of a validator.
+type Node struct {
+	Name      string
+	Validator idx.ValidatorID
+
+	// Store and Service are set once the node is started
+	Store   *gossip.Store
+	Service *gossip.Service
+
+	twin    bool // the second instance of a forking validator
+	forking bool
+	silent  bool
+
+	genesis gossip.LlrState // the first block and epoch to decide after the genesis
+
+	id        enode.ID
+	sim       *adapters.SimNode
+	validator *integration.FakeValidator
+}
+
+// Forking returns true if the node is one of two instances of a forking validator.
+func (node *Node) Forking() bool {
+	return node.forking
+}
+
+// Silent returns true if the node doesn't emit events.
+func (node *Node) Silent() bool {
+	return node.silent
+}
+
+// Silence stops the events emitting, so the validator goes offline while its node keeps syncing.
+func (node *Node) Silence() {
+	if node.validator != nil && node.validator.Emitter != nil {
+		node.validator.Emitter.Stop()
+	}
+	node.silent = true
+}
+
+// Peers returns a number of the connected peers.
+func (node *Node) Peers() int {
+	return node.sim.Server().PeerCount()
+}
+
+func (node *Node) connected(other *Node) bool {
+	for _, p := range node.sim.Server().Peers() {
+		if p.ID() == other.id {
+			return true
+		}
+	}
+	return false
+}
+
+func (node *Node) disconnect(other *Node) {
+	// RemovePeer waits until the peer is dropped, so both sides are awaited
+	node.sim.Server().RemovePeer(other.sim.Node())
+	other.sim.Server().RemovePeer(node.sim.Node())
+}
+
+func (node *Node) close() {
+	if node.validator != nil {
+		node.validator.Close()
+	}
+}
+
+// newService is a LifecycleConstructor of the gossip service, which assembles the node
+// from the fake genesis, like a fakenet node.
+func (n *Network) newService(ctx *adapters.ServiceContext, stack *node.Node) (node.Lifecycle, error) {
+	nd := n.byID[ctx.Config.ID]
+
+	genStore := makefakegenesis.FakeGenesisStoreWithRules(n.cfg.Validators, utils.Tosesa(genesisBalance), utils.Tosesa(genesisStake), n.cfg.Rules)
+	defer genStore.Close()
+
+	validator, err := integration.NewFakeValidator(stack, genStore.Genesis(), integration.FakeValidatorConfig{
+		ID:            nd.Validator,
+		Silent:        nd.silent,
+		EmitIntervals: n.cfg.EmitIntervals,
+		Logger:        log.New("node", nd.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	nd.validator = validator
+	nd.Store = validator.Store
+	nd.Service = validator.Service
+	nd.genesis = nd.Store.GetLlrState()
+
+	stack.RegisterAPIs(nd.Service.APIs())
+	stack.RegisterProtocols(nd.Service.Protocols())
+	stack.RegisterLifecycle(nd.Service)
+	return nd.Service, nil
+}
@@ -19,11 +19,14 @@ package web3ext
 
 var Modules = map[string]string{
 	"admin":    AdminJs,
+	"abft":     AbftJs,
+	"dag":      DagJs,
 	"debug":    DebugJs,
-	"sesa":      sesaJs,
+	"eth":      sesaJs, // the eth namespace is exposed as 'sesa' in the console
 	"net":      NetJs,
 	"personal": PersonalJs,
 	"rpc":      RpcJs,
+	"trace":    TraceJs,
 	"txpool":   TxpoolJs,
 	"les":      LESJs,
 	"vflux":    VfluxJs,
@@ -478,6 +481,43 @@ web3._extend({
 			params: 3,
 			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'getBlockReceipts',
+			call: 'eth_getBlockReceipts',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'lowestAvailableBlock',
+			call: 'eth_lowestAvailableBlock',
+			params: 0,
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'currentEpoch',
+			call: 'eth_currentEpoch',
+			params: 0,
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'getEpochStats',
+			call: 'eth_getEpochStats',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: web3._extend.formatters.outputEpochStatsFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getEpochBlock',
+			call: 'eth_getEpochBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'getRules',
+			call: 'eth_getRules',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -501,6 +541,103 @@ web3._extend({
 });
 `
 
+const DagJs = `
+web3._extend({
+	property: 'dag',
+	methods: [
+		new web3._extend.Method({
+			name: 'getEvent',
+			call: 'dag_getEvent',
+			params: 1,
+			outputFormatter: web3._extend.formatters.outputDagEventFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getEventPayload',
+			call: 'dag_getEventPayload',
+			params: 2,
+			inputFormatter: [null, function (val) { return !!val; }],
+			outputFormatter: web3._extend.formatters.outputDagEventFormatter
+		}),
+		new web3._extend.Method({
+			name: 'getHeads',
+			call: 'dag_getHeads',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+	],
+	properties: []
+});
+`
+
+const AbftJs = `
+web3._extend({
+	property: 'abft',
+	methods: [
+		new web3._extend.Method({
+			name: 'getValidators',
+			call: 'abft_getValidators',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getDowntime',
+			call: 'abft_getDowntime',
+			params: 1,
+			inputFormatter: [web3._extend.utils.toHex],
+			outputFormatter: web3._extend.formatters.outputDecimalProperties
+		}),
+		new web3._extend.Method({
+			name: 'getEpochUptime',
+			call: 'abft_getEpochUptime',
+			params: 1,
+			inputFormatter: [web3._extend.utils.toHex],
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'getOriginatedEpochFee',
+			call: 'abft_getOriginatedEpochFee',
+			params: 1,
+			inputFormatter: [web3._extend.utils.toHex],
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+	],
+	properties: []
+});
+`
+
+const TraceJs = `
+web3._extend({
+	property: 'trace',
+	methods: [
+		new web3._extend.Method({
+			name: 'block',
+			call: 'trace_block',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'transaction',
+			call: 'trace_transaction',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'get',
+			call: 'trace_get',
+			params: 2,
+			inputFormatter: [null, function (indexes) {
+				return indexes.map(web3._extend.utils.toHex);
+			}]
+		}),
+		new web3._extend.Method({
+			name: 'filter',
+			call: 'trace_filter',
+			params: 1
+		}),
+	],
+	properties: []
+});
+`
+
 const NetJs = `
 web3._extend({
 	property: 'net',
@@ -45,6 +45,7 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirJWTSecret       = "jwtsecret"          // Path within the datadir to the JWT secret of the authenticated RPC
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -171,6 +172,37 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// AuthAddr is the listening address on which the authenticated HTTP and WebSocket
+	// RPC is served. If this field is empty, the authenticated endpoint isn't started.
+	AuthAddr string `toml:",omitempty"`
+
+	// AuthPort is the port number on which the authenticated RPC is served.
+	AuthPort int `toml:",omitempty"`
+
+	// AuthVirtualHosts is the list of virtual hostnames which are allowed on incoming requests
+	// for the authenticated RPC.
+	AuthVirtualHosts []string `toml:",omitempty"`
+
+	// AuthModules is a list of API modules to expose via the authenticated RPC interface.
+	// The tokens may restrict the modules further with the "namespaces" claim.
+	AuthModules []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex-encoded 32 bytes secret of the authenticated RPC.
+	// If the file doesn't exist, a random secret is generated.
+	JWTSecret string `toml:",omitempty"`
+
+	// BatchRequestLimit is the maximum number of requests in a batch of the HTTP and
+	// WebSocket RPC endpoints, 0 means no limit.
+	BatchRequestLimit int `toml:",omitempty"`
+
+	// BatchResponseMaxSize is the maximum number of bytes returned from a batched call
+	// of the HTTP and WebSocket RPC endpoints, 0 means no limit.
+	BatchResponseMaxSize int `toml:",omitempty"`
+
+	// RPCRateLimits are the request rate limits of every remote IP of the HTTP and
+	// WebSocket RPC endpoints.
+	RPCRateLimits rpc.RateLimits `toml:",omitempty"`
+
 	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
 	// clients. Please be aware that CORS is a browser enforced security, it's fully
 	// useless for custom HTTP clients.
@@ -220,6 +252,23 @@ func (c *Config) IPCEndpoint() string {
 	return c.IPCPath
 }
 
+// AuthEndpoint resolves the authenticated RPC endpoint based on the configured host
+// interface and port parameters.
+func (c *Config) AuthEndpoint() string {
+	if c.AuthAddr == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.AuthAddr, c.AuthPort)
+}
+
+// JWTSecretPath returns the path to the JWT secret of the authenticated RPC.
+func (c *Config) JWTSecretPath() string {
+	if c.JWTSecret != "" {
+		return c.JWTSecret
+	}
+	return c.ResolvePath(datadirJWTSecret)
+}
+
 // NodeDB returns the path to the discovery node database.
 func (c *Config) NodeDB() string {
 	if c.DataDir == "" {
@@ -0,0 +1,83 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/rpc"
+)
+
+// NewJWTAuth creates an rpc client authentication provider, which signs a fresh
+// HS256 token with the secret for every request.
+// If namespaces are given, the token is restricted to them.
+func NewJWTAuth(secret [32]byte, namespaces ...string) rpc.HTTPAuth {
+	return func(h http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+			Namespaces: namespaces,
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return fmt.Errorf("failed to create JWT token: %w", err)
+		}
+		h.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
+// LoadJWTSecret reads the hex encoded 32 bytes secret from the file.
+// If the file doesn't exist and create is true, a random secret is generated and stored.
+func LoadJWTSecret(fileName string, create bool) (secret [32]byte, err error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err == nil {
+		hexSecret := strings.TrimSpace(string(data))
+		b, err := hexutil.Decode("0x" + strings.TrimPrefix(hexSecret, "0x"))
+		if err != nil || len(b) != len(secret) {
+			return secret, fmt.Errorf("invalid JWT secret in %s, expected 32 hex encoded bytes", fileName)
+		}
+		copy(secret[:], b)
+		return secret, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return secret, err
+	}
+	if _, err = rand.Read(secret[:]); err != nil {
+		return secret, err
+	}
+	if err = os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return secret, err
+	}
+	if err = ioutil.WriteFile(fileName, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		return secret, err
+	}
+	log.Info("Generated JWT secret", "path", fileName)
+	return secret, nil
+}
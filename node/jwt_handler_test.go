@@ -0,0 +1,116 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/sesanetwork/go-sesa/internal/testlog"
+	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/rpc"
+)
+
+type jwtTestService struct{}
+
+func (jwtTestService) Hello() string { return "hello" }
+
+func createAndStartAuthServer(t *testing.T, secret [32]byte) *httpServer {
+	t.Helper()
+
+	apis := []rpc.API{
+		{Namespace: "debug", Service: jwtTestService{}},
+		{Namespace: "admin", Service: jwtTestService{}},
+	}
+	srv := newHTTPServer(testlog.Logger(t, log.LvlDebug), rpc.DefaultHTTPTimeouts)
+	require.NoError(t, srv.enableRPC(apis, httpConfig{Modules: []string{"debug", "admin"}, Vhosts: []string{"*"}, jwtSecret: secret[:]}))
+	require.NoError(t, srv.enableWS(apis, wsConfig{Modules: []string{"debug", "admin"}, Origins: []string{"*"}, jwtSecret: secret[:]}))
+	require.NoError(t, srv.setListenAddr("localhost", 0))
+	require.NoError(t, srv.start())
+	return srv
+}
+
+func issueToken(secret [32]byte, iat time.Time) rpc.HTTPAuth {
+	return func(h http.Header) error {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(iat),
+		})
+		s, err := token.SignedString(secret[:])
+		if err != nil {
+			return err
+		}
+		h.Set("Authorization", "Bearer "+s)
+		return nil
+	}
+}
+
+func TestJWTAuth(t *testing.T) {
+	secret := [32]byte{1, 2, 3}
+	srv := createAndStartAuthServer(t, secret)
+	defer srv.stop()
+
+	for _, scheme := range []string{"http", "ws"} {
+		url := fmt.Sprintf("%s://%s", scheme, srv.listenAddr())
+		call := func(auth rpc.HTTPAuth, method string) error {
+			var opts []rpc.ClientOption
+			if auth != nil {
+				opts = append(opts, rpc.WithHTTPAuth(auth))
+			}
+			c, err := rpc.DialOptions(context.Background(), url, opts...)
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+			var res string
+			return c.Call(&res, method)
+		}
+
+		require.NoError(t, call(NewJWTAuth(secret), "debug_hello"), scheme)
+		require.NoError(t, call(NewJWTAuth(secret), "admin_hello"), scheme)
+
+		require.Error(t, call(nil, "debug_hello"), scheme)
+		require.Error(t, call(NewJWTAuth([32]byte{4, 5, 6}), "debug_hello"), scheme)
+		require.Error(t, call(issueToken(secret, time.Now().Add(-2*jwtIatSkew)), "debug_hello"), scheme)
+		require.Error(t, call(issueToken(secret, time.Now().Add(2*jwtIatSkew)), "debug_hello"), scheme)
+		require.NoError(t, call(issueToken(secret, time.Now().Add(jwtIatSkew/2)), "debug_hello"), scheme)
+
+		// namespaces claim
+		require.NoError(t, call(NewJWTAuth(secret, "debug"), "debug_hello"), scheme)
+		require.Error(t, call(NewJWTAuth(secret, "debug"), "admin_hello"), scheme)
+	}
+}
+
+func TestLoadJWTSecret(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "jwtsecret")
+
+	_, err := LoadJWTSecret(fn, false)
+	require.Error(t, err)
+
+	generated, err := LoadJWTSecret(fn, true)
+	require.NoError(t, err)
+	require.NotEqual(t, [32]byte{}, generated)
+
+	loaded, err := LoadJWTSecret(fn, false)
+	require.NoError(t, err)
+	require.Equal(t, generated, loaded)
+}
@@ -54,6 +54,7 @@ type Node struct {
 	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
 	http          *httpServer //
 	ws            *httpServer //
+	httpAuth      *httpServer // Serves the authenticated HTTP and WebSocket RPC
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
 
@@ -146,6 +147,7 @@ func New(conf *Config) (*Node, error) {
 	// Configure RPC servers.
 	node.http = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
+	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
 	return node, nil
@@ -348,6 +350,12 @@ func (n *Node) startRPC() error {
 		}
 	}
 
+	rpcConfig := rpcEndpointConfig{
+		batchItemLimit:         n.config.BatchRequestLimit,
+		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimits:             n.config.RPCRateLimits,
+	}
+
 	// Configure HTTP.
 	if n.config.HTTPHost != "" {
 		config := httpConfig{
@@ -355,6 +363,7 @@ func (n *Node) startRPC() error {
 			Vhosts:             n.config.HTTPVirtualHosts,
 			Modules:            n.config.HTTPModules,
 			prefix:             n.config.HTTPPathPrefix,
+			rpcEndpointConfig:  rpcConfig,
 		}
 		if err := n.http.setListenAddr(n.config.HTTPHost, n.config.HTTPPort); err != nil {
 			return err
@@ -368,9 +377,10 @@ func (n *Node) startRPC() error {
 	if n.config.WSHost != "" {
 		server := n.wsServerForPort(n.config.WSPort)
 		config := wsConfig{
-			Modules: n.config.WSModules,
-			Origins: n.config.WSOrigins,
-			prefix:  n.config.WSPathPrefix,
+			Modules:           n.config.WSModules,
+			Origins:           n.config.WSOrigins,
+			prefix:            n.config.WSPathPrefix,
+			rpcEndpointConfig: rpcConfig,
 		}
 		if err := server.setListenAddr(n.config.WSHost, n.config.WSPort); err != nil {
 			return err
@@ -380,12 +390,49 @@ func (n *Node) startRPC() error {
 		}
 	}
 
+	// Configure authenticated HTTP and WebSocket.
+	if n.config.AuthAddr != "" {
+		if err := n.startAuthRPC(rpcConfig); err != nil {
+			return err
+		}
+	}
+
 	if err := n.http.start(); err != nil {
 		return err
 	}
 	return n.ws.start()
 }
 
+// startAuthRPC starts the JWT authenticated RPC endpoint, which serves both HTTP and WebSocket.
+func (n *Node) startAuthRPC(rpcConfig rpcEndpointConfig) error {
+	secret, err := LoadJWTSecret(n.config.JWTSecretPath(), true)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT secret: %w", err)
+	}
+	if err := n.httpAuth.setListenAddr(n.config.AuthAddr, n.config.AuthPort); err != nil {
+		return err
+	}
+	httpConfig := httpConfig{
+		Vhosts:            n.config.AuthVirtualHosts,
+		Modules:           n.config.AuthModules,
+		jwtSecret:         secret[:],
+		rpcEndpointConfig: rpcConfig,
+	}
+	if err := n.httpAuth.enableRPC(n.rpcAPIs, httpConfig); err != nil {
+		return err
+	}
+	wsConfig := wsConfig{
+		Modules:           n.config.AuthModules,
+		Origins:           n.config.WSOrigins,
+		jwtSecret:         secret[:],
+		rpcEndpointConfig: rpcConfig,
+	}
+	if err := n.httpAuth.enableWS(n.rpcAPIs, wsConfig); err != nil {
+		return err
+	}
+	return n.httpAuth.start()
+}
+
 func (n *Node) wsServerForPort(port int) *httpServer {
 	if n.config.HTTPHost == "" || n.http.port == port {
 		return n.http
@@ -396,6 +443,7 @@ func (n *Node) wsServerForPort(port int) *httpServer {
 func (n *Node) stopRPC() {
 	n.http.stop()
 	n.ws.stop()
+	n.httpAuth.stop()
 	n.ipc.stop()
 	n.stopInProc()
 }
@@ -530,6 +578,11 @@ func (n *Node) HTTPEndpoint() string {
 	return "http://" + n.http.listenAddr()
 }
 
+// AuthEndpoint returns the URL of the authenticated HTTP server.
+func (n *Node) AuthEndpoint() string {
+	return "http://" + n.httpAuth.listenAddr()
+}
+
 // WSEndpoint returns the current JSON-RPC over WebSocket endpoint.
 func (n *Node) WSEndpoint() string {
 	if n.http.wsAllowed() {
@@ -55,6 +55,17 @@ func NewSimAdapter(services LifecycleConstructors) *SimAdapter {
 	}
 }
 
+// NewSimAdapterWithPipe creates a SimAdapter which connects the nodes using
+// the pipes created by the given constructor instead of a plain net.Pipe
+// (e.g. pipes.LatencyPipe to simulate the network latency)
+func NewSimAdapterWithPipe(services LifecycleConstructors, pipe func() (net.Conn, net.Conn, error)) *SimAdapter {
+	return &SimAdapter{
+		pipe:       pipe,
+		nodes:      make(map[enode.ID]*SimNode),
+		lifecycles: services,
+	}
+}
+
 // Name returns the name of the adapter for logging purposes
 func (s *SimAdapter) Name() string {
 	return "sim-adapter"
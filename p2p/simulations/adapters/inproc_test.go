@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/sesanetwork/go-sesa/p2p/simulations/pipes"
 )
@@ -200,3 +201,31 @@ func TestNetPipeBidirections(t *testing.T) {
 		}
 	}
 }
+
+func TestLatencyPipe(t *testing.T) {
+	latency := 50 * time.Millisecond
+	c1, c2, err := pipes.LatencyPipe(func() time.Duration { return latency })()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("ping")
+	start := time.Now()
+	// the pipe is blocking, so the write is emitted asynchronously
+	go func() {
+		if _, err := c1.Write(msg); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	out := make([]byte, len(msg))
+	if _, err := c2.Read(out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(msg, out) {
+		t.Fatalf("expected %#v, got %#v", msg, out)
+	}
+	if passed := time.Since(start); passed < latency {
+		t.Fatalf("expected the message to be delayed by %v, got %v", latency, passed)
+	}
+}
@@ -18,6 +18,7 @@ package pipes
 
 import (
 	"net"
+	"time"
 )
 
 // NetPipe wraps net.Pipe in a signature returning an error
@@ -53,3 +54,26 @@ func TCPPipe() (net.Conn, net.Conn, error) {
 	}
 	return aconn, dconn, nil
 }
+
+// LatencyPipe returns a constructor of the in-memory pipes, which delay every
+// write by the latency returned by the given function at the time of writing.
+// Like net.Pipe, the writes are blocking, so the delay also slows down the writer.
+func LatencyPipe(latency func() time.Duration) func() (net.Conn, net.Conn, error) {
+	return func() (net.Conn, net.Conn, error) {
+		p1, p2 := net.Pipe()
+		return &latencyConn{p1, latency}, &latencyConn{p2, latency}, nil
+	}
+}
+
+// latencyConn is a net.Conn which delays the writes
+type latencyConn struct {
+	net.Conn
+	latency func() time.Duration
+}
+
+func (c *latencyConn) Write(b []byte) (int, error) {
+	if d := c.latency(); d > 0 {
+		time.Sleep(d)
+	}
+	return c.Conn.Write(b)
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -77,6 +78,7 @@ type Client struct {
 	idgen    func() ID // for subscriptions
 	isHTTP   bool
 	services *serviceRegistry
+	limits   *serverLimits // limits of the server side connections, nil for clients
 
 	idCounter uint32
 
@@ -111,7 +113,10 @@ type clientConn struct {
 
 func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx := context.WithValue(context.Background(), clientContextKey{}, c)
-	handler := newHandler(ctx, conn, c.idgen, c.services)
+	if wc, ok := conn.(*websocketCodec); ok && wc.namespaces != nil {
+		ctx = WithNamespaces(ctx, wc.namespaces)
+	}
+	handler := newHandler(ctx, conn, c.idgen, c.services, c.limits)
 	return &clientConn{conn, handler}
 }
 
@@ -167,15 +172,28 @@ func Dial(rawurl string) (*Client, error) {
 // The context is used to cancel or time out the initial connection establishment. It does
 // not affect subsequent interactions with the client.
 func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	return DialOptions(ctx, rawurl)
+}
+
+// DialOptions creates a new RPC client for the given URL. You can supply any of the
+// pre-defined client options to configure the underlying transport.
+//
+// The context is used to cancel or time out the initial connection establishment. It does
+// not affect subsequent interactions with the client.
+func DialOptions(ctx context.Context, rawurl string, options ...ClientOption) (*Client, error) {
 	u, err := url.Parse(rawurl)
 	if err != nil {
 		return nil, err
 	}
+	cfg := new(clientConfig)
+	for _, opt := range options {
+		opt.applyOption(cfg)
+	}
 	switch u.Scheme {
 	case "http", "https":
-		return DialHTTP(rawurl)
+		return dialHTTP(rawurl, new(http.Client), cfg.httpAuth)
 	case "ws", "wss":
-		return DialWebsocket(ctx, rawurl, "")
+		return dialWebsocket(ctx, rawurl, "", defaultWebsocketDialer(), cfg.httpAuth)
 	case "stdio":
 		return DialStdIO(ctx)
 	case "":
@@ -197,17 +215,18 @@ func newClient(initctx context.Context, connect reconnectFunc) (*Client, error)
 	if err != nil {
 		return nil, err
 	}
-	c := initClient(conn, randomIDGenerator(), new(serviceRegistry))
+	c := initClient(conn, randomIDGenerator(), new(serviceRegistry), nil)
 	c.reconnectFunc = connect
 	return c, nil
 }
 
-func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry) *Client {
+func initClient(conn ServerCodec, idgen func() ID, services *serviceRegistry, limits *serverLimits) *Client {
 	_, isHTTP := conn.(*httpConn)
 	c := &Client{
 		idgen:       idgen,
 		isHTTP:      isHTTP,
 		services:    services,
+		limits:      limits,
 		writeConn:   conn,
 		close:       make(chan struct{}),
 		closing:     make(chan struct{}),
@@ -0,0 +1,49 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http"
+)
+
+// HTTPAuth is a function that sets the authentication headers of the HTTP requests and
+// of the WebSocket handshake. It's called before every request, so it may produce
+// short-lived tokens.
+type HTTPAuth func(h http.Header) error
+
+// ClientOption is a configuration option for the RPC client.
+type ClientOption interface {
+	applyOption(*clientConfig)
+}
+
+type clientConfig struct {
+	httpAuth HTTPAuth
+}
+
+type optionFunc func(*clientConfig)
+
+func (fn optionFunc) applyOption(opt *clientConfig) {
+	fn(opt)
+}
+
+// WithHTTPAuth configures the HTTP and WebSocket connections to authenticate with the
+// given function. It has no effect for the IPC and stdio connections.
+func WithHTTPAuth(a HTTPAuth) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.httpAuth = a
+	})
+}
@@ -54,10 +54,22 @@ var (
 	_ Error = new(invalidRequestError)
 	_ Error = new(invalidMessageError)
 	_ Error = new(invalidParamsError)
+	_ Error = new(internalServerError)
 )
 
 const defaultErrorCode = -32000
 
+const (
+	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
+)
+
+const (
+	errMsgBatchTooLarge    = "batch too large"
+	errMsgResponseTooLarge = "response too large"
+	errMsgLimitExceeded    = "request rate limit exceeded"
+)
+
 type methodNotFoundError struct{ method string }
 
 func (e *methodNotFoundError) ErrorCode() int { return -32601 }
@@ -101,3 +113,13 @@ type invalidParamsError struct{ message string }
 func (e *invalidParamsError) ErrorCode() int { return -32602 }
 
 func (e *invalidParamsError) Error() string { return e.message }
+
+// internalServerError is returned when the server refuses to serve the request
+type internalServerError struct {
+	code    int
+	message string
+}
+
+func (e *internalServerError) ErrorCode() int { return e.code }
+
+func (e *internalServerError) Error() string { return e.message }
@@ -32,6 +32,35 @@ var (
 	executionTimeLimit = 5 * time.Second
 )
 
+type namespacesKey struct{}
+
+// WithNamespaces returns a copy of the context, which restricts the methods served
+// to the requests with this context to the given namespaces.
+// The context has to be passed to Server.ServeHTTP or Server.WebsocketHandler.
+func WithNamespaces(ctx context.Context, namespaces []string) context.Context {
+	return context.WithValue(ctx, namespacesKey{}, namespaces)
+}
+
+func namespacesFromContext(ctx context.Context) []string {
+	namespaces, _ := ctx.Value(namespacesKey{}).([]string)
+	return namespaces
+}
+
+// namespaceAllowed checks if the namespace isn't restricted by the context.
+// The metadata namespace is always allowed.
+func namespaceAllowed(ctx context.Context, namespace string) bool {
+	namespaces, ok := ctx.Value(namespacesKey{}).([]string)
+	if !ok || namespace == MetadataApi {
+		return true
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // SetExecutionTimeLimit sets execution limit for RPC method calls
 func SetExecutionTimeLimit(limit time.Duration) {
 	executionTimeLimit = limit
@@ -69,6 +98,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limits         *serverLimits // nil if the requests aren't limited
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -79,7 +109,7 @@ type callProc struct {
 	notifiers []*Notifier
 }
 
-func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry) *handler {
+func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *serviceRegistry, limits *serverLimits) *handler {
 	rootCtx, cancelRoot := context.WithCancel(connCtx)
 	h := &handler{
 		reg:            reg,
@@ -92,6 +122,7 @@ func newHandler(connCtx context.Context, conn jsonWriter, idgen func() ID, reg *
 		allowSubscribe: true,
 		serverSubs:     make(map[ID]*Subscription),
 		log:            log.Root(),
+		limits:         limits,
 	}
 	if conn.remoteAddr() != "" {
 		h.log = h.log.New("conn", conn.remoteAddr())
@@ -110,6 +141,15 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		return
 	}
 
+	// Apply limit on total number of requests.
+	if h.limits.batchTooLarge(len(msgs)) {
+		rpcBatchTooLargeCounter.Inc(1)
+		h.startCallProc(func(cp *callProc) {
+			h.respondWithBatchTooLarge(cp, msgs)
+		})
+		return
+	}
+
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
 	for _, msg := range msgs {
@@ -123,9 +163,24 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
 		answers := make([]*jsonrpcMessage, 0, len(msgs))
-		for _, msg := range calls {
-			if answer := h.handleCallMsg(cp, msg); answer != nil {
-				answers = append(answers, answer)
+		responseBytes := 0
+		for i, msg := range calls {
+			answer := h.handleCallMsg(cp, msg)
+			if answer == nil {
+				continue
+			}
+			answers = append(answers, answer)
+			responseBytes += len(answer.Result)
+			if h.limits.responseTooLarge(responseBytes) {
+				// respond to the rest of calls with the error
+				rpcResponseTooLargeCounter.Inc(1)
+				err := &internalServerError{errcodeResponseTooLarge, errMsgResponseTooLarge}
+				for _, rest := range calls[i+1:] {
+					if rest.isCall() {
+						answers = append(answers, rest.errorResponse(err))
+					}
+				}
+				break
 			}
 		}
 		h.addSubscriptions(cp.notifiers)
@@ -138,6 +193,19 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	})
 }
 
+// respondWithBatchTooLarge sends the error response to the batch, which exceeds the items limit.
+func (h *handler) respondWithBatchTooLarge(cp *callProc, batch []*jsonrpcMessage) {
+	resp := errorMessage(&invalidRequestError{errMsgBatchTooLarge})
+	// Find the first call and add its "id" field to the error.
+	for _, msg := range batch {
+		if msg.isCall() {
+			resp.ID = msg.ID
+			break
+		}
+	}
+	h.conn.writeJSON(cp.ctx, []*jsonrpcMessage{resp})
+}
+
 // handleMsg handles a single message.
 func (h *handler) handleMsg(msg *jsonrpcMessage) {
 	if ok := h.handleImmediate(msg); ok {
@@ -328,6 +396,12 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if err := h.limits.allow(h.conn.remoteAddr(), msg); err != nil {
+		return msg.errorResponse(err)
+	}
+	if !namespaceAllowed(cp.ctx, msg.namespace()) {
+		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -46,6 +46,7 @@ type httpConn struct {
 	closeCh   chan interface{}
 	mu        sync.Mutex // protects headers
 	headers   http.Header
+	auth      HTTPAuth // sets the authentication headers, may be nil
 }
 
 // httpConn is treated specially by Client.
@@ -105,6 +106,10 @@ var DefaultHTTPTimeouts = HTTPTimeouts{
 // DialHTTPWithClient creates a new RPC client that connects to an RPC server over HTTP
 // using the provided HTTP Client.
 func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
+	return dialHTTP(endpoint, client, nil)
+}
+
+func dialHTTP(endpoint string, client *http.Client, auth HTTPAuth) (*Client, error) {
 	// Sanity check URL so we don't end up with a client that will fail every request.
 	_, err := url.Parse(endpoint)
 	if err != nil {
@@ -119,6 +124,7 @@ func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
 		hc := &httpConn{
 			client:  client,
 			headers: headers,
+			auth:    auth,
 			url:     endpoint,
 			closeCh: make(chan interface{}),
 		}
@@ -179,6 +185,11 @@ func (hc *httpConn) doRequest(ctx context.Context, msg interface{}) (io.ReadClos
 	hc.mu.Lock()
 	req.Header = hc.headers.Clone()
 	hc.mu.Unlock()
+	if hc.auth != nil {
+		if err := hc.auth(req.Header); err != nil {
+			return nil, err
+		}
+	}
 
 	// do request
 	resp, err := hc.client.Do(req)
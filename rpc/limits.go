@@ -0,0 +1,151 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimiterTTL is the time after which the bucket of an inactive client is dropped.
+	rateLimiterTTL = 10 * time.Minute
+	// maxRateLimiters is the number of client buckets after which the inactive ones are pruned.
+	maxRateLimiters = 10000
+)
+
+// RateLimits configures the token-bucket request rate limits, applied separately to each remote IP.
+// Every request of a batch consumes a token.
+type RateLimits struct {
+	// PerIP is the number of requests per second allowed from a single IP, 0 disables the limit.
+	PerIP float64 `toml:",omitempty"`
+	// Burst is the max number of requests, which may be served at once. Defaults to the rate rounded up.
+	Burst int `toml:",omitempty"`
+	// Namespaces are the number of requests per second allowed from a single IP
+	// to the methods of a namespace, on top of the PerIP limit.
+	Namespaces map[string]float64 `toml:",omitempty"`
+}
+
+func (l RateLimits) enabled() bool {
+	return l.PerIP > 0 || len(l.Namespaces) != 0
+}
+
+func (l RateLimits) burst(r float64) int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Ceil(r))
+}
+
+type rateLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter keeps the token buckets of the remote IPs and of the (IP, namespace) pairs.
+type rateLimiter struct {
+	cfg RateLimits
+
+	mu        sync.Mutex
+	buckets   map[string]*rateLimiterEntry
+	lastPrune time.Time
+}
+
+func newRateLimiter(cfg RateLimits) *rateLimiter {
+	return &rateLimiter{
+		cfg:     cfg,
+		buckets: make(map[string]*rateLimiterEntry),
+	}
+}
+
+// allow consumes a token of the IP and of the namespace buckets.
+func (rl *rateLimiter) allow(ip, namespace string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	rl.prune(now)
+
+	if rl.cfg.PerIP > 0 && !rl.bucket(ip, rl.cfg.PerIP, now).AllowN(now, 1) {
+		return false
+	}
+	if r, ok := rl.cfg.Namespaces[namespace]; ok && r > 0 {
+		if !rl.bucket(ip+"/"+namespace, r, now).AllowN(now, 1) {
+			return false
+		}
+	}
+	return true
+}
+
+func (rl *rateLimiter) bucket(key string, r float64, now time.Time) *rate.Limiter {
+	e := rl.buckets[key]
+	if e == nil {
+		e = &rateLimiterEntry{limiter: rate.NewLimiter(rate.Limit(r), rl.cfg.burst(r))}
+		rl.buckets[key] = e
+	}
+	e.lastSeen = now
+	return e.limiter
+}
+
+// prune drops the buckets of the inactive clients once there are too many of them.
+func (rl *rateLimiter) prune(now time.Time) {
+	if len(rl.buckets) < maxRateLimiters || now.Sub(rl.lastPrune) < rateLimiterTTL/10 {
+		return
+	}
+	rl.lastPrune = now
+	for key, e := range rl.buckets {
+		if now.Sub(e.lastSeen) > rateLimiterTTL {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// serverLimits are the limits applied by the server to the requests of its clients.
+type serverLimits struct {
+	batchItemLimit     int
+	batchResponseLimit int
+	rates              *rateLimiter
+}
+
+// allow checks the rate limits of the remote address.
+// The limits are not applied to the connections without a remote address, like in-process and IPC ones.
+func (l *serverLimits) allow(remote string, msg *jsonrpcMessage) error {
+	if l == nil || l.rates == nil || remote == "" {
+		return nil
+	}
+	ip := remote
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		ip = host
+	}
+	if !l.rates.allow(ip, msg.namespace()) {
+		rpcRateLimitedCounter.Inc(1)
+		return &internalServerError{errcodeLimitExceeded, errMsgLimitExceeded}
+	}
+	return nil
+}
+
+func (l *serverLimits) batchTooLarge(items int) bool {
+	return l != nil && l.batchItemLimit != 0 && items > l.batchItemLimit
+}
+
+func (l *serverLimits) responseTooLarge(size int) bool {
+	return l != nil && l.batchResponseLimit != 0 && size > l.batchResponseLimit
+}
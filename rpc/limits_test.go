@@ -0,0 +1,144 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func postBatch(t *testing.T, url string, calls ...string) []*jsonrpcMessage {
+	t.Helper()
+	reqs := make([]string, len(calls))
+	for i, method := range calls {
+		reqs[i] = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":"%s","params":[]}`, i+1, method)
+	}
+	resp, err := http.Post(url, contentType, strings.NewReader("["+strings.Join(reqs, ",")+"]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var msgs []*jsonrpcMessage
+	if err := json.NewDecoder(resp.Body).Decode(&msgs); err != nil {
+		t.Fatal(err)
+	}
+	return msgs
+}
+
+func TestServerBatchItemLimit(t *testing.T) {
+	s := newTestServer()
+	defer s.Stop()
+	s.SetBatchLimits(2, 0)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp := postBatch(t, ts.URL, "test_rets", "test_rets")
+	if len(resp) != 2 || resp[0].Error != nil || resp[1].Error != nil {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+
+	resp = postBatch(t, ts.URL, "test_rets", "test_rets", "test_rets")
+	if len(resp) != 1 || resp[0].Error == nil {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if resp[0].Error.Code != -32600 || resp[0].Error.Message != errMsgBatchTooLarge {
+		t.Fatalf("wrong error: %v", resp[0].Error)
+	}
+	if string(resp[0].ID) != "1" {
+		t.Fatalf("wrong id: %s", resp[0].ID)
+	}
+}
+
+func TestServerBatchResponseLimit(t *testing.T) {
+	s := NewServer()
+	defer s.Stop()
+	s.RegisterName("test", largeRespService{100})
+	s.SetBatchLimits(0, 250)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	resp := postBatch(t, ts.URL, "test_largeResp", "test_largeResp", "test_largeResp", "test_largeResp")
+	if len(resp) != 4 {
+		t.Fatalf("wrong number of responses: %d", len(resp))
+	}
+	for i, r := range resp {
+		if i < 3 {
+			if r.Error != nil {
+				t.Fatalf("response %d: unexpected error %v", i, r.Error)
+			}
+			continue
+		}
+		if r.Error == nil || r.Error.Code != errcodeResponseTooLarge {
+			t.Fatalf("response %d: wrong error %v", i, r.Error)
+		}
+	}
+}
+
+func TestServerRateLimits(t *testing.T) {
+	s := newTestServer()
+	defer s.Stop()
+	s.SetRateLimits(RateLimits{
+		PerIP: 0.001,
+		Burst: 3,
+		Namespaces: map[string]float64{
+			"nftest": 0.001,
+		},
+	})
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// namespace bucket has the burst of 3 as well, the 4th request exceeds the IP limit
+	resp := postBatch(t, ts.URL, "test_rets", "nftest_echo", "test_rets", "test_rets")
+	if len(resp) != 4 {
+		t.Fatalf("wrong number of responses: %d", len(resp))
+	}
+	for i, r := range resp[:3] {
+		if r.Error != nil && r.Error.Code == errcodeLimitExceeded {
+			t.Fatalf("response %d: unexpected limit error", i)
+		}
+	}
+	if resp[3].Error == nil || resp[3].Error.Code != errcodeLimitExceeded {
+		t.Fatalf("wrong error: %v", resp[3].Error)
+	}
+}
+
+func TestRateLimiterNamespaces(t *testing.T) {
+	rl := newRateLimiter(RateLimits{
+		Burst: 1,
+		Namespaces: map[string]float64{
+			"debug": 0.001,
+		},
+	})
+	if !rl.allow("127.0.0.1", "debug") {
+		t.Fatal("first request is limited")
+	}
+	if rl.allow("127.0.0.1", "debug") {
+		t.Fatal("second request isn't limited")
+	}
+	if !rl.allow("127.0.0.2", "debug") {
+		t.Fatal("other IP is limited")
+	}
+	for i := 0; i < 10; i++ {
+		if !rl.allow("127.0.0.1", "eth") {
+			t.Fatal("not limited namespace is limited")
+		}
+	}
+}
@@ -27,6 +27,10 @@ var (
 	successfulRequestGauge = metrics.NewRegisteredGauge("rpc/success", nil)
 	failedReqeustGauge     = metrics.NewRegisteredGauge("rpc/failure", nil)
 	rpcServingTimer        = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	rpcBatchTooLargeCounter    = metrics.NewRegisteredCounter("rpc/rejected/batch", nil)
+	rpcResponseTooLargeCounter = metrics.NewRegisteredCounter("rpc/rejected/response", nil)
+	rpcRateLimitedCounter      = metrics.NewRegisteredCounter("rpc/rejected/ratelimit", nil)
 )
 
 func newRPCServingTimer(method string, valid bool) metrics.Timer {
@@ -46,6 +46,7 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	limits   serverLimits
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -58,6 +59,29 @@ func NewServer() *Server {
 	return server
 }
 
+// SetBatchLimits sets limits applied to batch requests. There is no limit by default.
+//
+// Both limits can be left zero to disable them:
+//   - itemLimit: maximum number of items in a batch
+//   - maxResponseSize: maximum number of response bytes across all requests in a batch
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetBatchLimits(itemLimit, maxResponseSize int) {
+	s.limits.batchItemLimit = itemLimit
+	s.limits.batchResponseLimit = maxResponseSize
+}
+
+// SetRateLimits sets the request rate limits of the remote clients. There is no limit by default.
+//
+// This method should be called before processing any requests.
+func (s *Server) SetRateLimits(limits RateLimits) {
+	s.limits.rates = nil
+	if limits.enabled() {
+		s.limits.rates = newRateLimiter(limits)
+	}
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either a RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -83,7 +107,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, &s.limits)
 	<-codec.closed()
 	c.Close()
 }
@@ -97,7 +121,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 		return
 	}
 
-	h := newHandler(ctx, codec, s.idgen, &s.services)
+	h := newHandler(ctx, codec, s.idgen, &s.services, &s.limits)
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
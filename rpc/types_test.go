@@ -122,3 +122,29 @@ func TestBlockNumberOrHash_UnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockNumberOrHash_String(t *testing.T) {
+	tests := []BlockNumberOrHash{
+		BlockNumberOrHashWithNumber(0),
+		BlockNumberOrHashWithNumber(18),
+		BlockNumberOrHashWithNumber(PendingBlockNumber),
+		BlockNumberOrHashWithNumber(LatestBlockNumber),
+		BlockNumberOrHashWithHash(common.HexToHash("0x1"), false),
+	}
+
+	for i, test := range tests {
+		var bnh BlockNumberOrHash
+		input, _ := json.Marshal(test.String())
+		if err := json.Unmarshal(input, &bnh); err != nil {
+			t.Errorf("Test %d failed to unmarshal %s: %v", i, input, err)
+			continue
+		}
+		hash, _ := bnh.Hash()
+		expectedHash, _ := test.Hash()
+		num, _ := bnh.Number()
+		expectedNum, _ := test.Number()
+		if hash != expectedHash || num != expectedNum {
+			t.Errorf("Test %d got unexpected value, want %v, got %v", i, test, bnh)
+		}
+	}
+}
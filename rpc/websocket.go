@@ -60,6 +60,7 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn)
+		codec.(*websocketCodec).namespaces = namespacesFromContext(r.Context())
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -183,11 +184,21 @@ func parseOriginURL(origin string) (string, string, string, error) {
 // DialWebsocketWithDialer creates a new RPC client that communicates with a JSON-RPC server
 // that is listening on the given endpoint using the provided dialer.
 func DialWebsocketWithDialer(ctx context.Context, endpoint, origin string, dialer websocket.Dialer) (*Client, error) {
+	return dialWebsocket(ctx, endpoint, origin, dialer, nil)
+}
+
+func dialWebsocket(ctx context.Context, endpoint, origin string, dialer websocket.Dialer, auth HTTPAuth) (*Client, error) {
 	endpoint, header, err := wsClientHeaders(endpoint, origin)
 	if err != nil {
 		return nil, err
 	}
 	return newClient(ctx, func(ctx context.Context) (ServerCodec, error) {
+		header := header.Clone()
+		if auth != nil {
+			if err := auth(header); err != nil {
+				return nil, err
+			}
+		}
 		conn, resp, err := dialer.DialContext(ctx, endpoint, header)
 		if err != nil {
 			hErr := wsHandshakeError{err: err}
@@ -206,12 +217,15 @@ func DialWebsocketWithDialer(ctx context.Context, endpoint, origin string, diale
 // The context is used for the initial connection establishment. It does not
 // affect subsequent interactions with the client.
 func DialWebsocket(ctx context.Context, endpoint, origin string) (*Client, error) {
-	dialer := websocket.Dialer{
+	return DialWebsocketWithDialer(ctx, endpoint, origin, defaultWebsocketDialer())
+}
+
+func defaultWebsocketDialer() websocket.Dialer {
+	return websocket.Dialer{
 		ReadBufferSize:  wsReadBuffer,
 		WriteBufferSize: wsWriteBuffer,
 		WriteBufferPool: wsBufferPool,
 	}
-	return DialWebsocketWithDialer(ctx, endpoint, origin, dialer)
 }
 
 func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
@@ -233,7 +247,8 @@ func wsClientHeaders(endpoint, origin string) (string, http.Header, error) {
 
 type websocketCodec struct {
 	*jsonCodec
-	conn *websocket.Conn
+	conn       *websocket.Conn
+	namespaces []string // namespaces allowed to the connection, nil if not restricted
 
 	wg        sync.WaitGroup
 	pingReset chan struct{}
@@ -246,6 +261,9 @@ func newWebsocketCodec(conn *websocket.Conn) ServerCodec {
 		conn:      conn,
 		pingReset: make(chan struct{}, 1),
 	}
+	if addr := conn.RemoteAddr(); addr != nil {
+		wc.remote = addr.String()
+	}
 	wc.wg.Add(1)
 	go wc.pingLoop()
 	return wc
@@ -70,61 +70,91 @@ type Unit struct {
 	Header   genesis.Header
 }
 
-func OpenGenesisStore(rawReader ReadAtSeekerCloser) (*Store, genesis.Hashes, error) {
-	header := genesis.Header{}
-	hashes := genesis.Hashes{}
-	units := make([]readersmap.Unit, 0, 3)
+// UnitInfo describes a unit of a genesis file.
+type UnitInfo struct {
+	Unit
+	Hash             hash.Hash
+	DataOffset       int64
+	CompressedSize   uint64
+	UncompressedSize uint64
+}
+
+func readUnitInfo(rawReader io.ReaderAt, offset int64) (UnitInfo, error) {
+	info := UnitInfo{}
+	// header cannot be long, cap it with 100000 bytes
+	headerReader := io.NewSectionReader(rawReader, offset, offset+100000)
+	err := checkFileHeader(headerReader)
+	if err != nil {
+		return info, err
+	}
+	err = rlp.Decode(dummyByteReader{headerReader}, &info.Unit)
+	if err != nil {
+		return info, err
+	}
+
+	err = ioread.ReadAll(headerReader, info.Hash[:])
+	if err != nil {
+		return info, err
+	}
+
+	var numB [8]byte
+	err = ioread.ReadAll(headerReader, numB[:])
+	if err != nil {
+		return info, err
+	}
+	info.CompressedSize = bigendian.BytesToUint64(numB[:])
+
+	err = ioread.ReadAll(headerReader, numB[:])
+	if err != nil {
+		return info, err
+	}
+	info.UncompressedSize = bigendian.BytesToUint64(numB[:])
+
+	headerSize, err := headerReader.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return info, err
+	}
+	info.DataOffset = offset + headerSize
+	return info, nil
+}
+
+// ReadUnits reads the headers of all the units of a genesis file, without reading the units data.
+func ReadUnits(rawReader io.ReaderAt) ([]UnitInfo, error) {
+	units := make([]UnitInfo, 0, 3)
 	offset := int64(0)
-	for i := 0; ; i++ {
-		// header cannot be long, cap it with 100000 bytes
-		headerReader := io.NewSectionReader(rawReader, offset, offset+100000)
-		err := checkFileHeader(headerReader)
+	for {
+		info, err := readUnitInfo(rawReader, offset)
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			return nil, hashes, err
-		}
-		unit := Unit{}
-		err = rlp.Decode(dummyByteReader{headerReader}, &unit)
-		if err != nil {
-			return nil, hashes, err
+			return nil, err
 		}
+		units = append(units, info)
+		offset = info.DataOffset + int64(info.CompressedSize)
+	}
+	return units, nil
+}
+
+func OpenGenesisStore(rawReader ReadAtSeekerCloser) (*Store, genesis.Hashes, error) {
+	header := genesis.Header{}
+	hashes := genesis.Hashes{}
+	units := make([]readersmap.Unit, 0, 3)
+	infos, err := ReadUnits(rawReader)
+	if err != nil {
+		return nil, hashes, err
+	}
+	for i, info := range infos {
 		if i == 0 {
-			header = unit.Header
+			header = info.Header
 		} else {
-			if !header.Equal(unit.Header) {
+			if !header.Equal(info.Header) {
 				return nil, hashes, errors.New("subsequent genesis header doesn't match the first header")
 			}
 		}
+		hashes[info.UnitName] = info.Hash
 
-		var h hash.Hash
-		err = ioread.ReadAll(headerReader, h[:])
-		if err != nil {
-			return nil, hashes, err
-		}
-		hashes[unit.UnitName] = h
-
-		var numB [8]byte
-		err = ioread.ReadAll(headerReader, numB[:])
-		if err != nil {
-			return nil, hashes, err
-		}
-		dataCompressedSize := bigendian.BytesToUint64(numB[:])
-
-		err = ioread.ReadAll(headerReader, numB[:])
-		if err != nil {
-			return nil, hashes, err
-		}
-		uncompressedSize := bigendian.BytesToUint64(numB[:])
-
-		headerSize, err := headerReader.Seek(0, io.SeekCurrent)
-		if err != nil {
-			return nil, hashes, err
-		}
-
-		unitReader := io.NewSectionReader(rawReader, offset+headerSize, offset+headerSize+int64(dataCompressedSize))
-		offset += headerSize + int64(dataCompressedSize)
+		unitReader := io.NewSectionReader(rawReader, info.DataOffset, info.DataOffset+int64(info.CompressedSize))
 
 		gzipReader, err := gzip.NewReader(unitReader)
 		if err != nil {
@@ -133,7 +163,7 @@ func OpenGenesisStore(rawReader ReadAtSeekerCloser) (*Store, genesis.Hashes, err
 
 		// wrap with a logger
 		// human-readable name
-		name := unit.UnitName
+		name := info.UnitName
 		scanfName := strings.ReplaceAll(name, "-", "")
 		if scanfName[len(scanfName)-1] < '0' || scanfName[len(scanfName)-1] > '9' {
 			scanfName += "0"
@@ -148,10 +178,10 @@ func OpenGenesisStore(rawReader ReadAtSeekerCloser) (*Store, genesis.Hashes, err
 		if _, err := fmt.Sscanf(scanfName, "evm%d", &part); err == nil {
 			name = fmt.Sprintf("EVM unit %d", part)
 		}
-		loggedReader := filelog.Wrap(gzipReader, name, uncompressedSize, time.Minute)
+		loggedReader := filelog.Wrap(gzipReader, name, info.UncompressedSize, time.Minute)
 
 		units = append(units, readersmap.Unit{
-			Name:   unit.UnitName,
+			Name:   info.UnitName,
 			Reader: loggedReader,
 		})
 	}
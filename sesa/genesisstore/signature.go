@@ -0,0 +1,85 @@
+package genesisstore
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+	"github.com/sesanetwork/go-sesa/rlp"
+	"github.com/sesanetwork/go-sesa/sesa/genesis"
+	"github.com/sesanetwork/go-sesa/sesa/genesisstore/fileshash"
+)
+
+// SignatureSection is the unit of a genesis file which keeps the signature
+// of the genesis header and the hashes of the other units.
+const SignatureSection = "signature"
+
+var ErrUnsigned = errors.New("genesis file isn't signed")
+
+type signedGenesis struct {
+	Header genesis.Header
+	Names  []string
+	Hashes []hash.Hash
+}
+
+// SignatureHash returns the hash of the genesis header and the hashes of all the units
+// except the signature, in the order of the unit names.
+func SignatureHash(header genesis.Header, hashes genesis.Hashes) (common.Hash, error) {
+	s := signedGenesis{
+		Header: header,
+		Names:  make([]string, 0, len(hashes)),
+	}
+	for name := range hashes {
+		if name != SignatureSection {
+			s.Names = append(s.Names, name)
+		}
+	}
+	sort.Strings(s.Names)
+	for _, name := range s.Names {
+		s.Hashes = append(s.Hashes, hashes[name])
+	}
+	b, err := rlp.EncodeToBytes(&s)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(b), nil
+}
+
+// SignGenesis signs the genesis header and the unit hashes with the key.
+func SignGenesis(header genesis.Header, hashes genesis.Hashes, key *ecdsa.PrivateKey) ([]byte, error) {
+	h, err := SignatureHash(header, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(h.Bytes(), key)
+}
+
+// Signer reads the signature unit and returns the address which has signed the genesis
+// header and the unit hashes. The signature unit can be read only once.
+func (s *Store) Signer(hashes genesis.Hashes) (common.Address, error) {
+	section, err := s.Section(SignatureSection)
+	if err == fileshash.ErrRootNotFound {
+		return common.Address{}, ErrUnsigned
+	}
+	if err != nil {
+		return common.Address{}, err
+	}
+	sig, err := io.ReadAll(section)
+	if err != nil {
+		return common.Address{}, err
+	}
+	h, err := SignatureHash(s.head, hashes)
+	if err != nil {
+		return common.Address{}, err
+	}
+	pub, err := crypto.SigToPub(h.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
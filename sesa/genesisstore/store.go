@@ -45,3 +45,8 @@ func (s *Store) Close() error {
 	s.fMap = nil
 	return s.close()
 }
+
+// Section returns the raw reader of the genesis section.
+func (s *Store) Section(name string) (io.Reader, error) {
+	return s.fMap(name)
+}
@@ -79,6 +79,24 @@ func TestRulesLondonRLP(t *testing.T) {
 	require.True(decodedRules.Upgrades.London)
 }
 
+func TestRulesCancunRLP(t *testing.T) {
+	rules := MainNetRules()
+	rules.Upgrades.Cancun = true
+	require := require.New(t)
+
+	b, err := rlp.EncodeToBytes(rules)
+	require.NoError(err)
+
+	decodedRules := Rules{}
+	require.NoError(rlp.DecodeBytes(b, &decodedRules))
+
+	require.Equal(rules.String(), decodedRules.String())
+	require.True(decodedRules.Upgrades.Berlin)
+	require.True(decodedRules.Upgrades.London)
+	require.True(decodedRules.Upgrades.Llr)
+	require.True(decodedRules.Upgrades.Cancun)
+}
+
 func TestRulesBerlinCompatibilityRLP(t *testing.T) {
 	require := require.New(t)
 
@@ -22,6 +22,7 @@ const (
 	berlinBit              = 1 << 0
 	londonBit              = 1 << 1
 	llrBit                 = 1 << 2
+	cancunBit              = 1 << 3
 )
 
 var DefaultVMConfig = vm.Config{
@@ -115,6 +116,10 @@ type Upgrades struct {
 	Berlin bool
 	London bool
 	Llr    bool
+	// Cancun enables the Shanghai and Cancun EVM changes: PUSH0 (EIP-3855),
+	// initcode metering (EIP-3860), transient storage (EIP-1153), MCOPY (EIP-5656)
+	// and the restricted SELFDESTRUCT (EIP-6780)
+	Cancun bool
 }
 
 type UpgradeHeight struct {
@@ -128,6 +133,8 @@ func (r Rules) EvmChainConfig(hh []UpgradeHeight) *ethparams.ChainConfig {
 	cfg.ChainID = new(big.Int).SetUint64(r.NetworkID)
 	cfg.BerlinBlock = nil
 	cfg.LondonBlock = nil
+	cfg.ShanghaiBlock = nil
+	cfg.CancunBlock = nil
 	for i, h := range hh {
 		height := new(big.Int)
 		if i > 0 {
@@ -146,6 +153,15 @@ func (r Rules) EvmChainConfig(hh []UpgradeHeight) *ethparams.ChainConfig {
 		if !h.Upgrades.London {
 			cfg.LondonBlock = nil
 		}
+
+		if cfg.CancunBlock == nil && h.Upgrades.Cancun {
+			cfg.ShanghaiBlock = height
+			cfg.CancunBlock = height
+		}
+		if !h.Upgrades.Cancun {
+			cfg.ShanghaiBlock = nil
+			cfg.CancunBlock = nil
+		}
 	}
 	return &cfg
 }
@@ -0,0 +1,84 @@
+package sesaclient
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	go_sesa "github.com/sesanetwork/go-sesa"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/drivertype"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+)
+
+// Downtime is the validator's downtime.
+type Downtime struct {
+	OfflineBlocks idx.Block
+	OfflineTime   native.Timestamp
+}
+
+type rpcValidator struct {
+	Weight *hexutil.Big       `json:"weight"`
+	PubKey validatorpk.PubKey `json:"pubkey"`
+}
+
+func toValidators(raw map[hexutil.Uint64]rpcValidator) map[idx.ValidatorID]drivertype.Validator {
+	res := make(map[idx.ValidatorID]drivertype.Validator, len(raw))
+	for vid, v := range raw {
+		res[idx.ValidatorID(vid)] = drivertype.Validator{
+			Weight: (*big.Int)(v.Weight),
+			PubKey: v.PubKey,
+		}
+	}
+	return res
+}
+
+// GetValidators returns the validators of an epoch.
+// * When epoch is nil the validators of the latest sealed epoch are returned.
+// * When epoch is -1 the validators of the current (not sealed yet) epoch are returned.
+func (ec *Client) GetValidators(ctx context.Context, epoch *big.Int) (map[idx.ValidatorID]drivertype.Validator, error) {
+	var raw map[hexutil.Uint64]rpcValidator
+	err := ec.c.CallContext(ctx, &raw, "abft_getValidators", toBlockNumArg(epoch))
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, go_sesa.NotFound
+	}
+	return toValidators(raw), nil
+}
+
+// GetDowntime returns the validator's downtime.
+func (ec *Client) GetDowntime(ctx context.Context, validatorID idx.ValidatorID) (*Downtime, error) {
+	var raw struct {
+		OfflineBlocks hexutil.Uint64 `json:"offlineBlocks"`
+		OfflineTime   hexutil.Uint64 `json:"offlineTime"`
+	}
+	err := ec.c.CallContext(ctx, &raw, "abft_getDowntime", hexutil.Uint(validatorID))
+	if err != nil {
+		return nil, err
+	}
+	return &Downtime{
+		OfflineBlocks: idx.Block(raw.OfflineBlocks),
+		OfflineTime:   native.Timestamp(raw.OfflineTime),
+	}, nil
+}
+
+// GetEpochUptime returns the validator's uptime in the current epoch.
+func (ec *Client) GetEpochUptime(ctx context.Context, validatorID idx.ValidatorID) (time.Duration, error) {
+	var uptime hexutil.Uint64
+	err := ec.c.CallContext(ctx, &uptime, "abft_getEpochUptime", hexutil.Uint(validatorID))
+	return time.Duration(uptime), err
+}
+
+// GetOriginatedEpochFee returns the fee originated by the validator in the current epoch.
+func (ec *Client) GetOriginatedEpochFee(ctx context.Context, validatorID idx.ValidatorID) (*big.Int, error) {
+	var fee *hexutil.Big
+	err := ec.c.CallContext(ctx, &fee, "abft_getOriginatedEpochFee", hexutil.Uint(validatorID))
+	if err != nil {
+		return nil, err
+	}
+	return (*big.Int)(fee), nil
+}
@@ -0,0 +1,308 @@
+package sesaclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	go_sesa "github.com/sesanetwork/go-sesa"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/event"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/drivertype"
+)
+
+// EpochStats is the statistics of a sealed epoch.
+type EpochStats struct {
+	Epoch                 idx.Epoch
+	Start                 native.Timestamp
+	End                   native.Timestamp
+	TotalFee              *big.Int
+	TotalBaseRewardWeight *big.Int
+	TotalTxRewardWeight   *big.Int
+}
+
+type rpcEpochStats struct {
+	Epoch                 hexutil.Uint64 `json:"epoch"`
+	Start                 hexutil.Uint64 `json:"start"`
+	End                   hexutil.Uint64 `json:"end"`
+	TotalFee              *hexutil.Big   `json:"totalFee"`
+	TotalBaseRewardWeight *hexutil.Big   `json:"totalBaseRewardWeight"`
+	TotalTxRewardWeight   *hexutil.Big   `json:"totalTxRewardWeight"`
+}
+
+// EpochNotify is the summary of a sealed epoch, sent by the newEpoch subscription.
+type EpochNotify struct {
+	Epoch          idx.Epoch
+	SealedEpoch    idx.Epoch
+	EpochStart     native.Timestamp
+	PrevEpochStart native.Timestamp
+	LastBlock      idx.Block
+	StateRoot      hash.Hash
+	Validators     map[idx.ValidatorID]drivertype.Validator
+}
+
+type rpcEpochNotify struct {
+	Epoch          hexutil.Uint64                  `json:"epoch"`
+	SealedEpoch    hexutil.Uint64                  `json:"sealedEpoch"`
+	EpochStart     hexutil.Uint64                  `json:"epochStart"`
+	PrevEpochStart hexutil.Uint64                  `json:"prevEpochStart"`
+	LastBlock      hexutil.Uint64                  `json:"lastBlock"`
+	StateRoot      hexutil.Bytes                   `json:"stateRoot"`
+	Validators     map[hexutil.Uint64]rpcValidator `json:"validators"`
+}
+
+// UnmarshalJSON decodes the RPC output of the newEpoch subscription.
+func (n *EpochNotify) UnmarshalJSON(input []byte) error {
+	var dec rpcEpochNotify
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	*n = EpochNotify{
+		Epoch:          idx.Epoch(dec.Epoch),
+		SealedEpoch:    idx.Epoch(dec.SealedEpoch),
+		EpochStart:     native.Timestamp(dec.EpochStart),
+		PrevEpochStart: native.Timestamp(dec.PrevEpochStart),
+		LastBlock:      idx.Block(dec.LastBlock),
+		StateRoot:      hash.BytesToHash(dec.StateRoot),
+		Validators:     toValidators(dec.Validators),
+	}
+	return nil
+}
+
+// LlrBlockResult is a block record hash decided by the LLR votes.
+type LlrBlockResult struct {
+	Block idx.Block
+	Hash  hash.Hash
+}
+
+// UnmarshalJSON decodes the RPC output of the llrBlockResult subscription.
+func (r *LlrBlockResult) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Block hexutil.Uint64 `json:"block"`
+		Hash  hexutil.Bytes  `json:"hash"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	r.Block = idx.Block(dec.Block)
+	r.Hash = hash.BytesToHash(dec.Hash)
+	return nil
+}
+
+// LlrEpochResult is an epoch record hash decided by the LLR votes.
+type LlrEpochResult struct {
+	Epoch idx.Epoch
+	Hash  hash.Hash
+}
+
+// UnmarshalJSON decodes the RPC output of the llrEpochResult subscription.
+func (r *LlrEpochResult) UnmarshalJSON(input []byte) error {
+	var dec struct {
+		Epoch hexutil.Uint64 `json:"epoch"`
+		Hash  hexutil.Bytes  `json:"hash"`
+	}
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+	r.Epoch = idx.Epoch(dec.Epoch)
+	r.Hash = hash.BytesToHash(dec.Hash)
+	return nil
+}
+
+// unmarshalEvent checks the fields of the RPC event output, and converts it to the event header.
+func unmarshalEvent(raw map[string]interface{}) (native.EventI, error) {
+	field := func(name string) (string, error) {
+		s, ok := raw[name].(string)
+		if !ok {
+			return "", fmt.Errorf("event field %s is missing", name)
+		}
+		return s, nil
+	}
+	for _, name := range []string{"version", "networkVersion", "epoch", "seq", "frame", "creator", "lamport", "creationTime", "medianTime", "gasPowerUsed"} {
+		s, err := field(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := hexutil.DecodeUint64(s); err != nil {
+			return nil, fmt.Errorf("invalid event field %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"id", "extraData", "payloadHash"} {
+		s, err := field(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := hexutil.Decode(s); err != nil {
+			return nil, fmt.Errorf("invalid event field %s: %v", name, err)
+		}
+	}
+	if v := raw["prevEpochHash"]; v != nil {
+		if s, ok := v.(string); !ok {
+			return nil, errors.New("invalid event field prevEpochHash")
+		} else if _, err := hexutil.Decode(s); err != nil {
+			return nil, fmt.Errorf("invalid event field prevEpochHash: %v", err)
+		}
+	}
+	for _, name := range []string{"anyTxs", "anyMisbehaviourProofs", "anyEpochVote", "anyBlockVotes"} {
+		if _, ok := raw[name].(bool); !ok {
+			return nil, fmt.Errorf("event field %s is missing", name)
+		}
+	}
+	var parents []hexutil.Bytes
+	if err := remarshal(raw["parents"], &parents); err != nil {
+		return nil, fmt.Errorf("invalid event field parents: %v", err)
+	} else if parents == nil {
+		return nil, errors.New("event field parents is missing")
+	}
+	var gas struct {
+		ShortTerm *hexutil.Uint64 `json:"shortTerm"`
+		LongTerm  *hexutil.Uint64 `json:"longTerm"`
+	}
+	if err := remarshal(raw["gasPowerLeft"], &gas); err != nil {
+		return nil, fmt.Errorf("invalid event field gasPowerLeft: %v", err)
+	} else if gas.ShortTerm == nil || gas.LongTerm == nil {
+		return nil, errors.New("event field gasPowerLeft is missing")
+	}
+	return native.RPCUnmarshalEvent(raw), nil
+}
+
+// remarshal decodes a value of the generic JSON output into a typed one.
+func remarshal(v interface{}, res interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, res)
+}
+
+// GetEvent returns Hashgraph event header by hash.
+func (ec *Client) GetEvent(ctx context.Context, h hash.Event) (native.EventI, error) {
+	var raw map[string]interface{}
+	err := ec.c.CallContext(ctx, &raw, "dag_getEvent", h.Hex())
+	if err != nil {
+		return nil, err
+	} else if len(raw) == 0 {
+		return nil, go_sesa.NotFound
+	}
+	return unmarshalEvent(raw)
+}
+
+// GetEventPayload returns Hashgraph event header by hash, and the hashes of its transactions if inclTx is true.
+func (ec *Client) GetEventPayload(ctx context.Context, h hash.Event, inclTx bool) (native.EventI, []common.Hash, error) {
+	var raw map[string]interface{}
+	err := ec.c.CallContext(ctx, &raw, "dag_getEventPayload", h.Hex(), inclTx)
+	if err != nil {
+		return nil, nil, err
+	} else if len(raw) == 0 {
+		return nil, nil, go_sesa.NotFound
+	}
+
+	e, err := unmarshalEvent(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	var txs []common.Hash
+	if inclTx {
+		var vv []hexutil.Bytes
+		if err := remarshal(raw["transactions"], &vv); err != nil {
+			return nil, nil, fmt.Errorf("invalid event transactions: %v", err)
+		}
+		txs = make([]common.Hash, len(vv))
+		for i, v := range vv {
+			txs[i] = common.BytesToHash(v)
+		}
+	}
+	return e, txs, nil
+}
+
+// GetHeads returns IDs of all the epoch events with no descendants.
+// Only the heads of the current epoch are available.
+// * When epoch is nil the latest sealed epoch is requested.
+// * When epoch is -1 the current (not sealed yet) epoch is requested.
+func (ec *Client) GetHeads(ctx context.Context, epoch *big.Int) (hash.Events, error) {
+	var raw []hexutil.Bytes
+	err := ec.c.CallContext(ctx, &raw, "dag_getHeads", toBlockNumArg(epoch))
+	if err != nil {
+		return nil, err
+	}
+	heads := make(hash.Events, len(raw))
+	for i, id := range raw {
+		heads[i] = hash.BytesToEvent(id)
+	}
+	return heads, nil
+}
+
+// GetEpochStats returns the statistics of the latest sealed epoch,
+// which is the only epoch the statistics are available for.
+// * When epoch is nil the latest sealed epoch is requested.
+func (ec *Client) GetEpochStats(ctx context.Context, epoch *big.Int) (*EpochStats, error) {
+	var raw *rpcEpochStats
+	err := ec.c.CallContext(ctx, &raw, "eth_getEpochStats", toBlockNumArg(epoch))
+	if err != nil {
+		return nil, err
+	} else if raw == nil {
+		return nil, go_sesa.NotFound
+	}
+	return &EpochStats{
+		Epoch:                 idx.Epoch(raw.Epoch),
+		Start:                 native.Timestamp(raw.Start),
+		End:                   native.Timestamp(raw.End),
+		TotalFee:              (*big.Int)(raw.TotalFee),
+		TotalBaseRewardWeight: (*big.Int)(raw.TotalBaseRewardWeight),
+		TotalTxRewardWeight:   (*big.Int)(raw.TotalTxRewardWeight),
+	}, nil
+}
+
+// SubscribeNewEvents subscribes to notifications about the events connected to the DAG.
+func (ec *Client) SubscribeNewEvents(ctx context.Context, ch chan<- native.EventI) (go_sesa.Subscription, error) {
+	raws := make(chan map[string]interface{})
+	sub, err := ec.c.Subscribe(ctx, "dag", raws, "newEvents")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case raw := <-raws:
+				e, err := unmarshalEvent(raw)
+				if err != nil {
+					return err
+				}
+				select {
+				case ch <- e:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// SubscribeNewEpochs subscribes to notifications about the sealed epochs.
+func (ec *Client) SubscribeNewEpochs(ctx context.Context, ch chan<- *EpochNotify) (go_sesa.Subscription, error) {
+	return ec.c.Subscribe(ctx, "dag", ch, "newEpoch")
+}
+
+// SubscribeLlrBlockResults subscribes to notifications about the block records decided by the LLR votes.
+func (ec *Client) SubscribeLlrBlockResults(ctx context.Context, ch chan<- *LlrBlockResult) (go_sesa.Subscription, error) {
+	return ec.c.Subscribe(ctx, "dag", ch, "llrBlockResult")
+}
+
+// SubscribeLlrEpochResults subscribes to notifications about the epoch records decided by the LLR votes.
+func (ec *Client) SubscribeLlrEpochResults(ctx context.Context, ch chan<- *LlrEpochResult) (go_sesa.Subscription, error) {
+	return ec.c.Subscribe(ctx, "dag", ch, "llrEpochResult")
+}
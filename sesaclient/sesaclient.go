@@ -0,0 +1,80 @@
+// Package sesaclient provides a client for the Sesa-specific RPC API namespaces:
+// dag, abft, trace and the Sesa extensions of the eth namespace.
+package sesaclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	go_sesa "github.com/sesanetwork/go-sesa"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/ethclient"
+	"github.com/sesanetwork/go-sesa/rpc"
+
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+// Client extends the Ethereum API client with typed wrappers for the Sesa-specific API.
+type Client struct {
+	ethclient.Client
+	c *rpc.Client
+}
+
+// Dial connects a client to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	return DialContext(context.Background(), rawurl)
+}
+
+func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
+// NewClient creates a client that uses the given RPC client.
+func NewClient(c *rpc.Client) *Client {
+	return &Client{
+		Client: *ethclient.NewClient(c),
+		c:      c,
+	}
+}
+
+// CurrentEpoch returns the current epoch number.
+func (ec *Client) CurrentEpoch(ctx context.Context) (idx.Epoch, error) {
+	var epoch hexutil.Uint64
+	err := ec.c.CallContext(ctx, &epoch, "eth_currentEpoch")
+	return idx.Epoch(epoch), err
+}
+
+// GetRules returns the network rules of an epoch.
+// * When epoch is nil the rules of the latest sealed epoch are returned.
+// * When epoch is -1 the rules of the current (not sealed yet) epoch are returned.
+func (ec *Client) GetRules(ctx context.Context, epoch *big.Int) (*sesa.Rules, error) {
+	var rules *sesa.Rules
+	err := ec.c.CallContext(ctx, &rules, "eth_getRules", toBlockNumArg(epoch))
+	if err == nil && rules == nil {
+		return nil, go_sesa.NotFound
+	}
+	return rules, err
+}
+
+// GetEpochBlock returns the block height at the beginning of an epoch.
+func (ec *Client) GetEpochBlock(ctx context.Context, epoch *big.Int) (idx.Block, error) {
+	var block hexutil.Uint64
+	err := ec.c.CallContext(ctx, &block, "eth_getEpochBlock", toBlockNumArg(epoch))
+	return idx.Block(block), err
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	pending := big.NewInt(-1)
+	if number.Cmp(pending) == 0 {
+		return "pending"
+	}
+	return hexutil.EncodeBig(number)
+}
@@ -0,0 +1,347 @@
+package sesaclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/hash"
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	go_sesa "github.com/sesanetwork/go-sesa"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/rpc"
+
+	"github.com/sesanetwork/go-sesa/native"
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+	"github.com/sesanetwork/go-sesa/sesa"
+)
+
+// testDAGService serves the dag namespace from the prepared RPC outputs.
+type testDAGService struct {
+	events map[string]map[string]interface{}
+	txs    []common.Hash
+	heads  hash.Events
+	epochs []rpc.BlockNumber
+}
+
+func (s *testDAGService) GetEvent(ctx context.Context, id string) (map[string]interface{}, error) {
+	return s.events[id], nil
+}
+
+func (s *testDAGService) GetEventPayload(ctx context.Context, id string, inclTx bool) (map[string]interface{}, error) {
+	e, ok := s.events[id]
+	if !ok {
+		return nil, nil
+	}
+	res := make(map[string]interface{}, len(e)+1)
+	for k, v := range e {
+		res[k] = v
+	}
+	if inclTx {
+		res["transactions"] = s.txs
+	}
+	return res, nil
+}
+
+func (s *testDAGService) GetHeads(ctx context.Context, epoch rpc.BlockNumber) ([]hexutil.Bytes, error) {
+	s.epochs = append(s.epochs, epoch)
+	return native.EventIDsToHex(s.heads), nil
+}
+
+func (s *testDAGService) NewEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	events := make([]map[string]interface{}, 0, len(s.events))
+	for _, e := range s.events {
+		events = append(events, e)
+	}
+	go func() {
+		for _, e := range events {
+			_ = notifier.Notify(sub.ID, e)
+		}
+	}()
+	return sub, nil
+}
+
+// testAbftService serves the abft namespace.
+type testAbftService struct {
+	validators map[hexutil.Uint64]interface{}
+}
+
+func (s *testAbftService) GetValidators(ctx context.Context, epoch rpc.BlockNumber) (map[hexutil.Uint64]interface{}, error) {
+	if epoch > 10 {
+		return nil, nil
+	}
+	return s.validators, nil
+}
+
+func (s *testAbftService) GetDowntime(ctx context.Context, validatorID hexutil.Uint) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"offlineBlocks": hexutil.Uint64(validatorID) * 10,
+		"offlineTime":   hexutil.Uint64(validatorID) * 1000,
+	}, nil
+}
+
+func (s *testAbftService) GetEpochUptime(ctx context.Context, validatorID hexutil.Uint) (hexutil.Uint64, error) {
+	return hexutil.Uint64(validatorID) * 100, nil
+}
+
+func (s *testAbftService) GetOriginatedEpochFee(ctx context.Context, validatorID hexutil.Uint) (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(int64(validatorID) * 7)), nil
+}
+
+// testEthService serves the Sesa extensions of the eth namespace.
+type testEthService struct {
+	rules sesa.Rules
+}
+
+func (s *testEthService) CurrentEpoch(ctx context.Context) hexutil.Uint64 {
+	return 5
+}
+
+func (s *testEthService) GetRules(ctx context.Context, epoch rpc.BlockNumber) (*sesa.Rules, error) {
+	if epoch > 5 {
+		return nil, nil
+	}
+	return &s.rules, nil
+}
+
+func (s *testEthService) GetEpochBlock(ctx context.Context, epoch rpc.BlockNumber) (hexutil.Uint64, error) {
+	return hexutil.Uint64(epoch) * 100, nil
+}
+
+func (s *testEthService) GetEpochStats(ctx context.Context, epoch rpc.BlockNumber) (map[string]interface{}, error) {
+	if epoch != rpc.LatestBlockNumber {
+		return nil, errors.New("getEpochStats API call doesn't support retrieving previous sealed epochs")
+	}
+	return map[string]interface{}{
+		"epoch":                 hexutil.Uint64(4),
+		"start":                 hexutil.Uint64(1000),
+		"end":                   hexutil.Uint64(2000),
+		"totalFee":              (*hexutil.Big)(big.NewInt(30)),
+		"totalBaseRewardWeight": (*hexutil.Big)(big.NewInt(40)),
+		"totalTxRewardWeight":   (*hexutil.Big)(big.NewInt(50)),
+	}, nil
+}
+
+func testEvent(seq idx.Event, parents hash.Events) native.EventI {
+	e := native.MutableEventPayload{}
+	e.SetVersion(1)
+	e.SetEpoch(3)
+	e.SetSeq(seq)
+	e.SetCreator(2)
+	e.SetFrame(idx.Frame(seq))
+	e.SetLamport(idx.Lamport(seq) + 1)
+	e.SetParents(parents)
+	e.SetCreationTime(native.Timestamp(seq) * 1000)
+	e.SetMedianTime(native.Timestamp(seq) * 900)
+	e.SetExtra([]byte{byte(seq)})
+	e.SetPayloadHash(hash.Of([]byte{byte(seq)}))
+	e.SetGasPowerUsed(uint64(seq) * 10)
+	e.SetGasPowerLeft(native.GasPowerLeft{Gas: [native.GasPowerConfigs]uint64{uint64(seq), uint64(seq) * 2}})
+	return &e.Build().Event
+}
+
+func newTestClient(t *testing.T, dag *testDAGService, abft *testAbftService, eth *testEthService) *Client {
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("dag", dag))
+	require.NoError(t, server.RegisterName("abft", abft))
+	require.NoError(t, server.RegisterName("eth", eth))
+	t.Cleanup(server.Stop)
+	c := rpc.DialInProc(server)
+	t.Cleanup(c.Close)
+	return NewClient(c)
+}
+
+func requireSameEvent(t *testing.T, expected, got native.EventI) {
+	require := require.New(t)
+	require.Equal(expected.Version(), got.Version())
+	require.Equal(expected.Epoch(), got.Epoch())
+	require.Equal(expected.Seq(), got.Seq())
+	require.Equal(expected.Creator(), got.Creator())
+	require.Equal(expected.Frame(), got.Frame())
+	require.Equal(expected.Lamport(), got.Lamport())
+	require.Equal(expected.Parents(), got.Parents())
+	require.Equal(expected.CreationTime(), got.CreationTime())
+	require.Equal(expected.MedianTime(), got.MedianTime())
+	require.Equal(expected.Extra(), got.Extra())
+	require.Equal(expected.PayloadHash(), got.PayloadHash())
+	require.Equal(expected.GasPowerUsed(), got.GasPowerUsed())
+	require.Equal(expected.GasPowerLeft(), got.GasPowerLeft())
+}
+
+func TestClient_DAG(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	e1 := testEvent(1, hash.Events{})
+	e2 := testEvent(2, hash.Events{e1.ID()})
+	dag := &testDAGService{
+		events: map[string]map[string]interface{}{
+			e1.ID().Hex(): native.RPCMarshalEvent(e1),
+			e2.ID().Hex(): native.RPCMarshalEvent(e2),
+		},
+		txs:   []common.Hash{{1}, {2}},
+		heads: hash.Events{e2.ID()},
+	}
+	client := newTestClient(t, dag, &testAbftService{}, &testEthService{})
+
+	got, err := client.GetEvent(ctx, e2.ID())
+	require.NoError(err)
+	requireSameEvent(t, e2, got)
+
+	got, txs, err := client.GetEventPayload(ctx, e1.ID(), true)
+	require.NoError(err)
+	requireSameEvent(t, e1, got)
+	require.Equal(dag.txs, txs)
+	_, txs, err = client.GetEventPayload(ctx, e1.ID(), false)
+	require.NoError(err)
+	require.Empty(txs)
+
+	_, err = client.GetEvent(ctx, hash.Event{3})
+	require.Equal(go_sesa.NotFound, err)
+	_, _, err = client.GetEventPayload(ctx, hash.Event{3}, true)
+	require.Equal(go_sesa.NotFound, err)
+
+	heads, err := client.GetHeads(ctx, nil)
+	require.NoError(err)
+	require.Equal(dag.heads, heads)
+	_, err = client.GetHeads(ctx, big.NewInt(-1))
+	require.NoError(err)
+	_, err = client.GetHeads(ctx, big.NewInt(3))
+	require.NoError(err)
+	require.Equal([]rpc.BlockNumber{rpc.LatestBlockNumber, rpc.PendingBlockNumber, 3}, dag.epochs)
+
+	ch := make(chan native.EventI)
+	sub, err := client.SubscribeNewEvents(ctx, ch)
+	require.NoError(err)
+	defer sub.Unsubscribe()
+	received := map[hash.Event]bool{}
+	for len(received) < len(dag.events) {
+		select {
+		case e := <-ch:
+			received[e.ID()] = true
+		case err := <-sub.Err():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the events aren't received")
+		}
+	}
+}
+
+func TestClient_MalformedEvent(t *testing.T) {
+	e := testEvent(1, hash.Events{})
+	for name, corrupt := range map[string]func(map[string]interface{}){
+		"missing seq":        func(raw map[string]interface{}) { delete(raw, "seq") },
+		"invalid epoch":      func(raw map[string]interface{}) { raw["epoch"] = "epoch" },
+		"numeric creator":    func(raw map[string]interface{}) { raw["creator"] = 2 },
+		"invalid extra":      func(raw map[string]interface{}) { raw["extraData"] = "0xz" },
+		"invalid prev epoch": func(raw map[string]interface{}) { raw["prevEpochHash"] = true },
+		"missing anyTxs":     func(raw map[string]interface{}) { delete(raw, "anyTxs") },
+		"missing parents":    func(raw map[string]interface{}) { delete(raw, "parents") },
+		"invalid parents":    func(raw map[string]interface{}) { raw["parents"] = "0x01" },
+		"missing gas power":  func(raw map[string]interface{}) { raw["gasPowerLeft"] = map[string]interface{}{} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			raw := native.RPCMarshalEvent(e)
+			corrupt(raw)
+			dag := &testDAGService{
+				events: map[string]map[string]interface{}{
+					e.ID().Hex(): raw,
+				},
+			}
+			client := newTestClient(t, dag, &testAbftService{}, &testEthService{})
+
+			require.NotPanics(func() {
+				_, err := client.GetEvent(context.Background(), e.ID())
+				require.Error(err)
+				_, _, err = client.GetEventPayload(context.Background(), e.ID(), false)
+				require.Error(err)
+			})
+		})
+	}
+}
+
+func TestClient_Abft(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	pubkey := validatorpk.PubKey{
+		Type: validatorpk.Types.Secp256k1,
+		Raw:  []byte{1, 2, 3},
+	}
+	abft := &testAbftService{
+		validators: map[hexutil.Uint64]interface{}{
+			5: map[string]interface{}{
+				"weight": (*hexutil.Big)(big.NewInt(10)),
+				"pubkey": pubkey.String(),
+			},
+		},
+	}
+	client := newTestClient(t, &testDAGService{}, abft, &testEthService{})
+
+	validators, err := client.GetValidators(ctx, nil)
+	require.NoError(err)
+	require.Len(validators, 1)
+	require.Equal(big.NewInt(10), validators[5].Weight)
+	require.Equal(pubkey, validators[5].PubKey)
+	_, err = client.GetValidators(ctx, big.NewInt(11))
+	require.Equal(go_sesa.NotFound, err)
+
+	downtime, err := client.GetDowntime(ctx, 2)
+	require.NoError(err)
+	require.Equal(&Downtime{OfflineBlocks: 20, OfflineTime: 2000}, downtime)
+
+	uptime, err := client.GetEpochUptime(ctx, 2)
+	require.NoError(err)
+	require.Equal(time.Duration(200), uptime)
+
+	fee, err := client.GetOriginatedEpochFee(ctx, 2)
+	require.NoError(err)
+	require.Equal(big.NewInt(14), fee)
+}
+
+func TestClient_Eth(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	eth := &testEthService{rules: sesa.FakeNetRules()}
+	client := newTestClient(t, &testDAGService{}, &testAbftService{}, eth)
+
+	epoch, err := client.CurrentEpoch(ctx)
+	require.NoError(err)
+	require.Equal(idx.Epoch(5), epoch)
+
+	rules, err := client.GetRules(ctx, big.NewInt(3))
+	require.NoError(err)
+	require.Equal(eth.rules.Name, rules.Name)
+	require.Equal(eth.rules.NetworkID, rules.NetworkID)
+	_, err = client.GetRules(ctx, big.NewInt(6))
+	require.Equal(go_sesa.NotFound, err)
+
+	block, err := client.GetEpochBlock(ctx, big.NewInt(3))
+	require.NoError(err)
+	require.Equal(idx.Block(300), block)
+
+	stats, err := client.GetEpochStats(ctx, nil)
+	require.NoError(err)
+	require.Equal(&EpochStats{
+		Epoch:                 4,
+		Start:                 1000,
+		End:                   2000,
+		TotalFee:              big.NewInt(30),
+		TotalBaseRewardWeight: big.NewInt(40),
+		TotalTxRewardWeight:   big.NewInt(50),
+	}, stats)
+	_, err = client.GetEpochStats(ctx, big.NewInt(3))
+	require.Error(err)
+}
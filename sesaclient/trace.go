@@ -0,0 +1,53 @@
+package sesaclient
+
+import (
+	"context"
+
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+	"github.com/sesanetwork/go-sesa/rpc"
+
+	"github.com/sesanetwork/go-sesa/evmcore/txtracer"
+)
+
+// TraceFilter is the criteria of the trace_filter call.
+type TraceFilter struct {
+	FromAddress *[]common.Address      `json:"fromAddress,omitempty"`
+	ToAddress   *[]common.Address      `json:"toAddress,omitempty"`
+	FromBlock   *rpc.BlockNumberOrHash `json:"fromBlock,omitempty"`
+	ToBlock     *rpc.BlockNumberOrHash `json:"toBlock,omitempty"`
+	After       uint                   `json:"after,omitempty"`
+	Count       uint                   `json:"count,omitempty"`
+}
+
+// TraceBlock returns the traces of all the transactions of a block.
+func (ec *Client) TraceBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]txtracer.ActionTrace, error) {
+	var traces []txtracer.ActionTrace
+	err := ec.c.CallContext(ctx, &traces, "trace_block", blockNrOrHash)
+	return traces, err
+}
+
+// TraceTransaction returns the traces of a transaction.
+func (ec *Client) TraceTransaction(ctx context.Context, txHash common.Hash) ([]txtracer.ActionTrace, error) {
+	var traces []txtracer.ActionTrace
+	err := ec.c.CallContext(ctx, &traces, "trace_transaction", txHash)
+	return traces, err
+}
+
+// TraceGet returns the transaction traces at the given trace address.
+func (ec *Client) TraceGet(ctx context.Context, txHash common.Hash, traceIndex []uint) ([]txtracer.ActionTrace, error) {
+	index := make([]hexutil.Uint, len(traceIndex))
+	for i, v := range traceIndex {
+		index[i] = hexutil.Uint(v)
+	}
+	var traces []txtracer.ActionTrace
+	err := ec.c.CallContext(ctx, &traces, "trace_get", txHash, index)
+	return traces, err
+}
+
+// TraceFilter returns the traces matching the filter criteria.
+func (ec *Client) TraceFilter(ctx context.Context, filter TraceFilter) ([]txtracer.ActionTrace, error) {
+	var traces []txtracer.ActionTrace
+	err := ec.c.CallContext(ctx, &traces, "trace_filter", filter)
+	return traces, err
+}
@@ -18,6 +18,8 @@ type index struct {
 		Topic sesadb.Store `table:"t"`
 		// (blockN+TxHash+logIndex) -> ordered topic_count topics, blockHash, address, data
 		Logrec sesadb.Store `table:"r"`
+		// address+blockN -> nil (the blocks which contain logs of the address)
+		Address sesadb.Store `table:"a"`
 	}
 }
 
@@ -38,9 +40,12 @@ func (tt *index) WrapTablesAsBatched() (unwrap func()) {
 	tt.table.Topic = batchedTopic
 	batchedLogrec := batched.Wrap(tt.table.Logrec)
 	tt.table.Logrec = batchedLogrec
+	batchedAddress := batched.Wrap(tt.table.Address)
+	tt.table.Address = batchedAddress
 	return func() {
 		_ = batchedTopic.Flush()
 		_ = batchedLogrec.Flush()
+		_ = batchedAddress.Flush()
 		tt.table = origTables
 	}
 }
@@ -83,6 +88,25 @@ func (tt *index) ForEachInBlocks(ctx context.Context, from, to idx.Block, patter
 	return tt.searchParallel(ctx, pattern, uint64(from), uint64(to), onMatched, doNothing)
 }
 
+// FindInBlocksByAddresses returns all log records of block range by the addresses set and topics pattern.
+func (tt *index) FindInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash) (logs []*types.Log, err error) {
+	err = tt.ForEachInBlocksByAddresses(
+		ctx,
+		from, to,
+		addresses, topics,
+		func(l *types.Log) bool {
+			logs = append(logs, l)
+			return true
+		})
+
+	return
+}
+
+// ForEachInBlocksByAddresses matches log records of block range by the addresses set and topics pattern.
+func (tt *index) ForEachInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash, onLog func(*types.Log) (gonext bool)) error {
+	return tt.forEachInBlocksByAddresses(ctx, from, to, addresses, topics, onLog, tt.ForEachInBlocks)
+}
+
 func doNothing() {}
 
 // Push log record to database batch
@@ -123,6 +147,9 @@ func (tt *index) Push(recs ...*types.Log) error {
 		if err := pushIndex(rec.Address.Hash()); err != nil {
 			return err
 		}
+		if err := tt.table.Address.Put(addressKey(rec.Address, rec.BlockNumber), []byte{}); err != nil {
+			return err
+		}
 
 		for _, topic := range rec.Topics {
 			if err := pushIndex(topic); err != nil {
@@ -135,7 +162,38 @@ func (tt *index) Push(recs ...*types.Log) error {
 	return nil
 }
 
+// ReindexAddresses fills the address->blocks index from the topics index,
+// it is needed for the log records pushed before the address index was introduced.
+func (tt *index) ReindexAddresses() error {
+	it := tt.table.Topic.NewIterator(nil, nil)
+	defer it.Release()
+	batch := tt.table.Address.NewBatch()
+	defer batch.Reset()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != topicKeySize || bytesToPos(key[hashSize:]) != 0 {
+			continue
+		}
+		addr := common.BytesToAddress(key[:hashSize])
+		id := extractLogrecID(key)
+		if err := batch.Put(addressKey(addr, id.BlockNumber()), []byte{}); err != nil {
+			return err
+		}
+		if batch.ValueSize() > sesadb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
 func (tt *index) Close() {
 	_ = tt.table.Topic.Close()
 	_ = tt.table.Logrec.Close()
+	_ = tt.table.Address.Close()
 }
@@ -10,9 +10,10 @@ const (
 	uint64Size = 8
 	hashSize   = common.HashLength
 
-	logrecKeySize = uint64Size + hashSize + uint64Size
-	topicKeySize  = hashSize + uint8Size + logrecKeySize
-	otherKeySize  = logrecKeySize + uint8Size
+	logrecKeySize  = uint64Size + hashSize + uint64Size
+	topicKeySize   = hashSize + uint8Size + logrecKeySize
+	otherKeySize   = logrecKeySize + uint8Size
+	addressKeySize = common.AddressLength + uint64Size
 )
 
 type (
@@ -55,6 +56,22 @@ func topicKey(topic common.Hash, pos uint8, logrec ID) []byte {
 	return key
 }
 
+func addressKey(addr common.Address, block uint64) []byte {
+	key := make([]byte, 0, addressKeySize)
+
+	key = append(key, addr.Bytes()...)
+	key = append(key, uintToBytes(block)...)
+
+	return key
+}
+
+func extractAddressBlock(key []byte) uint64 {
+	if len(key) != addressKeySize {
+		panic("wrong key type")
+	}
+	return bytesToUint(key[common.AddressLength:])
+}
+
 func posToBytes(pos uint8) []byte {
 	return []byte{pos}
 }
@@ -0,0 +1,66 @@
+package topicsdb
+
+import (
+	"bytes"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common"
+)
+
+// Prune deletes the log records of the blocks below the until block, including their
+// topics and address indexes. It returns the number of the deleted log records.
+func (tt *index) Prune(until idx.Block) (pruned int, err error) {
+	it := tt.table.Logrec.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		var id ID
+		copy(id[:], it.Key())
+		if id.BlockNumber() >= uint64(until) {
+			break
+		}
+
+		addr, topics, ok := tt.recoverIndexKeys(id, it.Value())
+		if ok {
+			if err = tt.table.Topic.Delete(topicKey(addr.Hash(), 0, id)); err != nil {
+				return
+			}
+			for i, topic := range topics {
+				if err = tt.table.Topic.Delete(topicKey(topic, uint8(i+1), id)); err != nil {
+					return
+				}
+			}
+			if err = tt.table.Address.Delete(addressKey(addr, id.BlockNumber())); err != nil {
+				return
+			}
+		}
+		if err = tt.table.Logrec.Delete(id.Bytes()); err != nil {
+			return
+		}
+		pruned++
+	}
+	err = it.Error()
+	return
+}
+
+// recoverIndexKeys parses the address and topics of a log record. The topics count
+// isn't stored in the record itself, so it's checked against the address index.
+func (tt *index) recoverIndexKeys(id ID, buf []byte) (addr common.Address, topics []common.Hash, ok bool) {
+	for n := 0; n <= maxTopicsCount; n++ {
+		offset := n*common.HashLength + common.HashLength
+		if len(buf) < offset+common.AddressLength {
+			return
+		}
+		addr = common.BytesToAddress(buf[offset : offset+common.AddressLength])
+		count, err := tt.table.Topic.Get(topicKey(addr.Hash(), 0, id))
+		if err != nil || !bytes.Equal(count, posToBytes(uint8(n))) {
+			continue
+		}
+		topics = make([]common.Hash, n)
+		for i := range topics {
+			topics[i] = common.BytesToHash(buf[i*common.HashLength : (i+1)*common.HashLength])
+		}
+		ok = true
+		return
+	}
+	return
+}
@@ -0,0 +1,201 @@
+package topicsdb
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+
+	"github.com/sesanetwork/go-vassalo/native/idx"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/core/types"
+)
+
+const (
+	// MaxParallelAddresses is the max addresses count which is searched by the
+	// parallel topics search, bigger address sets are searched by the address index.
+	MaxParallelAddresses = 8
+	// addressesWindow is a number of blocks which are scanned in a single pass
+	// over the addresses set.
+	addressesWindow = 4096
+)
+
+type (
+	patternSearch func(ctx context.Context, from, to idx.Block, pattern [][]common.Hash, onLog func(*types.Log) (gonext bool)) error
+
+	addressBlock struct {
+		addr  common.Address
+		block uint64
+	}
+)
+
+// forEachInBlocksByAddresses matches log records of block range by the addresses set and topics pattern.
+// Small address sets with a topics pattern are passed to byPattern, while the rest are searched by the address index.
+func (tt *index) forEachInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash, onLog func(*types.Log) (gonext bool), byPattern patternSearch) error {
+	if 0 < to && to < from {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	addresses = uniqAddresses(addresses)
+	topics = limitTopics(topics)
+	if len(addresses) == 0 || (len(addresses) <= MaxParallelAddresses && !isEmptyPattern(topics)) {
+		pattern := make([][]common.Hash, 1, len(topics)+1)
+		pattern[0] = make([]common.Hash, len(addresses))
+		for i, addr := range addresses {
+			pattern[0][i] = addr.Hash()
+		}
+		pattern = append(pattern, topics...)
+		return byPattern(ctx, from, to, pattern, onLog)
+	}
+
+	return tt.searchByAddresses(ctx, addresses, topics, uint64(from), uint64(to), onLog)
+}
+
+// searchByAddresses walks over the blocks which contain logs of the addresses (using the address index)
+// window by window, and matches the logs of these blocks against the topics pattern.
+func (tt *index) searchByAddresses(ctx context.Context, addresses []common.Address, topics [][]common.Hash, blockStart, blockEnd uint64, onLog func(*types.Log) (gonext bool)) error {
+	for windowStart := blockStart; blockEnd == 0 || windowStart <= blockEnd; {
+		windowEnd := windowStart + addressesWindow - 1
+		if windowEnd < windowStart {
+			windowEnd = math.MaxUint64
+		}
+		if blockEnd > 0 && windowEnd > blockEnd {
+			windowEnd = blockEnd
+		}
+
+		found, next, err := tt.scanAddressesWindow(ctx, addresses, windowStart, windowEnd)
+		if err != nil {
+			return err
+		}
+
+		for len(found) > 0 {
+			block := found[0].block
+			n := 1
+			for n < len(found) && found[n].block == block {
+				n++
+			}
+			gonext, err := tt.matchBlockLogs(ctx, found[:n], topics, onLog)
+			if err != nil || !gonext {
+				return err
+			}
+			found = found[n:]
+		}
+
+		// skip the blocks without logs of the addresses
+		if next == math.MaxUint64 {
+			break
+		}
+		windowStart = next
+	}
+	return ctx.Err()
+}
+
+// scanAddressesWindow returns the ordered (block, address) pairs of the window
+// and the lowest block after the window which contains logs of the addresses.
+func (tt *index) scanAddressesWindow(ctx context.Context, addresses []common.Address, windowStart, windowEnd uint64) (found []addressBlock, next uint64, err error) {
+	next = math.MaxUint64
+	for _, addr := range addresses {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		it := tt.table.Address.NewIterator(addr.Bytes(), uintToBytes(windowStart))
+		for it.Next() {
+			block := extractAddressBlock(it.Key())
+			if block > windowEnd {
+				if block < next {
+					next = block
+				}
+				break
+			}
+			found = append(found, addressBlock{addr, block})
+		}
+		err = it.Error()
+		it.Release()
+		if err != nil {
+			return
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].block != found[j].block {
+			return found[i].block < found[j].block
+		}
+		return bytes.Compare(found[i].addr.Bytes(), found[j].addr.Bytes()) < 0
+	})
+	return
+}
+
+// matchBlockLogs calls onLog for each log record of the (single block) addresses which matches the topics pattern.
+func (tt *index) matchBlockLogs(ctx context.Context, found []addressBlock, topics [][]common.Hash, onLog func(*types.Log) (gonext bool)) (gonext bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	var recs []*logrec
+	for _, ab := range found {
+		prefix := append(ab.addr.Hash().Bytes(), posToBytes(0)...)
+		it := tt.table.Topic.NewIterator(prefix, uintToBytes(ab.block))
+		for it.Next() {
+			id := extractLogrecID(it.Key())
+			if id.BlockNumber() != ab.block {
+				break
+			}
+			topicsCount := bytesToPos(it.Value())
+			if topicsCount < uint8(len(topics)) {
+				continue
+			}
+			recs = append(recs, newLogrec(id, topicsCount))
+		}
+		err = it.Error()
+		it.Release()
+		if err != nil {
+			return
+		}
+	}
+
+	sort.Slice(recs, func(i, j int) bool {
+		return bytes.Compare(recs[i].ID.Bytes(), recs[j].ID.Bytes()) < 0
+	})
+
+	for _, rec := range recs {
+		rec.fetch(tt.table.Logrec)
+		if rec.err != nil {
+			err = rec.err
+			return
+		}
+		if !matchTopics(rec.result.Topics, topics) {
+			continue
+		}
+		if !onLog(rec.result) {
+			return
+		}
+	}
+	gonext = true
+	return
+}
+
+func matchTopics(got []common.Hash, pattern [][]common.Hash) bool {
+	if len(pattern) > len(got) {
+		return false
+	}
+	for i, variants := range pattern {
+		if len(variants) == 0 {
+			continue
+		}
+		match := false
+		for _, topic := range variants {
+			if got[i] == topic {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
@@ -2,9 +2,11 @@ package topicsdb
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/sesanetwork/go-vassalo/hash"
 	"github.com/sesanetwork/go-vassalo/native/idx"
 	"github.com/sesanetwork/go-sesa/common"
 	"github.com/sesanetwork/go-sesa/core/types"
@@ -50,3 +52,52 @@ func BenchmarkSearch(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkSearchByAddresses(b *testing.B) {
+	const (
+		addressesCount = 500
+		blocksCount    = 10000
+	)
+
+	addresses := make([]common.Address, addressesCount)
+	for i := range addresses {
+		addresses[i] = randAddress()
+	}
+	topic := hash.FakeHash(1)
+
+	index := newTestIndex()
+	for i := 0; i < blocksCount; i++ {
+		err := index.Push(&types.Log{
+			BlockNumber: uint64(i),
+			TxHash:      hash.FakeHash(int64(i)),
+			Address:     addresses[i%len(addresses)],
+			Topics:      []common.Hash{topic},
+		})
+		require.NoError(b, err)
+	}
+
+	pooled := withThreadPool{index}
+
+	for _, count := range []int{MaxParallelAddresses, 50, addressesCount} {
+		aa := addresses[:count]
+		pattern := [][]common.Hash{make([]common.Hash, count)}
+		for i, addr := range aa {
+			pattern[0][i] = addr.Hash()
+		}
+
+		b.Run(fmt.Sprintf("parallel-%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := pooled.FindInBlocks(nil, 0, blocksCount, pattern)
+				require.NoError(b, err)
+			}
+		})
+		b.Run(fmt.Sprintf("addresses-%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				err := index.searchByAddresses(context.Background(), aa, nil, 0, blocksCount, func(*types.Log) bool {
+					return true
+				})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
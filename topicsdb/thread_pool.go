@@ -97,6 +97,25 @@ func (tt *withThreadPool) ForEachInBlocks(ctx context.Context, from, to idx.Bloc
 	return nil
 }
 
+// FindInBlocksByAddresses returns all log records of block range by the addresses set and topics pattern.
+func (tt *withThreadPool) FindInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash) (logs []*types.Log, err error) {
+	err = tt.ForEachInBlocksByAddresses(
+		ctx,
+		from, to,
+		addresses, topics,
+		func(l *types.Log) bool {
+			logs = append(logs, l)
+			return true
+		})
+
+	return
+}
+
+// ForEachInBlocksByAddresses matches log records of block range by the addresses set and topics pattern.
+func (tt *withThreadPool) ForEachInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash, onLog func(*types.Log) (gonext bool)) error {
+	return tt.forEachInBlocksByAddresses(ctx, from, to, addresses, topics, onLog, tt.ForEachInBlocks)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
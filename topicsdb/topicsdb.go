@@ -21,7 +21,11 @@ var (
 type Index interface {
 	FindInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash) (logs []*types.Log, err error)
 	ForEachInBlocks(ctx context.Context, from, to idx.Block, pattern [][]common.Hash, onLog func(*types.Log) (gonext bool)) error
+	FindInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash) (logs []*types.Log, err error)
+	ForEachInBlocksByAddresses(ctx context.Context, from, to idx.Block, addresses []common.Address, topics [][]common.Hash, onLog func(*types.Log) (gonext bool)) error
 	Push(recs ...*types.Log) error
+	ReindexAddresses() error
+	Prune(until idx.Block) (pruned int, err error)
 	Close()
 
 	WrapTablesAsBatched() (unwrap func())
@@ -75,3 +79,41 @@ func uniqOnly(hh []common.Hash) []common.Hash {
 	}
 	return uniq
 }
+
+func limitTopics(topics [][]common.Hash) (limited [][]common.Hash) {
+	if len(topics) > maxTopicsCount {
+		limited = make([][]common.Hash, maxTopicsCount)
+	} else {
+		limited = make([][]common.Hash, len(topics))
+	}
+	copy(limited, topics)
+
+	for i, variants := range limited {
+		if len(variants) > 1 {
+			limited[i] = uniqOnly(variants)
+		}
+	}
+	return
+}
+
+func isEmptyPattern(pattern [][]common.Hash) bool {
+	for _, variants := range pattern {
+		if len(variants) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqAddresses(aa []common.Address) []common.Address {
+	index := make(map[common.Address]struct{}, len(aa))
+	uniq := make([]common.Address, 0, len(aa))
+	for _, a := range aa {
+		if _, ok := index[a]; ok {
+			continue
+		}
+		index[a] = struct{}{}
+		uniq = append(uniq, a)
+	}
+	return uniq
+}
@@ -505,6 +505,190 @@ func TestKvdbThreadsPoolLimit(t *testing.T) {
 	}
 }
 
+func TestIndexSearchByAddresses(t *testing.T) {
+	logger.SetTestMode(t)
+
+	var (
+		topics    = []common.Hash{hash.FakeHash(1), hash.FakeHash(2), hash.FakeHash(3)}
+		addresses = make([]common.Address, 20)
+		testdata  = make([]*types.Log, 0, 1000)
+	)
+	for i := range addresses {
+		addresses[i] = randAddress()
+	}
+	for i := 0; i < cap(testdata); i++ {
+		testdata = append(testdata, &types.Log{
+			// span a few address index windows
+			BlockNumber: uint64(i * 13),
+			BlockHash:   hash.FakeHash(int64(i * 13)),
+			TxHash:      hash.FakeHash(int64(i)),
+			Index:       uint(i),
+			Address:     addresses[rand.Intn(len(addresses))],
+			Topics:      topics[:rand.Intn(len(topics)+1)],
+		})
+	}
+
+	index := newTestIndex()
+	for _, l := range testdata {
+		require.NoError(t, index.Push(l))
+	}
+
+	expect := func(from, to idx.Block, aa []common.Address, pattern [][]common.Hash) map[ID]bool {
+		res := make(map[ID]bool)
+		for _, l := range testdata {
+			if l.BlockNumber < uint64(from) || (to > 0 && l.BlockNumber > uint64(to)) {
+				continue
+			}
+			if len(aa) > 0 && !includesAddress(aa, l.Address) {
+				continue
+			}
+			if !matchTopics(l.Topics, pattern) {
+				continue
+			}
+			res[NewID(l.BlockNumber, l.TxHash, l.Index)] = true
+		}
+		return res
+	}
+
+	pooled := withThreadPool{index}
+
+	for dsc, method := range map[string]func(context.Context, idx.Block, idx.Block, []common.Address, [][]common.Hash) ([]*types.Log, error){
+		"index":  index.FindInBlocksByAddresses,
+		"pooled": pooled.FindInBlocksByAddresses,
+	} {
+		t.Run(dsc, func(t *testing.T) {
+			for _, aa := range [][]common.Address{
+				addresses[:1],
+				addresses[:MaxParallelAddresses],
+				addresses[:MaxParallelAddresses+1],
+				addresses,
+			} {
+				for _, pattern := range [][][]common.Hash{
+					{},
+					{{topics[0]}},
+					{{}, {topics[1]}},
+					{{topics[0], topics[2]}, {topics[1]}, {topics[2]}},
+				} {
+					for _, blocks := range [][2]idx.Block{
+						{0, 0},
+						{0, 0xffffffff},
+						{1000, 9000},
+						{5000, 0},
+					} {
+						require := require.New(t)
+
+						got, err := method(nil, blocks[0], blocks[1], aa, pattern)
+						require.NoError(err)
+
+						exp := expect(blocks[0], blocks[1], aa, pattern)
+						require.Equal(len(exp), len(got))
+						for _, l := range got {
+							require.True(exp[NewID(l.BlockNumber, l.TxHash, l.Index)])
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestIndexReindexAddresses(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	_, testdata, _ := genTestData(100)
+	index := newTestIndex()
+	for _, l := range testdata {
+		require.NoError(index.Push(l))
+	}
+
+	addresses := make([]common.Address, len(testdata))
+	for i, l := range testdata {
+		addresses[i] = l.Address
+	}
+
+	got, err := index.FindInBlocksByAddresses(nil, 0, 0, addresses, nil)
+	require.NoError(err)
+	require.Equal(len(testdata), len(got))
+
+	// drop the address index as it would be in a DB before the index was introduced
+	it := index.table.Address.NewIterator(nil, nil)
+	for it.Next() {
+		require.NoError(index.table.Address.Delete(common.CopyBytes(it.Key())))
+	}
+	it.Release()
+
+	got, err = index.FindInBlocksByAddresses(nil, 0, 0, addresses, nil)
+	require.NoError(err)
+	require.Empty(got)
+
+	require.NoError(index.ReindexAddresses())
+
+	got, err = index.FindInBlocksByAddresses(nil, 0, 0, addresses, nil)
+	require.NoError(err)
+	require.Equal(len(testdata), len(got))
+}
+
+func TestIndexPrune(t *testing.T) {
+	logger.SetTestMode(t)
+	require := require.New(t)
+
+	topics, testdata, _ := genTestData(100)
+	index := newTestIndex()
+	for _, l := range testdata {
+		require.NoError(index.Push(l))
+	}
+
+	const until = 10
+	expect := 0
+	for _, l := range testdata {
+		if l.BlockNumber < until {
+			expect++
+		}
+	}
+
+	pruned, err := index.Prune(until)
+	require.NoError(err)
+	require.Equal(expect, pruned)
+
+	got, err := index.FindInBlocks(nil, 0, 0, [][]common.Hash{nil, topics})
+	require.NoError(err)
+	require.Equal(len(testdata)-expect, len(got))
+	for _, l := range got {
+		require.GreaterOrEqual(l.BlockNumber, uint64(until))
+	}
+
+	addresses := make([]common.Address, len(testdata))
+	for i, l := range testdata {
+		addresses[i] = l.Address
+	}
+	got, err = index.FindInBlocksByAddresses(nil, 0, until-1, addresses, nil)
+	require.NoError(err)
+	require.Empty(got)
+
+	// no index keys of the pruned records are left
+	it := index.table.Topic.NewIterator(nil, nil)
+	for it.Next() {
+		id := extractLogrecID(it.Key())
+		require.GreaterOrEqual(id.BlockNumber(), uint64(until))
+	}
+	it.Release()
+	it = index.table.Address.NewIterator(nil, nil)
+	for it.Next() {
+		require.GreaterOrEqual(extractAddressBlock(it.Key()), uint64(until))
+	}
+	it.Release()
+}
+
+func includesAddress(aa []common.Address, a common.Address) bool {
+	for _, addr := range aa {
+		if addr == a {
+			return true
+		}
+	}
+	return false
+}
+
 func genTestData(count int) (
 	topics []common.Hash,
 	recs []*types.Log,
@@ -1,18 +1,36 @@
 package tracing
 
 import (
+	"context"
 	"sync"
 
-	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/sesanetwork/go-sesa/common"
 )
 
+const tracerName = "github.com/sesanetwork/go-sesa/tracing"
+
+// Attribute keys of the tx lifecycle spans.
+var (
+	TxHashKey  = attribute.Key("txhash")
+	EventKey   = attribute.Key("event")
+	EpochKey   = attribute.Key("epoch")
+	BlockKey   = attribute.Key("block")
+	AtroposKey = attribute.Key("atropos")
+	PeersKey   = attribute.Key("peers")
+	StatusKey  = attribute.Key("status")
+)
+
 var (
 	enabled   bool
-	txSpans   = make(map[common.Hash]opentracing.Span)
+	txSpans   = make(map[common.Hash]trace.Span)
 	txSpansMu sync.RWMutex
 
-	noopSpan = opentracing.NoopTracer{}.StartSpan("")
+	noopSpan = trace.SpanFromContext(context.Background())
 )
 
 func SetEnabled(val bool) {
@@ -23,6 +41,11 @@ func Enabled() bool {
 	return enabled
 }
 
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartTx starts the lifecycle span of the tx, which is the parent of all the tx stages spans.
 func StartTx(tx common.Hash, operation string) {
 	if !enabled {
 		return
@@ -35,13 +58,34 @@ func StartTx(tx common.Hash, operation string) {
 		return
 	}
 
-	span := opentracing.StartSpan("lifecycle")
-	span.SetTag("txhash", tx.String())
-	span.SetTag("enter", operation)
+	_, span := tracer().Start(context.Background(), "lifecycle", trace.WithAttributes(
+		TxHashKey.String(tx.String()),
+		attribute.String("enter", operation),
+	))
 	txSpans[tx] = span
 }
 
-func FinishTx(tx common.Hash, operation string) {
+// FinishTx ends the lifecycle span of the tx.
+func FinishTx(tx common.Hash, operation string, attrs ...attribute.KeyValue) {
+	if !enabled {
+		return
+	}
+
+	txSpansMu.Lock()
+	defer txSpansMu.Unlock()
+
+	span, ok := txSpans[tx]
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(append(attrs, attribute.String("exit", operation))...)
+	span.End()
+	delete(txSpans, tx)
+}
+
+// FailTx ends the lifecycle span of the tx with an error.
+func FailTx(tx common.Hash, operation string, err error) {
 	if !enabled {
 		return
 	}
@@ -54,12 +98,16 @@ func FinishTx(tx common.Hash, operation string) {
 		return
 	}
 
-	span.SetTag("exit", operation)
-	span.Finish()
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(attribute.String("exit", operation))
+	span.End()
 	delete(txSpans, tx)
 }
 
-func CheckTx(tx common.Hash, operation string) opentracing.Span {
+// CheckTx starts a span of the tx stage, the caller has to end it.
+// The returned span is a no-op if the tx lifecycle isn't traced.
+func CheckTx(tx common.Hash, operation string, attrs ...attribute.KeyValue) trace.Span {
 	if !enabled {
 		return noopSpan
 	}
@@ -68,13 +116,19 @@ func CheckTx(tx common.Hash, operation string) opentracing.Span {
 	defer txSpansMu.RUnlock()
 
 	span, ok := txSpans[tx]
-
 	if !ok {
 		return noopSpan
 	}
 
-	return opentracing.GlobalTracer().StartSpan(
-		operation,
-		opentracing.ChildOf(span.Context()),
-	)
+	ctx := trace.ContextWithSpan(context.Background(), span)
+	_, child := tracer().Start(ctx, operation, trace.WithAttributes(attrs...))
+	return child
+}
+
+// TxStage records an instant stage of the tx lifecycle.
+func TxStage(tx common.Hash, operation string, attrs ...attribute.KeyValue) {
+	if !enabled {
+		return
+	}
+	CheckTx(tx, operation, attrs...).End()
 }
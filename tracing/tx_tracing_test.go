@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/sesanetwork/go-sesa/common"
+)
+
+func TestTxLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	SetEnabled(true)
+	defer SetEnabled(false)
+
+	tx1 := common.HexToHash("0x01")
+	tx2 := common.HexToHash("0x02")
+	unknown := common.HexToHash("0x03")
+
+	StartTx(tx1, "SendTx")
+	StartTx(tx2, "SendTx")
+	TxStage(tx1, "TxPool.AddLocal")
+	span := CheckTx(tx1, "Emitter.EmitEvent", EpochKey.Int64(2), EventKey.String("0x0a"))
+	span.End()
+	TxStage(unknown, "TxPool.AddLocal")
+	FinishTx(tx1, "Block.Execute", BlockKey.Int64(5))
+	FailTx(tx2, "TxPool.AddLocal", errors.New("underpriced"))
+	// already finished
+	TxStage(tx1, "TxPool.AddLocal")
+	FinishTx(tx1, "Block.Execute")
+
+	spans := exporter.GetSpans()
+	require.Len(spans, 4)
+	require.Equal("TxPool.AddLocal", spans[0].Name)
+	require.Equal("Emitter.EmitEvent", spans[1].Name)
+	require.Contains(spans[1].Attributes, EpochKey.Int64(2))
+	require.Equal("lifecycle", spans[2].Name)
+	require.Contains(spans[2].Attributes, TxHashKey.String(tx1.String()))
+	require.Contains(spans[2].Attributes, BlockKey.Int64(5))
+	// stages are children of the lifecycle span
+	require.Equal(spans[2].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	require.Equal(spans[2].SpanContext.SpanID(), spans[1].Parent.SpanID())
+	require.Equal("lifecycle", spans[3].Name)
+	require.Equal(codes.Error, spans[3].Status.Code)
+}
@@ -0,0 +1,40 @@
+package checkpoint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sesanetwork/go-vassalo/sesadb"
+)
+
+// ErrNotSupported is returned for the DBs which aren't able to write their checkpoints.
+var ErrNotSupported = errors.New("DB checkpoints aren't supported")
+
+// Checkpointer is a DB which is able to write its checkpoint into a directory.
+// The DB wrappers implement it to pass the checkpoints to the wrapped DB.
+type Checkpointer interface {
+	Checkpoint(dir string) error
+}
+
+// pebbleDB is a pebble DB which exposes its storage engine.
+type pebbleDB interface {
+	UnderlyingDB() *pebble.DB
+}
+
+// WriteCheckpoint writes the checkpoint of the DB into the directory, which must not exist.
+// A pebble checkpoint hard-links the SST files of the DB, so it takes no time and space
+// regardless of the DB size. It returns ErrNotSupported for the other DBs.
+func WriteCheckpoint(db sesadb.Store, dir string) error {
+	switch db := db.(type) {
+	case Checkpointer:
+		return db.Checkpoint(dir)
+	case pebbleDB:
+		if err := os.MkdirAll(filepath.Dir(dir), 0700); err != nil {
+			return err
+		}
+		return db.UnderlyingDB().Checkpoint(dir, pebble.WithFlushedWAL())
+	}
+	return ErrNotSupported
+}
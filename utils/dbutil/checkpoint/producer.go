@@ -0,0 +1,201 @@
+package checkpoint
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"github.com/sesanetwork/go-vassalo/sesadb"
+	"github.com/sesanetwork/go-sesa/common"
+	"github.com/sesanetwork/go-sesa/crypto"
+)
+
+// Producer is a DB producer which tracks the opened DBs,
+// to take consistent snapshots of all the DBs at once.
+type Producer struct {
+	sesadb.IterableDBProducer
+	mu  sync.Mutex
+	dbs map[string]*store
+}
+
+func Wrap(backend sesadb.IterableDBProducer) *Producer {
+	return &Producer{
+		IterableDBProducer: backend,
+		dbs:                make(map[string]*store),
+	}
+}
+
+func (p *Producer) OpenDB(name string) (sesadb.Store, error) {
+	db, err := p.IterableDBProducer.OpenDB(name)
+	if err != nil {
+		return nil, err
+	}
+	wrapped := &store{
+		Store: db,
+	}
+	wrapped.CloseFn = func() error {
+		p.mu.Lock()
+		if p.dbs[name] == wrapped {
+			delete(p.dbs, name)
+		}
+		p.mu.Unlock()
+		return db.Close()
+	}
+	p.mu.Lock()
+	p.dbs[name] = wrapped
+	p.mu.Unlock()
+	return wrapped, nil
+}
+
+// Snapshots takes the snapshots of all the DBs of the producer, including the DBs
+// which aren't opened at the moment. The caller has to pause writes into the DBs
+// to get a consistent view of them, and has to release the snapshots.
+func (p *Producer) Snapshots() (map[string]sesadb.Snapshot, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := p.allNames()
+	snapshots := make(map[string]sesadb.Snapshot, len(names))
+	for _, name := range names {
+		var (
+			snap sesadb.Snapshot
+			err  error
+		)
+		if db := p.dbs[name]; db != nil {
+			snap, err = db.GetSnapshot()
+		} else {
+			snap, err = p.openSnapshot(name)
+		}
+		if err != nil {
+			for _, s := range snapshots {
+				s.Release()
+			}
+			return nil, fmt.Errorf("failed to snapshot %s DB: %v", name, err)
+		}
+		snapshots[name] = snap
+	}
+	return snapshots, nil
+}
+
+// Checkpoints writes the checkpoints of all the DBs of the producer into the subdirectories
+// of dir by DB name, including the DBs which aren't opened at the moment. It returns
+// ErrNotSupported if the DBs aren't able to write their checkpoints, without writing anything.
+// The caller has to pause writes into the DBs to get a consistent view of them.
+func (p *Producer) Checkpoints(dir string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	names := p.allNames()
+	for i, name := range names {
+		var err error
+		if db := p.dbs[name]; db != nil {
+			err = db.Checkpoint(filepath.Join(dir, name))
+		} else {
+			err = p.openCheckpoint(name, filepath.Join(dir, name))
+		}
+		if err == ErrNotSupported && i == 0 {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to checkpoint %s DB: %v", name, err)
+		}
+	}
+	return names, nil
+}
+
+// allNames returns the names of all the DBs of the producer, including the opened DBs
+// which aren't written to the disk yet.
+func (p *Producer) allNames() []string {
+	names := p.Names()
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	for name := range p.dbs {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// openCheckpoint opens the DB for the checkpoint writing.
+func (p *Producer) openCheckpoint(name, dir string) error {
+	db, err := p.IterableDBProducer.OpenDB(name)
+	if err != nil {
+		return err
+	}
+	err = WriteCheckpoint(db, dir)
+	if err != nil {
+		_ = db.Close()
+		return err
+	}
+	return db.Close()
+}
+
+// openSnapshot opens the DB for the snapshot lifetime.
+func (p *Producer) openSnapshot(name string) (sesadb.Snapshot, error) {
+	db, err := p.IterableDBProducer.OpenDB(name)
+	if err != nil {
+		return nil, err
+	}
+	snap, err := db.GetSnapshot()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &closingSnapshot{
+		Snapshot: snap,
+		db:       db,
+	}, nil
+}
+
+// Copy writes all the records of src into dst. It returns the number of copied records.
+func Copy(dst sesadb.Store, src sesadb.Iteratee) (uint64, error) {
+	it := src.NewIterator(nil, nil)
+	defer it.Release()
+
+	batch := dst.NewBatch()
+	defer batch.Reset()
+	records := uint64(0)
+	for it.Next() {
+		err := batch.Put(it.Key(), it.Value())
+		if err != nil {
+			return records, err
+		}
+		records++
+		if batch.ValueSize() > sesadb.IdealBatchSize {
+			err := batch.Write()
+			if err != nil {
+				return records, err
+			}
+			batch.Reset()
+		}
+	}
+	if it.Error() != nil {
+		return records, it.Error()
+	}
+	return records, batch.Write()
+}
+
+// Digest returns the number of records in the DB and the hash of all its keys and values.
+func Digest(db sesadb.Iteratee) (uint64, common.Hash, error) {
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	hasher := crypto.NewKeccakState()
+	size := make([]byte, 4)
+	records := uint64(0)
+	for it.Next() {
+		for _, b := range [][]byte{it.Key(), it.Value()} {
+			binary.BigEndian.PutUint32(size, uint32(len(b)))
+			_, _ = hasher.Write(size)
+			_, _ = hasher.Write(b)
+		}
+		records++
+	}
+	var digest common.Hash
+	_, _ = hasher.Read(digest[:])
+	return records, digest, it.Error()
+}
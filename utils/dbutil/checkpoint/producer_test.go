@@ -0,0 +1,115 @@
+package checkpoint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-vassalo/common/bigendian"
+	"github.com/sesanetwork/go-vassalo/sesadb"
+	"github.com/sesanetwork/go-vassalo/sesadb/memorydb"
+)
+
+func TestProducer_Snapshots(t *testing.T) {
+	require := require.New(t)
+
+	producer := Wrap(memorydb.NewProducer(""))
+	db1, err := producer.OpenDB("db1")
+	require.NoError(err)
+	db2, err := producer.OpenDB("db2")
+	require.NoError(err)
+	for i := uint32(0); i < 100; i++ {
+		require.NoError(db1.Put(bigendian.Uint32ToBytes(i), []byte{1}))
+		require.NoError(db2.Put(bigendian.Uint32ToBytes(i*2), []byte{2}))
+	}
+
+	snapshots, err := producer.Snapshots()
+	require.NoError(err)
+	require.Len(snapshots, 2)
+
+	// writes after the snapshot aren't visible
+	require.NoError(db1.Put(bigendian.Uint32ToBytes(1000), []byte{1}))
+	require.NoError(db2.Delete(bigendian.Uint32ToBytes(0)))
+
+	dst := memorydb.NewProducer("")
+	for name, snap := range snapshots {
+		db, err := dst.OpenDB(name)
+		require.NoError(err)
+		records, err := Copy(db, snap)
+		require.NoError(err)
+		require.Equal(uint64(100), records)
+		count, digest, err := Digest(db)
+		require.NoError(err)
+		require.Equal(uint64(100), count)
+		_, snapDigest, err := Digest(snap)
+		require.NoError(err)
+		require.Equal(snapDigest, digest)
+		snap.Release()
+	}
+
+	// closed DBs aren't tracked
+	require.NoError(db2.Close())
+	producer.mu.Lock()
+	require.Len(producer.dbs, 1)
+	producer.mu.Unlock()
+	require.NoError(db1.Close())
+}
+
+// checkpointingProducer is a DB producer which DBs record their checkpoints.
+type checkpointingProducer struct {
+	sesadb.IterableDBProducer
+	checkpoints map[string]string
+}
+
+type checkpointingStore struct {
+	sesadb.Store
+	name     string
+	producer *checkpointingProducer
+}
+
+func (p *checkpointingProducer) OpenDB(name string) (sesadb.Store, error) {
+	db, err := p.IterableDBProducer.OpenDB(name)
+	if err != nil {
+		return nil, err
+	}
+	return &checkpointingStore{db, name, p}, nil
+}
+
+func (s *checkpointingStore) Checkpoint(dir string) error {
+	s.producer.checkpoints[s.name] = dir
+	return nil
+}
+
+func TestProducer_Checkpoints(t *testing.T) {
+	require := require.New(t)
+
+	// memory DBs aren't able to write their checkpoints
+	producer := Wrap(memorydb.NewProducer(""))
+	db1, err := producer.OpenDB("db1")
+	require.NoError(err)
+	_, err = producer.Checkpoints("checkpoints")
+	require.Equal(ErrNotSupported, err)
+	require.NoError(db1.Close())
+
+	backend := &checkpointingProducer{
+		IterableDBProducer: memorydb.NewProducer(""),
+		checkpoints:        make(map[string]string),
+	}
+	producer = Wrap(backend)
+	db1, err = producer.OpenDB("db1")
+	require.NoError(err)
+	db2, err := producer.OpenDB("db2")
+	require.NoError(err)
+	require.NoError(db2.Put([]byte{1}, []byte{2}))
+
+	names, err := producer.Checkpoints("checkpoints")
+	require.NoError(err)
+	require.ElementsMatch([]string{"db1", "db2"}, names)
+	require.Equal(map[string]string{
+		"db1": filepath.Join("checkpoints", "db1"),
+		"db2": filepath.Join("checkpoints", "db2"),
+	}, backend.checkpoints)
+	require.NoError(db1.Close())
+	require.NoError(db2.Close())
+}
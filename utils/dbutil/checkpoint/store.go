@@ -0,0 +1,27 @@
+package checkpoint
+
+import "github.com/sesanetwork/go-vassalo/sesadb"
+
+type store struct {
+	sesadb.Store
+	CloseFn func() error
+}
+
+func (s *store) Close() error {
+	return s.CloseFn()
+}
+
+func (s *store) Checkpoint(dir string) error {
+	return WriteCheckpoint(s.Store, dir)
+}
+
+// closingSnapshot closes the DB which is opened only for the snapshot.
+type closingSnapshot struct {
+	sesadb.Snapshot
+	db sesadb.Store
+}
+
+func (s *closingSnapshot) Release() {
+	s.Snapshot.Release()
+	_ = s.db.Close()
+}
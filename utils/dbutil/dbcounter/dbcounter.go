@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 
 	"github.com/sesanetwork/go-sesa/log"
+	"github.com/sesanetwork/go-sesa/utils/dbutil/checkpoint"
 
 	"github.com/sesanetwork/go-vassalo/sesadb"
 )
@@ -91,6 +92,10 @@ func (ds *Store) GetSnapshot() (sesadb.Snapshot, error) {
 	}, nil
 }
 
+func (ds *Store) Checkpoint(dir string) error {
+	return checkpoint.WriteCheckpoint(ds.Store, dir)
+}
+
 func (db *DBProducer) OpenDB(name string) (sesadb.Store, error) {
 	s, err := db.IterableDBProducer.OpenDB(name)
 	if err != nil {
@@ -0,0 +1,172 @@
+package valkeystore
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+)
+
+const (
+	remoteSignPath    = "/sign"
+	remoteSignTimeout = 5 * time.Second
+)
+
+var ErrEventPositionRequired = errors.New("remote signer signs only events with known position")
+
+type (
+	// RemoteSignRequest is a request of the remote signer API.
+	RemoteSignRequest struct {
+		PubKey validatorpk.PubKey `json:"pubkey"`
+		EventPosition
+		Digest hexutil.Bytes `json:"digest"`
+	}
+
+	// RemoteSignResponse is a response of the remote signer API.
+	RemoteSignResponse struct {
+		Signature hexutil.Bytes `json:"signature,omitempty"`
+		Error     string        `json:"error,omitempty"`
+	}
+)
+
+// RemoteSigner signs events by a signer daemon which holds the validator keys,
+// so the keys never get into the node process.
+type RemoteSigner struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewRemoteSigner makes a client of the signer daemon at the endpoint,
+// which is either an HTTP URL or a unix socket path prefixed with "unix://".
+func NewRemoteSigner(endpoint, token string) (*RemoteSigner, error) {
+	s := &RemoteSigner{
+		token: token,
+		client: &http.Client{
+			Timeout: remoteSignTimeout,
+		},
+	}
+	switch {
+	case strings.HasPrefix(endpoint, "unix://"):
+		socket := strings.TrimPrefix(endpoint, "unix://")
+		s.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		s.url = "http://unix" + remoteSignPath
+	case strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://"):
+		s.url = strings.TrimSuffix(endpoint, "/") + remoteSignPath
+	default:
+		return nil, fmt.Errorf("unsupported remote signer endpoint %s", endpoint)
+	}
+	return s, nil
+}
+
+// Sign isn't supported, as the signer daemon cannot protect from the double-sign without the event position.
+func (s *RemoteSigner) Sign(pubkey validatorpk.PubKey, digest []byte) ([]byte, error) {
+	return nil, ErrEventPositionRequired
+}
+
+// SignEvent requests the signer daemon to sign the event digest.
+func (s *RemoteSigner) SignEvent(pubkey validatorpk.PubKey, pos EventPosition, digest []byte) ([]byte, error) {
+	// PubKey.MarshalText has a pointer receiver, so marshal the request by pointer
+	body, err := json.Marshal(&RemoteSignRequest{
+		PubKey:        pubkey,
+		EventPosition: pos,
+		Digest:        digest,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var res RemoteSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("remote signer: %s", resp.Status)
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return nil, ErrDoubleSign
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer: %s", res.Error)
+	}
+	return res.Signature, nil
+}
+
+// RemoteSignerServer serves the remote signer API over the local signer,
+// refusing the events which conflict with the already signed ones.
+// The guard trusts the event position (epoch, seq, lamport) claimed by the client,
+// as the signer daemon sees only the event digest.
+type RemoteSignerServer struct {
+	signer SignerI
+	guard  *SignGuard
+	token  string
+}
+
+// NewRemoteSignerServer makes the remote signer API handler. Requests are authenticated by the bearer token.
+func NewRemoteSignerServer(signer SignerI, guard *SignGuard, token string) *RemoteSignerServer {
+	return &RemoteSignerServer{
+		signer: signer,
+		guard:  guard,
+		token:  token,
+	}
+}
+
+func (s *RemoteSignerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != remoteSignPath || r.Method != http.MethodPost {
+		writeSignResponse(w, http.StatusNotFound, RemoteSignResponse{Error: "not found"})
+		return
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(auth), []byte(s.token)) != 1 {
+		writeSignResponse(w, http.StatusUnauthorized, RemoteSignResponse{Error: "unauthorized"})
+		return
+	}
+
+	var req RemoteSignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeSignResponse(w, http.StatusBadRequest, RemoteSignResponse{Error: err.Error()})
+		return
+	}
+
+	sig, err := s.guard.Sign(req.PubKey, req.EventPosition, req.Digest, func() ([]byte, error) {
+		return s.signer.Sign(req.PubKey, req.Digest)
+	})
+	switch {
+	case err == ErrDoubleSign:
+		writeSignResponse(w, http.StatusConflict, RemoteSignResponse{Error: err.Error()})
+	case err != nil:
+		writeSignResponse(w, http.StatusInternalServerError, RemoteSignResponse{Error: err.Error()})
+	default:
+		writeSignResponse(w, http.StatusOK, RemoteSignResponse{Signature: sig})
+	}
+}
+
+func writeSignResponse(w http.ResponseWriter, status int, res RemoteSignResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,146 @@
+package valkeystore
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sesanetwork/go-sesa/crypto"
+)
+
+func newTestRemoteSigner(t *testing.T, guard *SignGuard) (*RemoteSigner, func()) {
+	require := require.New(t)
+
+	keystore := NewDefaultMemKeystore()
+	require.NoError(keystore.Add(pubkey1, key1, "auth1"))
+	require.NoError(keystore.Unlock(pubkey1, "auth1"))
+
+	srv := httptest.NewServer(NewRemoteSignerServer(NewSigner(keystore), guard, "secret"))
+	signer, err := NewRemoteSigner(srv.URL, "secret")
+	require.NoError(err)
+	return signer, srv.Close
+}
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+
+	guard, err := NewSignGuard("")
+	require.NoError(err)
+	signer, stop := newTestRemoteSigner(t, guard)
+	defer stop()
+
+	digest := crypto.Keccak256([]byte("event1"))
+	sig, err := signer.SignEvent(pubkey1, EventPosition{Epoch: 1, Seq: 1, Lamport: 1}, digest)
+	require.NoError(err)
+	require.Len(sig, 64)
+
+	local, err := crypto.ToECDSA(key1)
+	require.NoError(err)
+	require.True(crypto.VerifySignature(crypto.FromECDSAPub(&local.PublicKey), digest, sig))
+
+	// the event position is required
+	_, err = signer.Sign(pubkey1, digest)
+	require.Equal(ErrEventPositionRequired, err)
+
+	// unknown key
+	_, err = signer.SignEvent(pubkey2, EventPosition{Epoch: 1, Seq: 1, Lamport: 1}, digest)
+	require.Error(err)
+
+	// wrong token
+	wrong, err := NewRemoteSigner(signer.url[:len(signer.url)-len(remoteSignPath)], "wrong")
+	require.NoError(err)
+	_, err = wrong.SignEvent(pubkey1, EventPosition{Epoch: 1, Seq: 2, Lamport: 2}, digest)
+	require.EqualError(err, "remote signer: unauthorized")
+}
+
+func TestRemoteSignerDoubleSign(t *testing.T) {
+	require := require.New(t)
+
+	guard, err := NewSignGuard("")
+	require.NoError(err)
+	signer, stop := newTestRemoteSigner(t, guard)
+	defer stop()
+
+	digest1 := crypto.Keccak256([]byte("event1"))
+	digest2 := crypto.Keccak256([]byte("event2"))
+
+	_, err = signer.SignEvent(pubkey1, EventPosition{Epoch: 2, Seq: 5, Lamport: 10}, digest1)
+	require.NoError(err)
+	// the same event may be signed again
+	_, err = signer.SignEvent(pubkey1, EventPosition{Epoch: 2, Seq: 5, Lamport: 10}, digest1)
+	require.NoError(err)
+
+	for _, pos := range []EventPosition{
+		{Epoch: 2, Seq: 5, Lamport: 10},
+		{Epoch: 2, Seq: 4, Lamport: 11},
+		{Epoch: 2, Seq: 6, Lamport: 10},
+		{Epoch: 1, Seq: 6, Lamport: 11},
+	} {
+		_, err = signer.SignEvent(pubkey1, pos, digest2)
+		require.Equal(ErrDoubleSign, err, pos)
+	}
+
+	_, err = signer.SignEvent(pubkey1, EventPosition{Epoch: 2, Seq: 6, Lamport: 11}, digest2)
+	require.NoError(err)
+	_, err = signer.SignEvent(pubkey1, EventPosition{Epoch: 3, Seq: 1, Lamport: 1}, digest1)
+	require.NoError(err)
+}
+
+func TestSignGuardPersistence(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "guard.json")
+	guard, err := NewSignGuard(path)
+	require.NoError(err)
+
+	sign := func() ([]byte, error) {
+		return []byte{1}, nil
+	}
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 3, Lamport: 3}, []byte{1}, sign)
+	require.NoError(err)
+
+	guard, err = NewSignGuard(path)
+	require.NoError(err)
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 3, Lamport: 3}, []byte{2}, sign)
+	require.Equal(ErrDoubleSign, err)
+	_, err = guard.Sign(pubkey2, EventPosition{Epoch: 1, Seq: 3, Lamport: 3}, []byte{2}, sign)
+	require.NoError(err)
+}
+
+func TestSignGuardFlushFailure(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "guard.json")
+	guard, err := NewSignGuard(path)
+	require.NoError(err)
+
+	sign := func() ([]byte, error) {
+		return []byte{1}, nil
+	}
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 3, Lamport: 3}, []byte{1}, sign)
+	require.NoError(err)
+
+	// the temporary file cannot be written while a directory occupies its path
+	require.NoError(os.Mkdir(path+".tmp", 0700))
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 4, Lamport: 4}, []byte{2}, sign)
+	require.Error(err)
+	require.NotEqual(ErrDoubleSign, err)
+	// nothing is signed until the records are saved
+	_, err = guard.Sign(pubkey2, EventPosition{Epoch: 1, Seq: 1, Lamport: 1}, []byte{3}, sign)
+	require.Error(err)
+
+	require.NoError(os.Remove(path + ".tmp"))
+	// the last signed event is still protected
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 3, Lamport: 3}, []byte{4}, sign)
+	require.Equal(ErrDoubleSign, err)
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 4, Lamport: 4}, []byte{2}, sign)
+	require.NoError(err)
+
+	guard, err = NewSignGuard(path)
+	require.NoError(err)
+	_, err = guard.Sign(pubkey1, EventPosition{Epoch: 1, Seq: 4, Lamport: 4}, []byte{5}, sign)
+	require.Equal(ErrDoubleSign, err)
+}
@@ -0,0 +1,142 @@
+package valkeystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/sesanetwork/go-sesa/common/hexutil"
+
+	"github.com/sesanetwork/go-sesa/native/validatorpk"
+)
+
+var ErrDoubleSign = errors.New("double-sign protection: conflicting event")
+
+// EventPosition is a position of a signed event in the validator's events chain.
+type EventPosition struct {
+	Epoch   uint32 `json:"epoch"`
+	Seq     uint32 `json:"seq"`
+	Lamport uint32 `json:"lamport"`
+}
+
+// EventSignerI is a SignerI which is aware of the signed events positions,
+// the emitter prefers it to provide the events for the double-sign protection.
+type EventSignerI interface {
+	SignerI
+	SignEvent(pubkey validatorpk.PubKey, pos EventPosition, digest []byte) ([]byte, error)
+}
+
+type signedEvent struct {
+	EventPosition
+	Digest hexutil.Bytes `json:"digest"`
+}
+
+// SignGuard records the last signed event per validator and refuses to sign
+// the events which conflict with it. The records are kept in a file (if any)
+// to survive restarts.
+type SignGuard struct {
+	path string
+	last map[string]signedEvent
+	// flushErr is the error of the last failed flush, nothing is signed until a flush succeeds
+	flushErr error
+
+	mu sync.Mutex
+}
+
+// NewSignGuard loads the last signed events from the file, the file is created on the first sign.
+// Empty path means the records are kept in memory only.
+func NewSignGuard(path string) (*SignGuard, error) {
+	g := &SignGuard{
+		path: path,
+		last: make(map[string]signedEvent),
+	}
+	if path == "" {
+		return g, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &g.last); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return g, nil
+}
+
+// Sign calls the sign func unless the event conflicts with the last signed event of the validator.
+func (g *SignGuard) Sign(pubkey validatorpk.PubKey, pos EventPosition, digest []byte, sign func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.flushErr != nil {
+		if err := g.flush(); err != nil {
+			return nil, fmt.Errorf("double-sign protection: records aren't saved: %v", err)
+		}
+		g.flushErr = nil
+	}
+
+	id := pubkey.String()
+	last, ok := g.last[id]
+	if ok {
+		if err := checkConflict(last, pos, digest); err != nil {
+			return nil, err
+		}
+	}
+
+	sig, err := sign()
+	if err != nil {
+		return nil, err
+	}
+
+	g.last[id] = signedEvent{pos, digest}
+	if err := g.flush(); err != nil {
+		// the signature isn't released, so the previous record is still the last signed event
+		if ok {
+			g.last[id] = last
+		} else {
+			delete(g.last, id)
+		}
+		g.flushErr = err
+		return nil, err
+	}
+	return sig, nil
+}
+
+func checkConflict(last signedEvent, pos EventPosition, digest []byte) error {
+	if pos.Epoch == last.Epoch && pos.Seq == last.Seq {
+		// signing the same event again is safe
+		if bytes.Equal(digest, last.Digest) {
+			return nil
+		}
+		return ErrDoubleSign
+	}
+	if pos.Epoch < last.Epoch {
+		return ErrDoubleSign
+	}
+	if pos.Epoch == last.Epoch && (pos.Seq < last.Seq || pos.Lamport <= last.Lamport) {
+		return ErrDoubleSign
+	}
+	return nil
+}
+
+func (g *SignGuard) flush() error {
+	if g.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(g.last)
+	if err != nil {
+		return err
+	}
+	tmp := g.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, g.path)
+}